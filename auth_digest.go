@@ -0,0 +1,240 @@
+package hit
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // required by the digest authentication scheme
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// digestTransport is a http.RoundTripper that performs the Digest authentication (RFC 7616) challenge/response round
+// trip.
+type digestTransport struct {
+	username  string
+	password  string
+	transport http.RoundTripper
+}
+
+func (t *digestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := t.transport.RoundTrip(cloneRequestWithBody(req, body))
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	challenge := findDigestChallenge(res.Header.Values("WWW-Authenticate"))
+	if challenge == nil {
+		// no supported challenge, let the caller handle the response
+		return res, nil
+	}
+	_, _ = io.Copy(ioutil.Discard, res.Body)
+	_ = res.Body.Close()
+
+	cnonce, err := newDigestCnonce()
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := challenge.authorization(req.Method, req.URL.RequestURI(), body, t.username, t.password, cnonce)
+	if err != nil {
+		return nil, err
+	}
+	authReq := cloneRequestWithBody(req, body)
+	authReq.Header.Set("Authorization", authorization)
+	return t.transport.RoundTrip(authReq)
+}
+
+// cloneRequestWithBody returns a copy of the request that uses the specified body.
+func cloneRequestWithBody(req *http.Request, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	if req.Body == nil {
+		return r
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+	return r
+}
+
+func newDigestCnonce() (string, error) {
+	buf := make([]byte, 16) //nolint:gomnd // 128 bit
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// digestChallenge is a parsed WWW-Authenticate Digest challenge.
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+	userhash  bool
+}
+
+// findDigestChallenge returns the first Digest challenge with a supported algorithm, nil if there is none.
+func findDigestChallenge(headers []string) *digestChallenge {
+	for _, header := range headers {
+		if len(header) < 7 || !strings.EqualFold(header[:7], "Digest ") {
+			continue
+		}
+		params := parseAuthParams(header[7:])
+		c := &digestChallenge{
+			realm:     params["realm"],
+			nonce:     params["nonce"],
+			opaque:    params["opaque"],
+			algorithm: params["algorithm"],
+			userhash:  strings.EqualFold(params["userhash"], "true"),
+		}
+		if c.algorithm == "" {
+			c.algorithm = "MD5"
+		}
+		if c.hash() == nil {
+			continue
+		}
+		if qop, ok := params["qop"]; ok {
+			for _, s := range strings.Split(qop, ",") {
+				s = strings.TrimSpace(s)
+				// prefer auth over auth-int
+				if s == "auth" || (s == "auth-int" && c.qop == "") {
+					c.qop = s
+				}
+			}
+			if c.qop == "" {
+				continue
+			}
+		}
+		return c
+	}
+	return nil
+}
+
+// parseAuthParams parses a comma separated list of auth-params (e.g. realm="example", qop="auth,auth-int").
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return params
+		}
+		i := strings.IndexByte(s, '=')
+		if i < 0 {
+			return params
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:i]))
+		s = strings.TrimLeft(s[i+1:], " \t")
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			var sb strings.Builder
+			i = 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				sb.WriteByte(s[i])
+			}
+			value = sb.String()
+			if i < len(s) {
+				i++ // skip the closing quote
+			}
+			s = s[i:]
+		} else {
+			i = strings.IndexByte(s, ',')
+			if i < 0 {
+				i = len(s)
+			}
+			value = strings.TrimSpace(s[:i])
+			s = s[i:]
+		}
+		params[key] = value
+	}
+}
+
+// hash returns the hash function for the algorithm of the challenge, nil if the algorithm is not supported.
+func (c *digestChallenge) hash() func() hash.Hash {
+	switch strings.ToUpper(strings.TrimSuffix(strings.ToLower(c.algorithm), "-sess")) {
+	case "MD5":
+		return md5.New
+	case "SHA-256":
+		return sha256.New
+	default:
+		return nil
+	}
+}
+
+func (c *digestChallenge) h(s string) string {
+	h := c.hash()()
+	_, _ = io.WriteString(h, s)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// authorization computes the value for the Authorization header.
+func (c *digestChallenge) authorization(method, uri string, body []byte, username, password, cnonce string) (string, error) {
+	if c.hash() == nil {
+		return "", xerrors.Errorf("unsupported digest algorithm %s", c.algorithm)
+	}
+	const nc = "00000001"
+
+	ha1 := c.h(username + ":" + c.realm + ":" + password)
+	if strings.HasSuffix(strings.ToLower(c.algorithm), "-sess") {
+		ha1 = c.h(ha1 + ":" + c.nonce + ":" + cnonce)
+	}
+
+	a2 := method + ":" + uri
+	if c.qop == "auth-int" {
+		a2 += ":" + c.h(string(body))
+	}
+	ha2 := c.h(a2)
+
+	var response string
+	if c.qop == "" {
+		response = c.h(ha1 + ":" + c.nonce + ":" + ha2)
+	} else {
+		response = c.h(ha1 + ":" + c.nonce + ":" + nc + ":" + cnonce + ":" + c.qop + ":" + ha2)
+	}
+
+	if c.userhash {
+		username = c.h(username + ":" + c.realm)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `Digest username=%s, realm=%s, uri=%s, algorithm=%s, nonce=%s`,
+		quoteAuthParam(username), quoteAuthParam(c.realm), quoteAuthParam(uri), c.algorithm, quoteAuthParam(c.nonce))
+	if c.qop != "" {
+		fmt.Fprintf(&sb, `, nc=%s, cnonce=%s, qop=%s`, nc, quoteAuthParam(cnonce), c.qop)
+	}
+	fmt.Fprintf(&sb, `, response=%s`, quoteAuthParam(response))
+	if c.opaque != "" {
+		fmt.Fprintf(&sb, `, opaque=%s`, quoteAuthParam(c.opaque))
+	}
+	if c.userhash {
+		sb.WriteString(`, userhash=true`)
+	}
+	return sb.String(), nil
+}
+
+func quoteAuthParam(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
@@ -0,0 +1,126 @@
+package hit
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// oauth2ExpiryDelta is the time before the actual expiry of a token at which the token is considered as expired, so
+// a token will not expire while a request is in flight.
+const oauth2ExpiryDelta = 10 * time.Second
+
+type oauth2Token struct {
+	accessToken string
+	expiry      time.Time
+}
+
+func (t *oauth2Token) valid() bool {
+	return t.expiry.IsZero() || time.Now().Add(oauth2ExpiryDelta).Before(t.expiry)
+}
+
+// oauth2TokenCacheEntry holds the token for one combination of tokenURL, clientID, clientSecret and scopes.
+// The mutex is held while the token is fetched, so concurrent requests with the same credentials fetch the token
+// only once, while requests with other credentials are not blocked.
+type oauth2TokenCacheEntry struct {
+	mu    sync.Mutex
+	token *oauth2Token
+}
+
+// oauth2Tokens caches the tokens fetched by OAuth2ClientCredentials.
+var oauth2Tokens = struct {
+	sync.Mutex
+	entries map[string]*oauth2TokenCacheEntry
+}{
+	entries: make(map[string]*oauth2TokenCacheEntry),
+}
+
+// oauth2ClientCredentialsToken returns a cached token or fetches a new token using the client credentials grant.
+func oauth2ClientCredentialsToken(hit *hitImpl, tokenURL, clientID, clientSecret string, scopes []string) (string, error) {
+	key := strings.Join([]string{tokenURL, clientID, clientSecret, strings.Join(scopes, " ")}, "\x00")
+
+	oauth2Tokens.Lock()
+	entry, ok := oauth2Tokens.entries[key]
+	if !ok {
+		entry = &oauth2TokenCacheEntry{}
+		oauth2Tokens.entries[key] = entry
+	}
+	oauth2Tokens.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.token != nil && entry.token.valid() {
+		return entry.token.accessToken, nil
+	}
+
+	token, err := fetchOAuth2Token(hit, tokenURL, clientID, clientSecret, scopes)
+	if err != nil {
+		return "", xerrors.Errorf("unable to fetch oauth2 token: %w", err)
+	}
+	entry.token = token
+	return token.accessToken, nil
+}
+
+func fetchOAuth2Token(hit *hitImpl, tokenURL, clientID, clientSecret string, scopes []string) (*oauth2Token, error) {
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(hit.Context(), http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// RFC 6749 section 2.3.1: the client id and secret are form encoded before they are used as basic credentials
+	req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+
+	res, err := hit.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		if json.Unmarshal(body, &response) == nil && response.Error != "" {
+			if response.ErrorDescription != "" {
+				return nil, xerrors.Errorf("%s: %s (%s)", res.Status, response.Error, response.ErrorDescription)
+			}
+			return nil, xerrors.Errorf("%s: %s", res.Status, response.Error)
+		}
+		return nil, xerrors.Errorf("token endpoint responded with %s", res.Status)
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, xerrors.Errorf("unable to decode token response: %w", err)
+	}
+	if response.AccessToken == "" {
+		return nil, xerrors.New("token response contains no access_token")
+	}
+
+	token := &oauth2Token{
+		accessToken: response.AccessToken,
+	}
+	if response.ExpiresIn > 0 {
+		token.expiry = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
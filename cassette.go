@@ -0,0 +1,361 @@
+package hit
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+// CassetteMode defines whether a Cassette() records or replays the interactions.
+type CassetteMode int
+
+const (
+	// CassetteRecord performs the real requests and records the interactions to the cassette file.
+	// The cassette file will be overwritten the first time it is used in record mode.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay does not perform any real request, instead the responses will be served from the recorded
+	// interactions in the cassette file.
+	CassetteReplay
+)
+
+// String returns the string representation of the CassetteMode.
+func (m CassetteMode) String() string {
+	switch m {
+	case CassetteRecord:
+		return "CassetteRecord"
+	case CassetteReplay:
+		return "CassetteReplay"
+	default:
+		return fmt.Sprintf("CassetteMode(%d)", int(m))
+	}
+}
+
+// CassetteMatch defines which parts of a request must be equal to a recorded request to replay the recorded
+// response.
+type CassetteMatch struct {
+	// Method requires the request methods to be equal.
+	Method bool
+	// URL requires the request urls to be equal.
+	URL bool
+	// Body requires the request bodies to be equal.
+	Body bool
+	// Headers lists the request headers that must be equal.
+	Headers []string
+}
+
+// DefaultCassetteMatch is the CassetteMatch that will be used if no CassetteMatch was passed to Cassette().
+var DefaultCassetteMatch = CassetteMatch{
+	Method: true,
+	URL:    true,
+}
+
+// Cassette records the request and the response to the specified YAML file (CassetteRecord) or serves the response
+// from the recorded interactions in the file (CassetteReplay).
+// In replay mode the request will be matched against the recorded requests using the specified CassetteMatch
+// (DefaultCassetteMatch if omitted), if no recorded request matches the execution fails with an error that describes
+// the closest recorded interaction.
+// Matching interactions are replayed in the order they were recorded, if all matching interactions were replayed
+// the last one will be replayed again.
+// Request headers that contain credentials (Authorization, Proxy-Authorization, Cookie and headers set by
+// Send().Auth()) and the password of the url will be redacted in the cassette file, so they cannot be used for
+// matching.
+//
+// Usage:
+//     Cassette("testdata/users.yaml", CassetteRecord)
+//     Cassette("testdata/users.yaml", CassetteReplay)
+//     Cassette("testdata/users.yaml", CassetteReplay, CassetteMatch{Method: true, URL: true, Body: true})
+//
+// Example:
+//     cassette := filepath.Join(os.TempDir(), "example.yaml")
+//     MustDo(
+//         Cassette(cassette, CassetteRecord),
+//         Get("https://example.com/json"),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+//     MustDo(
+//         Cassette(cassette, CassetteReplay),
+//         Get("https://example.com/json"),
+//         Expect().Body().JSON().JQ(".Name").Equal("Joe"),
+//     )
+func Cassette(path string, mode CassetteMode, match ...CassetteMatch) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("Cassette", nil),
+		Exec: func(hit *hitImpl) error {
+			m := DefaultCassetteMatch
+			switch len(match) {
+			case 0:
+			case 1:
+				m = match[0]
+			default:
+				return xerrors.New("only one CassetteMatch can be specified")
+			}
+
+			c, err := openCassette(path, mode)
+			if err != nil {
+				return err
+			}
+
+			// the transport will be installed right before the request is performed, so steps that modify the
+			// client (e.g. HTTPClient() or TLS steps) can be used after Cassette()
+			hit.cassette = &cassetteTransport{
+				cassette: c,
+				match:    m,
+			}
+			return nil
+		},
+	}
+}
+
+type cassetteFile struct {
+	Interactions []*cassetteInteraction `yaml:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `yaml:"request"`
+	Response cassetteResponse `yaml:"response"`
+	replayed bool
+}
+
+type cassetteRequest struct {
+	Method  string      `yaml:"method"`
+	URL     string      `yaml:"url"`
+	Headers http.Header `yaml:"headers,omitempty"`
+	Body    string      `yaml:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status     string      `yaml:"status"`
+	StatusCode int         `yaml:"code"`
+	Headers    http.Header `yaml:"headers,omitempty"`
+	Body       string      `yaml:"body,omitempty"`
+}
+
+// cassettes holds all cassettes that were used in this process, so multiple Do() calls can record to (or replay from)
+// the same file.
+var cassettes = struct {
+	sync.Mutex
+	m map[string]*cassette
+}{
+	m: make(map[string]*cassette),
+}
+
+type cassette struct {
+	mu   sync.Mutex
+	path string
+	mode CassetteMode
+	file cassetteFile
+}
+
+func openCassette(path string, mode CassetteMode) (*cassette, error) {
+	if mode != CassetteRecord && mode != CassetteReplay {
+		return nil, xerrors.Errorf("invalid cassette mode %s", mode.String())
+	}
+	path = filepath.Clean(path)
+
+	cassettes.Lock()
+	defer cassettes.Unlock()
+	if c, ok := cassettes.m[path]; ok && c.mode == mode {
+		return c, nil
+	}
+
+	c := &cassette{
+		path: path,
+		mode: mode,
+	}
+	if mode == CassetteReplay {
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to load cassette: %w", err)
+		}
+		if err := yaml.Unmarshal(buf, &c.file); err != nil {
+			return nil, xerrors.Errorf("unable to load cassette %s: %w", path, err)
+		}
+	}
+	cassettes.m[path] = c
+	return c, nil
+}
+
+func (c *cassette) record(interaction *cassetteInteraction) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.file.Interactions = append(c.file.Interactions, interaction)
+
+	buf, err := yaml.Marshal(&c.file)
+	if err != nil {
+		return xerrors.Errorf("unable to save cassette %s: %w", c.path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return xerrors.Errorf("unable to save cassette %s: %w", c.path, err)
+	}
+	if err := ioutil.WriteFile(c.path, buf, 0600); err != nil {
+		return xerrors.Errorf("unable to save cassette %s: %w", c.path, err)
+	}
+	return nil
+}
+
+func (c *cassette) replay(req *cassetteRequest, match *CassetteMatch) (*cassetteInteraction, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.file.Interactions) == 0 {
+		return nil, xerrors.Errorf("cassette %s contains no interactions", c.path)
+	}
+
+	var last *cassetteInteraction
+	closest := -1
+	var closestDiffs []string
+	for i, interaction := range c.file.Interactions {
+		diffs := match.diff(&interaction.Request, req)
+		if len(diffs) == 0 {
+			if !interaction.replayed {
+				interaction.replayed = true
+				return interaction, nil
+			}
+			last = interaction
+			continue
+		}
+		if closest == -1 || len(diffs) < len(closestDiffs) {
+			closest = i
+			closestDiffs = diffs
+		}
+	}
+	if last != nil {
+		return last, nil
+	}
+
+	interaction := c.file.Interactions[closest]
+	return nil, xerrors.Errorf(
+		"no recorded interaction in cassette %s matches %s %s\nclosest recorded interaction #%d: %s %s\n\t%s",
+		c.path, req.Method, req.URL,
+		closest+1, interaction.Request.Method, interaction.Request.URL,
+		strings.Join(closestDiffs, "\n\t"),
+	)
+}
+
+// diff returns the differences between the recorded and the actual request.
+func (match *CassetteMatch) diff(recorded, actual *cassetteRequest) []string {
+	var diffs []string
+	if match.Method && recorded.Method != actual.Method {
+		diffs = append(diffs, fmt.Sprintf("method differs: recorded %q, got %q", recorded.Method, actual.Method))
+	}
+	if match.URL && recorded.URL != actual.URL {
+		diffs = append(diffs, fmt.Sprintf("url differs: recorded %q, got %q", recorded.URL, actual.URL))
+	}
+	if match.Body && recorded.Body != actual.Body {
+		diffs = append(diffs, fmt.Sprintf("body differs: recorded %q, got %q", recorded.Body, actual.Body))
+	}
+	for _, name := range match.Headers {
+		r := strings.Join(recorded.Headers.Values(name), ", ")
+		a := strings.Join(actual.Headers.Values(name), ", ")
+		if r != a {
+			diffs = append(diffs, fmt.Sprintf("header %s differs: recorded %q, got %q", http.CanonicalHeaderKey(name), r, a))
+		}
+	}
+	return diffs
+}
+
+// cassetteTransport is a http.RoundTripper that records or replays the interactions of a cassette.
+type cassetteTransport struct {
+	cassette *cassette
+	match    CassetteMatch
+	next     http.RoundTripper
+	// redact lists the request headers that will be redacted
+	redact []string
+}
+
+// install returns a copy of the client that uses the cassette.
+func (t *cassetteTransport) install(hit *hitImpl, client *http.Client) *http.Client {
+	transport := *t
+	transport.next = client.Transport
+	transport.redact = hit.recordingRedactions()
+	c := *client
+	c.Transport = &transport
+	return &c
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recordedRequest := cassetteRequest{
+		Method:  req.Method,
+		URL:     redactURL(req.URL).String(),
+		Headers: redactHeaders(req.Header, t.redact...).Clone(),
+		Body:    string(body),
+	}
+
+	if t.cassette.mode == CassetteReplay {
+		interaction, err := t.cassette.replay(&recordedRequest, &t.match)
+		if err != nil {
+			return nil, err
+		}
+		return interaction.Response.httpResponse(req), nil
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	r := req.Clone(req.Context())
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	res, err := next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := ioutil.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	err = t.cassette.record(&cassetteInteraction{
+		Request: recordedRequest,
+		Response: cassetteResponse{
+			Status:     res.Status,
+			StatusCode: res.StatusCode,
+			Headers:    res.Header.Clone(),
+			Body:       string(resBody),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *cassetteResponse) httpResponse(req *http.Request) *http.Response {
+	header := r.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        r.Status,
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,199 @@
+package hit_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestCassette(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := EchoServer()
+	url := s.URL
+
+	path := filepath.Join(dir, "testdata", "echo.yaml")
+
+	// record
+	Test(t,
+		Cassette(path, CassetteRecord),
+		Post(url+"/users"),
+		Send().Headers("X-Name").Add("Joe"),
+		Send().Body().String("Hello Joe"),
+		Expect().Body().String().Equal("Hello Joe"),
+	)
+	Test(t,
+		Cassette(path, CassetteRecord),
+		Post(url+"/users"),
+		Send().Headers("X-Name").Add("Alice"),
+		Send().Body().String("Hello Alice"),
+		Expect().Body().String().Equal("Hello Alice"),
+	)
+	Test(t,
+		Cassette(path, CassetteRecord),
+		Get(url+"/status"),
+		Expect().Status().Equal(http.StatusOK),
+	)
+
+	buf, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(buf), "body: Hello Joe")
+	require.Contains(t, string(buf), "body: Hello Alice")
+
+	// the server is not needed anymore
+	s.Close()
+
+	t.Run("replay in recorded order", func(t *testing.T) {
+		Test(t,
+			Cassette(path, CassetteReplay),
+			Post(url+"/users"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Headers("X-Name").Equal("Joe"),
+			Expect().Body().String().Equal("Hello Joe"),
+		)
+		Test(t,
+			Cassette(path, CassetteReplay),
+			Post(url+"/users"),
+			Expect().Body().String().Equal("Hello Alice"),
+		)
+		// all interactions were replayed, the last one will be used again
+		Test(t,
+			Cassette(path, CassetteReplay),
+			Post(url+"/users"),
+			Expect().Body().String().Equal("Hello Alice"),
+		)
+	})
+
+	t.Run("match body", func(t *testing.T) {
+		Test(t,
+			Cassette(path, CassetteReplay, CassetteMatch{Method: true, URL: true, Body: true}),
+			Post(url+"/users"),
+			Send().Body().String("Hello Joe"),
+			Expect().Headers("X-Name").Equal("Joe"),
+		)
+	})
+
+	t.Run("match headers", func(t *testing.T) {
+		Test(t,
+			Cassette(path, CassetteReplay, CassetteMatch{Headers: []string{"x-name"}}),
+			Post(url+"/users"),
+			Send().Headers("X-Name").Add("Alice"),
+			Expect().Body().String().Equal("Hello Alice"),
+		)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		err := Do(
+			Cassette(path, CassetteReplay),
+			Delete(url+"/users"),
+		)
+		require.Error(t, err)
+		var hitErr *Error
+		require.ErrorAs(t, err, &hitErr)
+		require.Contains(t, err.Error(), `no recorded interaction in cassette `+path+` matches DELETE `+url+`/users`)
+		require.Contains(t, err.Error(), `closest recorded interaction #1: POST `+url+`/users`)
+		require.Contains(t, err.Error(), `method differs: recorded "POST", got "DELETE"`)
+
+		err = Do(
+			Cassette(path, CassetteReplay, CassetteMatch{Method: true, URL: true, Headers: []string{"X-Name"}}),
+			Post(url+"/users"),
+			Send().Headers("X-Name").Add("Bob"),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `header X-Name differs: recorded "Joe", got "Bob"`)
+	})
+
+	t.Run("missing cassette", func(t *testing.T) {
+		err := Do(
+			Cassette(filepath.Join(dir, "missing.yaml"), CassetteReplay),
+			Get(url),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unable to load cassette")
+	})
+}
+
+func TestCassette_Record(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	t.Run("retry records every attempt once", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(3, &counter)
+		defer s.Close()
+
+		path := filepath.Join(dir, "retry.yaml")
+		Test(t,
+			Cassette(path, CassetteRecord),
+			Get(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		buf, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, 3, strings.Count(string(buf), "- request:"))
+	})
+
+	t.Run("client steps after Cassette", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer s.Close()
+
+		path := filepath.Join(dir, "tls.yaml")
+		Test(t,
+			Cassette(path, CassetteRecord),
+			HTTPClient(s.Client()),
+			Get(s.URL+"/client"),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		Test(t,
+			Cassette(path, CassetteRecord),
+			InsecureSkipVerify(),
+			Get(s.URL+"/insecure"),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		buf, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.Contains(t, string(buf), s.URL+"/client")
+		require.Contains(t, string(buf), s.URL+"/insecure")
+	})
+
+	t.Run("credentials are redacted", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		defer s.Close()
+
+		path := filepath.Join(dir, "credentials.yaml")
+		u := strings.Replace(s.URL, "http://", "http://joe:supersecret@", 1)
+		Test(t,
+			Cassette(path, CassetteRecord),
+			Get(u),
+			Send().Auth().Bearer("supersecret"),
+			Send().Headers("Cookie").Add("session=supersecret"),
+			Send().Headers("X-Name").Add("Joe"),
+		)
+		buf, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.NotContains(t, string(buf), "supersecret")
+		require.Contains(t, string(buf), "Bearer [REDACTED]")
+		require.Contains(t, string(buf), "Joe")
+		require.Contains(t, string(buf), "joe:%5BREDACTED%5D@")
+
+		Test(t,
+			Cassette(path, CassetteReplay, CassetteMatch{Method: true, URL: true, Headers: []string{"Authorization"}}),
+			Get(strings.Replace(s.URL, "http://", "http://joe:othersecret@", 1)),
+			Send().Auth().Bearer("othersecret"),
+			Expect().Status().Equal(http.StatusOK),
+		)
+	})
+}
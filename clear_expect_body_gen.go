@@ -29,6 +29,10 @@ type IClearExpectBody interface {
 	Int8() IClearExpectInt8
 	// JSON clears all matching JSON steps
 	JSON() IClearExpectBodyJSON
+	// MatchSnapshot clears all matching MatchSnapshot steps
+	MatchSnapshot(value ...string) IStep
+	// Multipart clears all matching Multipart steps
+	Multipart() IClearExpectBodyMultipart
 	// String clears all matching String steps
 	String() IClearExpectString
 	// Uint clears all matching Uint steps
@@ -41,6 +45,8 @@ type IClearExpectBody interface {
 	Uint64() IClearExpectUint64
 	// Uint8 clears all matching Uint8 steps
 	Uint8() IClearExpectUint8
+	// XML clears all matching XML steps
+	XML() IClearExpectBodyXML
 }
 type clearExpectBody struct {
 	cp callPath
@@ -95,6 +101,12 @@ func (v *clearExpectBody) Int8() IClearExpectInt8 {
 func (v *clearExpectBody) JSON() IClearExpectBodyJSON {
 	return newClearExpectBodyJSON(v.callPath().Push("JSON", nil))
 }
+func (v *clearExpectBody) MatchSnapshot(value ...string) IStep {
+	return removeStep(v.callPath().Push("MatchSnapshot", stringSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectBody) Multipart() IClearExpectBodyMultipart {
+	return newClearExpectBodyMultipart(v.callPath().Push("Multipart", nil))
+}
 func (v *clearExpectBody) String() IClearExpectString {
 	return newClearExpectString(v.callPath().Push("String", nil))
 }
@@ -113,3 +125,6 @@ func (v *clearExpectBody) Uint64() IClearExpectUint64 {
 func (v *clearExpectBody) Uint8() IClearExpectUint8 {
 	return newClearExpectUint8(v.callPath().Push("Uint8", nil))
 }
+func (v *clearExpectBody) XML() IClearExpectBodyXML {
+	return newClearExpectBodyXML(v.callPath().Push("XML", nil))
+}
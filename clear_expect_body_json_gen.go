@@ -21,6 +21,8 @@ type IClearExpectBodyJSON interface {
 	NotContains(value ...interface{}) IStep
 	// NotEqual clears all matching NotEqual steps
 	NotEqual(value ...interface{}) IStep
+	// Schema clears all matching Schema steps
+	Schema(value ...interface{}) IStep
 }
 type clearExpectBodyJSON struct {
 	cp callPath
@@ -63,3 +65,6 @@ func (v *clearExpectBodyJSON) NotContains(value ...interface{}) IStep {
 func (v *clearExpectBodyJSON) NotEqual(value ...interface{}) IStep {
 	return removeStep(v.callPath().Push("NotEqual", value))
 }
+func (v *clearExpectBodyJSON) Schema(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Schema", value))
+}
@@ -21,6 +21,8 @@ type IClearExpectBodyJSONJQ interface {
 	NotContains(value ...interface{}) IStep
 	// NotEqual clears all matching NotEqual steps
 	NotEqual(value ...interface{}) IStep
+	// Schema clears all matching Schema steps
+	Schema(value ...interface{}) IStep
 }
 type clearExpectBodyJSONJQ struct {
 	cp callPath
@@ -63,3 +65,6 @@ func (v *clearExpectBodyJSONJQ) NotContains(value ...interface{}) IStep {
 func (v *clearExpectBodyJSONJQ) NotEqual(value ...interface{}) IStep {
 	return removeStep(v.callPath().Push("NotEqual", value))
 }
+func (v *clearExpectBodyJSONJQ) Schema(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Schema", value))
+}
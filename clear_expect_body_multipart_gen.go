@@ -0,0 +1,45 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectBodyMultipart provides methods to clear steps.
+type IClearExpectBodyMultipart interface {
+	IStep
+	// Len clears all matching Len steps
+	Len() IClearExpectInt
+	// Part clears all matching Part steps
+	Part(value ...int) IClearExpectBodyMultipartPart
+}
+type clearExpectBodyMultipart struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectBodyMultipart(cp callPath) IClearExpectBodyMultipart {
+	return &clearExpectBodyMultipart{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectBodyMultipart) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectBodyMultipart) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectBodyMultipart) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectBodyMultipart) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectBodyMultipart) Len() IClearExpectInt {
+	return newClearExpectInt(v.callPath().Push("Len", nil))
+}
+func (v *clearExpectBodyMultipart) Part(value ...int) IClearExpectBodyMultipartPart {
+	return newClearExpectBodyMultipartPart(v.callPath().Push("Part", intSliceToInterfaceSlice(value)))
+}
@@ -0,0 +1,45 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectBodyMultipartPart provides methods to clear steps.
+type IClearExpectBodyMultipartPart interface {
+	IStep
+	// Body clears all matching Body steps
+	Body() IClearExpectBody
+	// Headers clears all matching Headers steps
+	Headers(value ...string) IClearExpectHeaders
+}
+type clearExpectBodyMultipartPart struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectBodyMultipartPart(cp callPath) IClearExpectBodyMultipartPart {
+	return &clearExpectBodyMultipartPart{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectBodyMultipartPart) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectBodyMultipartPart) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectBodyMultipartPart) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectBodyMultipartPart) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectBodyMultipartPart) Body() IClearExpectBody {
+	return newClearExpectBody(v.callPath().Push("Body", nil))
+}
+func (v *clearExpectBodyMultipartPart) Headers(value ...string) IClearExpectHeaders {
+	return newClearExpectHeaders(v.callPath().Push("Headers", stringSliceToInterfaceSlice(value)))
+}
@@ -0,0 +1,60 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectBodyXML provides methods to clear steps.
+type IClearExpectBodyXML interface {
+	IStep
+	// Contains clears all matching Contains steps
+	Contains(value ...interface{}) IStep
+	// Equal clears all matching Equal steps
+	Equal(value ...interface{}) IStep
+	// NotContains clears all matching NotContains steps
+	NotContains(value ...interface{}) IStep
+	// NotEqual clears all matching NotEqual steps
+	NotEqual(value ...interface{}) IStep
+	// XPath clears all matching XPath steps
+	XPath(value ...string) IClearExpectBodyXMLXPath
+}
+type clearExpectBodyXML struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectBodyXML(cp callPath) IClearExpectBodyXML {
+	return &clearExpectBodyXML{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectBodyXML) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectBodyXML) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectBodyXML) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectBodyXML) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectBodyXML) Contains(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Contains", value))
+}
+func (v *clearExpectBodyXML) Equal(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Equal", value))
+}
+func (v *clearExpectBodyXML) NotContains(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotContains", value))
+}
+func (v *clearExpectBodyXML) NotEqual(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotEqual", value))
+}
+func (v *clearExpectBodyXML) XPath(value ...string) IClearExpectBodyXMLXPath {
+	return newClearExpectBodyXMLXPath(v.callPath().Push("XPath", stringSliceToInterfaceSlice(value)))
+}
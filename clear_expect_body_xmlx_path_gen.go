@@ -0,0 +1,75 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectBodyXMLXPath provides methods to clear steps.
+type IClearExpectBodyXMLXPath interface {
+	IStep
+	// Contains clears all matching Contains steps
+	Contains(value ...interface{}) IStep
+	// Equal clears all matching Equal steps
+	Equal(value ...interface{}) IStep
+	// Float64 clears all matching Float64 steps
+	Float64() IClearExpectFloat64
+	// Int clears all matching Int steps
+	Int() IClearExpectInt
+	// Len clears all matching Len steps
+	Len() IClearExpectInt
+	// NotContains clears all matching NotContains steps
+	NotContains(value ...interface{}) IStep
+	// NotEqual clears all matching NotEqual steps
+	NotEqual(value ...interface{}) IStep
+	// String clears all matching String steps
+	String() IClearExpectString
+}
+type clearExpectBodyXMLXPath struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectBodyXMLXPath(cp callPath) IClearExpectBodyXMLXPath {
+	return &clearExpectBodyXMLXPath{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectBodyXMLXPath) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectBodyXMLXPath) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectBodyXMLXPath) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectBodyXMLXPath) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectBodyXMLXPath) Contains(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Contains", value))
+}
+func (v *clearExpectBodyXMLXPath) Equal(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Equal", value))
+}
+func (v *clearExpectBodyXMLXPath) Float64() IClearExpectFloat64 {
+	return newClearExpectFloat64(v.callPath().Push("Float64", nil))
+}
+func (v *clearExpectBodyXMLXPath) Int() IClearExpectInt {
+	return newClearExpectInt(v.callPath().Push("Int", nil))
+}
+func (v *clearExpectBodyXMLXPath) Len() IClearExpectInt {
+	return newClearExpectInt(v.callPath().Push("Len", nil))
+}
+func (v *clearExpectBodyXMLXPath) NotContains(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotContains", value))
+}
+func (v *clearExpectBodyXMLXPath) NotEqual(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotEqual", value))
+}
+func (v *clearExpectBodyXMLXPath) String() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("String", nil))
+}
@@ -0,0 +1,60 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectCertificate provides methods to clear steps.
+type IClearExpectCertificate interface {
+	IStep
+	// DNSNames clears all matching DNSNames steps
+	DNSNames() IClearExpectStrings
+	// Issuer clears all matching Issuer steps
+	Issuer() IClearExpectString
+	// NotAfter clears all matching NotAfter steps
+	NotAfter() IClearExpectTime
+	// NotBefore clears all matching NotBefore steps
+	NotBefore() IClearExpectTime
+	// Subject clears all matching Subject steps
+	Subject() IClearExpectString
+}
+type clearExpectCertificate struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectCertificate(cp callPath) IClearExpectCertificate {
+	return &clearExpectCertificate{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectCertificate) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectCertificate) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectCertificate) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectCertificate) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectCertificate) DNSNames() IClearExpectStrings {
+	return newClearExpectStrings(v.callPath().Push("DNSNames", nil))
+}
+func (v *clearExpectCertificate) Issuer() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("Issuer", nil))
+}
+func (v *clearExpectCertificate) NotAfter() IClearExpectTime {
+	return newClearExpectTime(v.callPath().Push("NotAfter", nil))
+}
+func (v *clearExpectCertificate) NotBefore() IClearExpectTime {
+	return newClearExpectTime(v.callPath().Push("NotBefore", nil))
+}
+func (v *clearExpectCertificate) Subject() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("Subject", nil))
+}
@@ -0,0 +1,85 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectCookie provides methods to clear steps.
+type IClearExpectCookie interface {
+	IStep
+	// Domain clears all matching Domain steps
+	Domain() IClearExpectString
+	// Exists clears all matching Exists steps
+	Exists() IStep
+	// Expires clears all matching Expires steps
+	Expires() IClearExpectTime
+	// HttpOnly clears all matching HttpOnly steps
+	HttpOnly() IStep
+	// MaxAge clears all matching MaxAge steps
+	MaxAge() IClearExpectInt
+	// NotExists clears all matching NotExists steps
+	NotExists() IStep
+	// Path clears all matching Path steps
+	Path() IClearExpectString
+	// SameSite clears all matching SameSite steps
+	SameSite() IClearExpectString
+	// Secure clears all matching Secure steps
+	Secure() IStep
+	// Value clears all matching Value steps
+	Value() IClearExpectString
+}
+type clearExpectCookie struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectCookie(cp callPath) IClearExpectCookie {
+	return &clearExpectCookie{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectCookie) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectCookie) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectCookie) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectCookie) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectCookie) Domain() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("Domain", nil))
+}
+func (v *clearExpectCookie) Exists() IStep {
+	return removeStep(v.callPath().Push("Exists", nil))
+}
+func (v *clearExpectCookie) Expires() IClearExpectTime {
+	return newClearExpectTime(v.callPath().Push("Expires", nil))
+}
+func (v *clearExpectCookie) HttpOnly() IStep {
+	return removeStep(v.callPath().Push("HttpOnly", nil))
+}
+func (v *clearExpectCookie) MaxAge() IClearExpectInt {
+	return newClearExpectInt(v.callPath().Push("MaxAge", nil))
+}
+func (v *clearExpectCookie) NotExists() IStep {
+	return removeStep(v.callPath().Push("NotExists", nil))
+}
+func (v *clearExpectCookie) Path() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("Path", nil))
+}
+func (v *clearExpectCookie) SameSite() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("SameSite", nil))
+}
+func (v *clearExpectCookie) Secure() IStep {
+	return removeStep(v.callPath().Push("Secure", nil))
+}
+func (v *clearExpectCookie) Value() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("Value", nil))
+}
@@ -0,0 +1,64 @@
+// +build !generate
+
+package hit
+
+import (
+	"time"
+
+	errortrace "github.com/Eun/go-hit/errortrace"
+)
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectDuration provides methods to clear steps.
+type IClearExpectDuration interface {
+	IStep
+	// Between clears all matching Between steps
+	Between(value ...time.Duration) IStep
+	// GreaterOrEqualThan clears all matching GreaterOrEqualThan steps
+	GreaterOrEqualThan(value ...time.Duration) IStep
+	// GreaterThan clears all matching GreaterThan steps
+	GreaterThan(value ...time.Duration) IStep
+	// LessOrEqualThan clears all matching LessOrEqualThan steps
+	LessOrEqualThan(value ...time.Duration) IStep
+	// LessThan clears all matching LessThan steps
+	LessThan(value ...time.Duration) IStep
+}
+type clearExpectDuration struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectDuration(cp callPath) IClearExpectDuration {
+	return &clearExpectDuration{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectDuration) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectDuration) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectDuration) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectDuration) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectDuration) Between(value ...time.Duration) IStep {
+	return removeStep(v.callPath().Push("Between", durationSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectDuration) GreaterOrEqualThan(value ...time.Duration) IStep {
+	return removeStep(v.callPath().Push("GreaterOrEqualThan", durationSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectDuration) GreaterThan(value ...time.Duration) IStep {
+	return removeStep(v.callPath().Push("GreaterThan", durationSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectDuration) LessOrEqualThan(value ...time.Duration) IStep {
+	return removeStep(v.callPath().Push("LessOrEqualThan", durationSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectDuration) LessThan(value ...time.Duration) IStep {
+	return removeStep(v.callPath().Push("LessThan", durationSliceToInterfaceSlice(value)))
+}
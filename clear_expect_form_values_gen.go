@@ -21,6 +21,8 @@ type IClearExpectFormValues interface {
 	Last() IClearExpectHeaderValue
 	// Len clears all matching Len steps
 	Len() IClearExpectInt
+	// MatchSnapshot clears all matching MatchSnapshot steps
+	MatchSnapshot(value ...string) IStep
 	// NotContains clears all matching NotContains steps
 	NotContains(value ...interface{}) IStep
 	// NotEmpty clears all matching NotEmpty steps
@@ -75,6 +77,9 @@ func (v *clearExpectFormValues) Last() IClearExpectHeaderValue {
 func (v *clearExpectFormValues) Len() IClearExpectInt {
 	return newClearExpectInt(v.callPath().Push("Len", nil))
 }
+func (v *clearExpectFormValues) MatchSnapshot(value ...string) IStep {
+	return removeStep(v.callPath().Push("MatchSnapshot", stringSliceToInterfaceSlice(value)))
+}
 func (v *clearExpectFormValues) NotContains(value ...interface{}) IStep {
 	return removeStep(v.callPath().Push("NotContains", value))
 }
@@ -9,14 +9,26 @@ import errortrace "github.com/Eun/go-hit/errortrace"
 // IClearExpect provides methods to clear steps.
 type IClearExpect interface {
 	IStep
+	// AllHeaders clears all matching AllHeaders steps
+	AllHeaders() IClearExpectHeaders
 	// Body clears all matching Body steps
 	Body() IClearExpectBody
+	// Cookies clears all matching Cookies steps
+	Cookies(value ...string) IClearExpectCookie
 	// Custom clears all matching Custom steps
 	Custom(value ...Callback) IStep
+	// Duration clears all matching Duration steps
+	Duration() IClearExpectDuration
 	// Headers clears all matching Headers steps
 	Headers(value ...string) IClearExpectHeaders
+	// Redirects clears all matching Redirects steps
+	Redirects() IClearExpectRedirects
 	// Status clears all matching Status steps
 	Status() IClearExpectInt64
+	// TLS clears all matching TLS steps
+	TLS() IClearExpectTLS
+	// Timing clears all matching Timing steps
+	Timing() IClearExpectTiming
 	// Trailers clears all matching Trailers steps
 	Trailers(value ...string) IClearExpectHeaders
 }
@@ -43,18 +55,36 @@ func (v *clearExpect) exec(hit *hitImpl) error {
 	}
 	return nil
 }
+func (v *clearExpect) AllHeaders() IClearExpectHeaders {
+	return newClearExpectHeaders(v.callPath().Push("AllHeaders", nil))
+}
 func (v *clearExpect) Body() IClearExpectBody {
 	return newClearExpectBody(v.callPath().Push("Body", nil))
 }
+func (v *clearExpect) Cookies(value ...string) IClearExpectCookie {
+	return newClearExpectCookie(v.callPath().Push("Cookies", stringSliceToInterfaceSlice(value)))
+}
 func (v *clearExpect) Custom(value ...Callback) IStep {
 	return removeStep(v.callPath().Push("Custom", callbackSliceToInterfaceSlice(value)))
 }
+func (v *clearExpect) Duration() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("Duration", nil))
+}
 func (v *clearExpect) Headers(value ...string) IClearExpectHeaders {
 	return newClearExpectHeaders(v.callPath().Push("Headers", stringSliceToInterfaceSlice(value)))
 }
+func (v *clearExpect) Redirects() IClearExpectRedirects {
+	return newClearExpectRedirects(v.callPath().Push("Redirects", nil))
+}
 func (v *clearExpect) Status() IClearExpectInt64 {
 	return newClearExpectInt64(v.callPath().Push("Status", nil))
 }
+func (v *clearExpect) TLS() IClearExpectTLS {
+	return newClearExpectTLS(v.callPath().Push("TLS", nil))
+}
+func (v *clearExpect) Timing() IClearExpectTiming {
+	return newClearExpectTiming(v.callPath().Push("Timing", nil))
+}
 func (v *clearExpect) Trailers(value ...string) IClearExpectHeaders {
 	return newClearExpectHeaders(v.callPath().Push("Trailers", stringSliceToInterfaceSlice(value)))
 }
@@ -0,0 +1,45 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectRedirect provides methods to clear steps.
+type IClearExpectRedirect interface {
+	IStep
+	// Status clears all matching Status steps
+	Status() IClearExpectInt64
+	// URL clears all matching URL steps
+	URL() IClearExpectString
+}
+type clearExpectRedirect struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectRedirect(cp callPath) IClearExpectRedirect {
+	return &clearExpectRedirect{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectRedirect) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectRedirect) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectRedirect) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectRedirect) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectRedirect) Status() IClearExpectInt64 {
+	return newClearExpectInt64(v.callPath().Push("Status", nil))
+}
+func (v *clearExpectRedirect) URL() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("URL", nil))
+}
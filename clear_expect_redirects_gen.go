@@ -0,0 +1,50 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectRedirects provides methods to clear steps.
+type IClearExpectRedirects interface {
+	IStep
+	// Count clears all matching Count steps
+	Count() IClearExpectInt
+	// Final clears all matching Final steps
+	Final() IClearExpectRedirect
+	// Nth clears all matching Nth steps
+	Nth(value ...int) IClearExpectRedirect
+}
+type clearExpectRedirects struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectRedirects(cp callPath) IClearExpectRedirects {
+	return &clearExpectRedirects{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectRedirects) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectRedirects) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectRedirects) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectRedirects) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectRedirects) Count() IClearExpectInt {
+	return newClearExpectInt(v.callPath().Push("Count", nil))
+}
+func (v *clearExpectRedirects) Final() IClearExpectRedirect {
+	return newClearExpectRedirect(v.callPath().Push("Final", nil))
+}
+func (v *clearExpectRedirects) Nth(value ...int) IClearExpectRedirect {
+	return newClearExpectRedirect(v.callPath().Push("Nth", intSliceToInterfaceSlice(value)))
+}
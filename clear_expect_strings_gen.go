@@ -0,0 +1,100 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectStrings provides methods to clear steps.
+type IClearExpectStrings interface {
+	IStep
+	// Contains clears all matching Contains steps
+	Contains(value ...interface{}) IStep
+	// Empty clears all matching Empty steps
+	Empty() IStep
+	// Equal clears all matching Equal steps
+	Equal(value ...interface{}) IStep
+	// First clears all matching First steps
+	First() IClearExpectHeaderValue
+	// Last clears all matching Last steps
+	Last() IClearExpectHeaderValue
+	// Len clears all matching Len steps
+	Len() IClearExpectInt
+	// MatchSnapshot clears all matching MatchSnapshot steps
+	MatchSnapshot(value ...string) IStep
+	// NotContains clears all matching NotContains steps
+	NotContains(value ...interface{}) IStep
+	// NotEmpty clears all matching NotEmpty steps
+	NotEmpty() IStep
+	// NotEqual clears all matching NotEqual steps
+	NotEqual(value ...interface{}) IStep
+	// NotOneOf clears all matching NotOneOf steps
+	NotOneOf(value ...interface{}) IStep
+	// Nth clears all matching Nth steps
+	Nth(value ...int) IClearExpectHeaderValue
+	// OneOf clears all matching OneOf steps
+	OneOf(value ...interface{}) IStep
+}
+type clearExpectStrings struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectStrings(cp callPath) IClearExpectStrings {
+	return &clearExpectStrings{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectStrings) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectStrings) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectStrings) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectStrings) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectStrings) Contains(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Contains", value))
+}
+func (v *clearExpectStrings) Empty() IStep {
+	return removeStep(v.callPath().Push("Empty", nil))
+}
+func (v *clearExpectStrings) Equal(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Equal", value))
+}
+func (v *clearExpectStrings) First() IClearExpectHeaderValue {
+	return newClearExpectHeaderValue(v.callPath().Push("First", nil))
+}
+func (v *clearExpectStrings) Last() IClearExpectHeaderValue {
+	return newClearExpectHeaderValue(v.callPath().Push("Last", nil))
+}
+func (v *clearExpectStrings) Len() IClearExpectInt {
+	return newClearExpectInt(v.callPath().Push("Len", nil))
+}
+func (v *clearExpectStrings) MatchSnapshot(value ...string) IStep {
+	return removeStep(v.callPath().Push("MatchSnapshot", stringSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectStrings) NotContains(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotContains", value))
+}
+func (v *clearExpectStrings) NotEmpty() IStep {
+	return removeStep(v.callPath().Push("NotEmpty", nil))
+}
+func (v *clearExpectStrings) NotEqual(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotEqual", value))
+}
+func (v *clearExpectStrings) NotOneOf(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("NotOneOf", value))
+}
+func (v *clearExpectStrings) Nth(value ...int) IClearExpectHeaderValue {
+	return newClearExpectHeaderValue(v.callPath().Push("Nth", intSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectStrings) OneOf(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("OneOf", value))
+}
@@ -0,0 +1,74 @@
+// +build !generate
+
+package hit
+
+import (
+	"time"
+
+	errortrace "github.com/Eun/go-hit/errortrace"
+)
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectTime provides methods to clear steps.
+type IClearExpectTime interface {
+	IStep
+	// After clears all matching After steps
+	After(value ...time.Time) IStep
+	// Before clears all matching Before steps
+	Before(value ...time.Time) IStep
+	// Empty clears all matching Empty steps
+	Empty() IStep
+	// Equal clears all matching Equal steps
+	Equal(value ...time.Time) IStep
+	// NotEmpty clears all matching NotEmpty steps
+	NotEmpty() IStep
+	// NotEqual clears all matching NotEqual steps
+	NotEqual(value ...time.Time) IStep
+	// Within clears all matching Within steps
+	Within(value ...time.Duration) IStep
+}
+type clearExpectTime struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectTime(cp callPath) IClearExpectTime {
+	return &clearExpectTime{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectTime) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectTime) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectTime) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectTime) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectTime) After(value ...time.Time) IStep {
+	return removeStep(v.callPath().Push("After", timeSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectTime) Before(value ...time.Time) IStep {
+	return removeStep(v.callPath().Push("Before", timeSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectTime) Empty() IStep {
+	return removeStep(v.callPath().Push("Empty", nil))
+}
+func (v *clearExpectTime) Equal(value ...time.Time) IStep {
+	return removeStep(v.callPath().Push("Equal", timeSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectTime) NotEmpty() IStep {
+	return removeStep(v.callPath().Push("NotEmpty", nil))
+}
+func (v *clearExpectTime) NotEqual(value ...time.Time) IStep {
+	return removeStep(v.callPath().Push("NotEqual", timeSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectTime) Within(value ...time.Duration) IStep {
+	return removeStep(v.callPath().Push("Within", durationSliceToInterfaceSlice(value)))
+}
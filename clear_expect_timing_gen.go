@@ -0,0 +1,65 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectTiming provides methods to clear steps.
+type IClearExpectTiming interface {
+	IStep
+	// BodyRead clears all matching BodyRead steps
+	BodyRead() IClearExpectDuration
+	// Connect clears all matching Connect steps
+	Connect() IClearExpectDuration
+	// DNS clears all matching DNS steps
+	DNS() IClearExpectDuration
+	// TLSHandshake clears all matching TLSHandshake steps
+	TLSHandshake() IClearExpectDuration
+	// TimeToFirstByte clears all matching TimeToFirstByte steps
+	TimeToFirstByte() IClearExpectDuration
+	// Total clears all matching Total steps
+	Total() IClearExpectDuration
+}
+type clearExpectTiming struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectTiming(cp callPath) IClearExpectTiming {
+	return &clearExpectTiming{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectTiming) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectTiming) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectTiming) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectTiming) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectTiming) BodyRead() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("BodyRead", nil))
+}
+func (v *clearExpectTiming) Connect() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("Connect", nil))
+}
+func (v *clearExpectTiming) DNS() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("DNS", nil))
+}
+func (v *clearExpectTiming) TLSHandshake() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("TLSHandshake", nil))
+}
+func (v *clearExpectTiming) TimeToFirstByte() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("TimeToFirstByte", nil))
+}
+func (v *clearExpectTiming) Total() IClearExpectDuration {
+	return newClearExpectDuration(v.callPath().Push("Total", nil))
+}
@@ -0,0 +1,60 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearExpectTLS provides methods to clear steps.
+type IClearExpectTLS interface {
+	IStep
+	// CipherSuite clears all matching CipherSuite steps
+	CipherSuite() IClearExpectString
+	// NegotiatedProtocol clears all matching NegotiatedProtocol steps
+	NegotiatedProtocol() IClearExpectString
+	// PeerCertificate clears all matching PeerCertificate steps
+	PeerCertificate(value ...int) IClearExpectCertificate
+	// Verified clears all matching Verified steps
+	Verified() IStep
+	// Version clears all matching Version steps
+	Version() IClearExpectString
+}
+type clearExpectTLS struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearExpectTLS(cp callPath) IClearExpectTLS {
+	return &clearExpectTLS{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearExpectTLS) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearExpectTLS) when() StepTime {
+	return cleanStep
+}
+func (v *clearExpectTLS) callPath() callPath {
+	return v.cp
+}
+func (v *clearExpectTLS) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearExpectTLS) CipherSuite() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("CipherSuite", nil))
+}
+func (v *clearExpectTLS) NegotiatedProtocol() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("NegotiatedProtocol", nil))
+}
+func (v *clearExpectTLS) PeerCertificate(value ...int) IClearExpectCertificate {
+	return newClearExpectCertificate(v.callPath().Push("PeerCertificate", intSliceToInterfaceSlice(value)))
+}
+func (v *clearExpectTLS) Verified() IStep {
+	return removeStep(v.callPath().Push("Verified", nil))
+}
+func (v *clearExpectTLS) Version() IClearExpectString {
+	return newClearExpectString(v.callPath().Push("Version", nil))
+}
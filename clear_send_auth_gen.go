@@ -0,0 +1,55 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearSendAuth provides methods to clear steps.
+type IClearSendAuth interface {
+	IStep
+	// Basic clears all matching Basic steps
+	Basic(value ...string) IStep
+	// Bearer clears all matching Bearer steps
+	Bearer(value ...string) IStep
+	// Digest clears all matching Digest steps
+	Digest(value ...string) IStep
+	// OAuth2ClientCredentials clears all matching OAuth2ClientCredentials steps
+	OAuth2ClientCredentials(value ...string) IStep
+}
+type clearSendAuth struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearSendAuth(cp callPath) IClearSendAuth {
+	return &clearSendAuth{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearSendAuth) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearSendAuth) when() StepTime {
+	return cleanStep
+}
+func (v *clearSendAuth) callPath() callPath {
+	return v.cp
+}
+func (v *clearSendAuth) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearSendAuth) Basic(value ...string) IStep {
+	return removeStep(v.callPath().Push("Basic", stringSliceToInterfaceSlice(value)))
+}
+func (v *clearSendAuth) Bearer(value ...string) IStep {
+	return removeStep(v.callPath().Push("Bearer", stringSliceToInterfaceSlice(value)))
+}
+func (v *clearSendAuth) Digest(value ...string) IStep {
+	return removeStep(v.callPath().Push("Digest", stringSliceToInterfaceSlice(value)))
+}
+func (v *clearSendAuth) OAuth2ClientCredentials(value ...string) IStep {
+	return removeStep(v.callPath().Push("OAuth2ClientCredentials", stringSliceToInterfaceSlice(value)))
+}
@@ -35,6 +35,8 @@ type IClearSendBody interface {
 	Int8(value ...int8) IStep
 	// JSON clears all matching JSON steps
 	JSON(value ...interface{}) IStep
+	// Multipart clears all matching Multipart steps
+	Multipart() IClearSendBodyMultipart
 	// Reader clears all matching Reader steps
 	Reader(value ...io.Reader) IStep
 	// String clears all matching String steps
@@ -108,6 +110,9 @@ func (v *clearSendBody) Int8(value ...int8) IStep {
 func (v *clearSendBody) JSON(value ...interface{}) IStep {
 	return removeStep(v.callPath().Push("JSON", value))
 }
+func (v *clearSendBody) Multipart() IClearSendBodyMultipart {
+	return newClearSendBodyMultipart(v.callPath().Push("Multipart", nil))
+}
 func (v *clearSendBody) Reader(value ...io.Reader) IStep {
 	return removeStep(v.callPath().Push("Reader", readerSliceToInterfaceSlice(value)))
 }
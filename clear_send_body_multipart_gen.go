@@ -0,0 +1,50 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearSendBodyMultipart provides methods to clear steps.
+type IClearSendBodyMultipart interface {
+	IStep
+	// Field clears all matching Field steps
+	Field(value ...interface{}) IStep
+	// File clears all matching File steps
+	File(value ...interface{}) IStep
+	// Part clears all matching Part steps
+	Part(value ...interface{}) IStep
+}
+type clearSendBodyMultipart struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearSendBodyMultipart(cp callPath) IClearSendBodyMultipart {
+	return &clearSendBodyMultipart{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearSendBodyMultipart) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearSendBodyMultipart) when() StepTime {
+	return cleanStep
+}
+func (v *clearSendBodyMultipart) callPath() callPath {
+	return v.cp
+}
+func (v *clearSendBodyMultipart) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearSendBodyMultipart) Field(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Field", value))
+}
+func (v *clearSendBodyMultipart) File(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("File", value))
+}
+func (v *clearSendBodyMultipart) Part(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Part", value))
+}
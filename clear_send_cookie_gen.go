@@ -0,0 +1,40 @@
+// +build !generate
+
+package hit
+
+import errortrace "github.com/Eun/go-hit/errortrace"
+
+// ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
+
+// IClearSendCookie provides methods to clear steps.
+type IClearSendCookie interface {
+	IStep
+	// Set clears all matching Set steps
+	Set(value ...interface{}) IStep
+}
+type clearSendCookie struct {
+	cp callPath
+	tr *errortrace.ErrorTrace
+}
+
+func newClearSendCookie(cp callPath) IClearSendCookie {
+	return &clearSendCookie{cp: cp, tr: ett.Prepare()}
+}
+func (v *clearSendCookie) trace() *errortrace.ErrorTrace {
+	return v.tr
+}
+func (*clearSendCookie) when() StepTime {
+	return cleanStep
+}
+func (v *clearSendCookie) callPath() callPath {
+	return v.cp
+}
+func (v *clearSendCookie) exec(hit *hitImpl) error {
+	if err := removeSteps(hit, v.callPath()); err != nil {
+		return err
+	}
+	return nil
+}
+func (v *clearSendCookie) Set(value ...interface{}) IStep {
+	return removeStep(v.callPath().Push("Set", value))
+}
@@ -2,15 +2,25 @@
 
 package hit
 
-import errortrace "github.com/Eun/go-hit/errortrace"
+import (
+	"net/http"
+
+	errortrace "github.com/Eun/go-hit/errortrace"
+)
 
 // ⚠️⚠️⚠️ This file was autogenerated by generators/clear/clear ⚠️⚠️⚠️ //
 
 // IClearSend provides methods to clear steps.
 type IClearSend interface {
 	IStep
+	// Auth clears all matching Auth steps
+	Auth() IClearSendAuth
 	// Body clears all matching Body steps
 	Body() IClearSendBody
+	// Cookie clears all matching Cookie steps
+	Cookie(value ...*http.Cookie) IStep
+	// Cookies clears all matching Cookies steps
+	Cookies(value ...string) IClearSendCookie
 	// Custom clears all matching Custom steps
 	Custom(value ...Callback) IStep
 	// Headers clears all matching Headers steps
@@ -41,9 +51,18 @@ func (v *clearSend) exec(hit *hitImpl) error {
 	}
 	return nil
 }
+func (v *clearSend) Auth() IClearSendAuth {
+	return newClearSendAuth(v.callPath().Push("Auth", nil))
+}
 func (v *clearSend) Body() IClearSendBody {
 	return newClearSendBody(v.callPath().Push("Body", nil))
 }
+func (v *clearSend) Cookie(value ...*http.Cookie) IStep {
+	return removeStep(v.callPath().Push("Cookie", cookieSliceToInterfaceSlice(value)))
+}
+func (v *clearSend) Cookies(value ...string) IClearSendCookie {
+	return newClearSendCookie(v.callPath().Push("Cookies", stringSliceToInterfaceSlice(value)))
+}
 func (v *clearSend) Custom(value ...Callback) IStep {
 	return removeStep(v.callPath().Push("Custom", callbackSliceToInterfaceSlice(value)))
 }
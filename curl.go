@@ -0,0 +1,65 @@
+package hit
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/Eun/go-hit/internal/curl"
+)
+
+// IncludeCurlInError includes the Request as a curl command line in the error output if the execution fails.
+// Credentials set by Send().Auth() and the password of the url will be redacted.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com"),
+//         IncludeCurlInError(),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+func IncludeCurlInError() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("IncludeCurlInError", nil),
+		Exec: func(hit *hitImpl) error {
+			hit.includeCurlInError = true
+			return nil
+		},
+	}
+}
+
+// curlCommand renders the current request as a curl command line.
+func curlCommand(hit Hit) (string, error) {
+	return renderCurlCommand(hit.Request(), hit.Request().URL, hit.Request().Header)
+}
+
+// redactedCurlCommand renders the current request as a curl command line, credentials will be redacted.
+func redactedCurlCommand(hit *hitImpl) (string, error) {
+	return renderCurlCommand(hit.Request(), redactURL(hit.Request().URL), hit.redactedRequestHeader())
+}
+
+func renderCurlCommand(req *HTTPRequest, u *url.URL, header http.Header) (string, error) {
+	body, err := req.Body().Bytes()
+	if err != nil {
+		return "", err
+	}
+	// use a shallow copy, so the header of the actual request will not be modified
+	r := req.Request.WithContext(req.Context())
+	r.URL = u
+	r.Header = header
+	return curl.Command(r, body), nil
+}
+
+// curlErrorContext returns the curl command line that should be included in the error output, it returns an empty
+// string if IncludeCurlInError() was not used.
+func curlErrorContext(hit Hit) string {
+	h, ok := hit.(*hitImpl)
+	if !ok || !h.includeCurlInError || h.request == nil {
+		return ""
+	}
+	s, err := redactedCurlCommand(h)
+	if err != nil {
+		return ""
+	}
+	return s
+}
@@ -55,11 +55,14 @@ func newDebug(cp callPath, w io.Writer) IDebug {
 	}
 }
 
-func (d *debug) out(*hitImpl) io.Writer {
-	if d.w == nil {
-		return os.Stdout
+func (d *debug) out(hit *hitImpl) io.Writer {
+	if d.w != nil {
+		return d.w
 	}
-	return d.w
+	if hit.stdout != nil {
+		return hit.stdout
+	}
+	return os.Stdout
 }
 func (*debug) trace() *errortrace.ErrorTrace {
 	return nil
@@ -63,6 +63,14 @@ type IDebugBody interface {
 
 	// Uint64 prints the body contents as a uint64 type
 	Uint64() IStep
+
+	// XML prints the body contents in the XML format (indented)
+	//
+	// given the following body: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Debug().Request().Body().XML()                                 // print the whole body
+	//     Debug().Response().Body().XML().XPath("/feed/entry[1]/title") // print <title>Hello</title>
+	XML() IDebugBodyXML
 }
 
 type debugBodyMode int
@@ -288,3 +296,7 @@ func (s *debugBody) Uint64() IStep {
 		},
 	}
 }
+
+func (s *debugBody) XML() IDebugBodyXML {
+	return newDebugBodyXML(s.callPath().Push("XML", nil), s.debug, s.mode)
+}
@@ -0,0 +1,100 @@
+package hit
+
+import (
+	"io"
+	"strings"
+
+	"github.com/Eun/go-hit/errortrace"
+	"github.com/Eun/go-hit/httpbody"
+	"github.com/Eun/go-hit/internal/xpath"
+)
+
+// IDebugBodyXML defines the debug functions that are available for the http request/response body in XML format.
+type IDebugBodyXML interface {
+	IStep
+	// XPath evaluates an XPath 1.0 expression on the XML body and prints the result
+	//
+	// given the following body: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Debug().Response().Body().XML().XPath("/feed/entry[1]/title") // print <title>Hello</title>
+	//     Debug().Response().Body().XML().XPath("count(//entry)")       // print 1
+	XPath(expression string) IStep
+}
+
+type debugBodyXML struct {
+	cp    callPath
+	debug *debug
+	mode  debugBodyMode
+	// et is used to report bodies that are not xml
+	et *errortrace.ErrorTrace
+}
+
+func newDebugBodyXML(cp callPath, debug *debug, mode debugBodyMode) IDebugBodyXML {
+	return &debugBodyXML{
+		cp:    cp,
+		debug: debug,
+		mode:  mode,
+		et:    ett.Prepare(),
+	}
+}
+
+func (s *debugBodyXML) trace() *errortrace.ErrorTrace {
+	return s.et
+}
+
+func (s *debugBodyXML) body(hit Hit) *httpbody.HTTPBody {
+	if s.mode == debugBodyRequest {
+		return hit.Request().Body()
+	}
+	return hit.Response().Body()
+}
+
+func (s *debugBodyXML) when() StepTime {
+	return BeforeExpectStep
+}
+
+func (s *debugBodyXML) callPath() callPath {
+	return s.cp
+}
+
+func (s *debugBodyXML) exec(hit *hitImpl) error {
+	doc, err := xpath.Parse(s.body(hit).Reader())
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(s.debug.out(hit), xpath.Indent(doc)+"\n")
+	return err
+}
+
+func (s *debugBodyXML) XPath(expression string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     BeforeExpectStep,
+		CallPath: nil,
+		Exec: func(hit *hitImpl) error {
+			expr, err := xpath.Compile(expression)
+			if err != nil {
+				return err
+			}
+			doc, err := xpath.Parse(s.body(hit).Reader())
+			if err != nil {
+				return err
+			}
+			result, err := expr.Evaluate(doc)
+			if err != nil {
+				return err
+			}
+
+			ns, ok := result.(xpath.NodeSet)
+			if !ok {
+				return s.debug.print(s.debug.out(hit), xpath.String(result))
+			}
+			nodes := make([]string, len(ns))
+			for i, n := range ns {
+				nodes[i] = xpath.Indent(n)
+			}
+			_, err = io.WriteString(s.debug.out(hit), strings.Join(nodes, "\n")+"\n")
+			return err
+		},
+	}
+}
@@ -48,7 +48,7 @@ func (d *debugHeader) exec(hit *hitImpl) error {
 	var headers http.Header
 	switch d.mode {
 	case debugHeaderRequest:
-		headers = hit.Request().Header
+		headers = hit.redactedRequestHeader()
 	case debugHeaderResponse:
 		headers = hit.Response().Header
 	case debugTrailerRequest:
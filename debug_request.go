@@ -14,7 +14,7 @@ type IDebugRequest interface {
 	// Method prints the Request's Method
 	Method() IStep
 
-	// URL prints the Request's URL
+	// URL prints the Request's URL, the password of the url will be redacted.
 	//
 	// The argument can be used to narrow down the print path
 	//
@@ -71,6 +71,13 @@ type IDebugRequest interface {
 	//     Debug().Request().Body().JSON().JQ(".Roles")     // will print ["Admin", "User"]
 	//     Debug().Request().Body().JSON().JQ(".Roles.0")   // will print "Admin"
 	Body() IDebugBody
+
+	// Curl prints the Request as a curl command line.
+	// Credentials set by Send().Auth() and the password of the url will be redacted.
+	//
+	// Usage:
+	//     Debug().Request().Curl()
+	Curl() IStep
 }
 
 type debugRequest struct {
@@ -93,10 +100,10 @@ func (*debugRequest) when() StepTime {
 	return BeforeExpectStep
 }
 
-func (d *debugRequest) data(hit Hit) map[string]interface{} {
+func (d *debugRequest) data(hit *hitImpl) map[string]interface{} {
 	var urlData map[string]interface{}
 
-	if u := hit.Request().URL; u != nil {
+	if u := redactURL(hit.Request().URL); u != nil {
 		urlData = make(map[string]interface{})
 		urlData["Scheme"] = u.Scheme
 		urlData["Opaque"] = u.Opaque
@@ -134,7 +141,7 @@ func (d *debugRequest) data(hit Hit) map[string]interface{} {
 		"ContentLength":    hit.Request().ContentLength,
 		"TransferEncoding": hit.Request().TransferEncoding,
 		"Host":             hit.Request().Host,
-		"Headers":          d.debug.getMap(hit.Request().Header),
+		"Headers":          d.debug.getMap(hit.redactedRequestHeader()),
 		"Trailers":         d.debug.getMap(hit.Request().Trailer),
 		"Body":             hit.Request().Body().GetBestFittingObject(),
 	}
@@ -166,7 +173,7 @@ func (d *debugRequest) URL() IStep {
 		When:     BeforeExpectStep,
 		CallPath: d.cp.Push("URL", nil),
 		Exec: func(hit *hitImpl) error {
-			return d.debug.print(d.debug.out(hit), hit.Request().URL)
+			return d.debug.print(d.debug.out(hit), redactURL(hit.Request().URL))
 		},
 	}
 }
@@ -259,3 +266,19 @@ func (d *debugRequest) Trailers(trailer ...string) IStep {
 func (d *debugRequest) Body() IDebugBody {
 	return newDebugBody(d.cp.Push("Body", nil), d.debug, debugBodyRequest)
 }
+
+func (d *debugRequest) Curl() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     BeforeExpectStep,
+		CallPath: d.cp.Push("Curl", nil),
+		Exec: func(hit *hitImpl) error {
+			s, err := redactedCurlCommand(hit)
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(d.debug.out(hit), s+"\n")
+			return err
+		},
+	}
+}
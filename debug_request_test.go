@@ -306,3 +306,21 @@ func TestDebugRequest_Trailer(t *testing.T) {
 		)
 	})
 }
+
+func TestDebugRequest_Curl(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	buf := bytes.NewBuffer(nil)
+
+	Test(t,
+		Post(s.URL),
+		Send().Headers("Content-Type").Add("text/plain"),
+		Send().Body().String("Hello World"),
+		Fdebug(buf).Request().Curl(),
+	)
+
+	b, err := ioutil.ReadAll(vtclean.NewReader(buf, false))
+	require.NoError(t, err)
+	require.Equal(t, `curl -X POST `+s.URL+` -H 'Content-Type: text/plain' -H User-Agent: --data-binary 'Hello World'`+"\n", string(b))
+}
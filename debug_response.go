@@ -63,6 +63,12 @@ type IDebugResponse interface {
 	//     Debug().Request().Body().JSON().JQ(".Roles")   // will print ["Admin", "User"]
 	//     Debug().Request().Body().JSON().JQ(".Roles.0") // will print "Admin"
 	Body() IDebugBody
+
+	// Timing prints the timing breakdown of the request
+	//
+	// Usage:
+	//     Debug().Response().Timing()
+	Timing() IStep
 }
 
 type debugResponse struct {
@@ -210,3 +216,22 @@ func (d *debugResponse) Trailers(trailer ...string) IStep {
 func (d *debugResponse) Body() IDebugBody {
 	return newDebugBody(d.cp.Push("Body", nil), d.debug, debugBodyResponse)
 }
+
+func (d *debugResponse) Timing() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     BeforeExpectStep,
+		CallPath: d.cp.Push("Timing", nil),
+		Exec: func(hit *hitImpl) error {
+			t := hit.Response().Timing()
+			return d.debug.print(d.debug.out(hit), map[string]string{
+				"DNS":             t.DNS.String(),
+				"Connect":         t.Connect.String(),
+				"TLSHandshake":    t.TLSHandshake.String(),
+				"TimeToFirstByte": t.TimeToFirstByte.String(),
+				"BodyRead":        t.BodyRead.String(),
+				"Total":           t.Total.String(),
+			})
+		},
+	}
+}
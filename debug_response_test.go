@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	. "github.com/Eun/go-hit"
 
@@ -163,6 +164,59 @@ func TestDebugResponse_Body(t *testing.T) {
 
 		require.Equal(t, []interface{}{1.0, 2.0, 3.0}, m)
 	})
+
+	t.Run("xml", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`<feed><entry id="1"><title>Hello</title></entry><!-- end --></feed>`),
+			Fdebug(buf).Response().Body().XML(),
+		)
+
+		require.Equal(t, `<feed>
+  <entry id="1">
+    <title>Hello</title>
+  </entry>
+  <!-- end -->
+</feed>
+`, buf.String())
+	})
+
+	t.Run("xml xpath", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`<feed><entry id="1"><title>Hello</title></entry><entry id="2"/></feed>`),
+			Fdebug(buf).Response().Body().XML().XPath("//entry"),
+		)
+
+		require.Equal(t, `<entry id="1">
+  <title>Hello</title>
+</entry>
+<entry id="2"/>
+`, buf.String())
+
+		buf.Reset()
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`<feed><entry id="1"><title>Hello</title></entry><entry id="2"/></feed>`),
+			Fdebug(buf).Response().Body().XML().XPath("count(//entry)"),
+		)
+		require.Equal(t, "2", buf.String())
+	})
+
+	t.Run("invalid xml", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String("Hello World"),
+				Fdebug(ioutil.Discard).Response().Body().XML(),
+			),
+			PtrStr("unable to parse xml: document has no root element"),
+		)
+	})
 }
 
 func TestDebugResponse_Header(t *testing.T) {
@@ -264,3 +318,23 @@ func TestDebugResponse_Trailer(t *testing.T) {
 		)
 	})
 }
+
+func TestDebugResponse_Timing(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	buf := bytes.NewBuffer(nil)
+
+	Test(t,
+		Post(s.URL),
+		Fdebug(buf).Response().Timing(),
+	)
+
+	var m map[string]string
+	require.NoError(t, json.NewDecoder(vtclean.NewReader(buf, false)).Decode(&m))
+	require.Len(t, m, 6)
+	for _, key := range []string{"DNS", "Connect", "TLSHandshake", "TimeToFirstByte", "BodyRead", "Total"} {
+		_, err := time.ParseDuration(m[key])
+		require.NoError(t, err, key)
+	}
+}
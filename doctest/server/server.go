@@ -176,6 +176,25 @@ func NewServer() *Server {
 		}
 	})
 
+	r.HandleFunc("/xml", func(writer http.ResponseWriter, request *http.Request) {
+		s.mu.Lock()
+		s.requestCount++
+		s.mu.Unlock()
+		writer.Header()["Date"] = nil
+		for k, v := range request.Header {
+			writer.Header()[k] = v
+		}
+
+		writer.Header().Set("Content-Type", "application/xml")
+
+		writer.WriteHeader(http.StatusOK)
+
+		n, _ := io.Copy(writer, request.Body)
+		if n == 0 {
+			_, _ = io.WriteString(writer, `<feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>`)
+		}
+	})
+
 	// this endpoint should mimic httpbin.org/post
 	r.HandleFunc("/post", func(writer http.ResponseWriter, request *http.Request) {
 		s.mu.Lock()
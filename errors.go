@@ -1,25 +1,52 @@
 package hit
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/Eun/go-hit/errortrace"
 )
 
 // Error represents the error that will be returned during an execution.
 type Error struct {
-	callPath callPath
-	et       *errortrace.ErrorTrace
+	callPath  callPath
+	et        *errortrace.ErrorTrace
+	collected []*Error
 }
 
 // Error returns the string representation for the error.
 func (e *Error) Error() string {
-	return e.et.Error()
+	if len(e.collected) == 0 {
+		return e.et.Error()
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d steps failed:\n", len(e.collected))
+	for _, err := range e.collected {
+		sb.WriteString(err.et.Error())
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// Errors returns all failures that were collected with CollectAllFailures(), if CollectAllFailures() was not used
+// it returns only the error itself.
+func (e *Error) Errors() []*Error {
+	if len(e.collected) == 0 {
+		return []*Error{e}
+	}
+	return e.collected
 }
 
 // Implement xerrors
 
 // Is implements the xerrors interface so we can use the xerrors.Is() function.
 func (e *Error) Is(err error) bool {
-	return e.et == err
+	for _, failure := range e.Errors() {
+		if failure.et == err {
+			return true
+		}
+	}
+	return false
 }
 
 // Unwrap implements the xerrors.Wrapper interface.
@@ -42,20 +69,42 @@ func (e *Error) Unwrap() error {
 //         }
 //     }
 func (e *Error) FailingStepIs(s IStep) bool {
-	if e.callPath == nil || s == nil {
+	if s == nil {
 		return false
 	}
 	cp := s.callPath()
 	if cp == nil {
 		return false
 	}
-	return e.callPath.Equal(cp)
+	for _, err := range e.Errors() {
+		if err.callPath != nil && err.callPath.Equal(cp) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectedError creates an error that contains all specified errors, it returns nil if no errors were passed.
+func collectedError(errs []*Error) *Error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return &Error{
+			callPath:  errs[0].callPath,
+			et:        errs[0].et,
+			collected: errs,
+		}
+	}
 }
 
 func wrapError(hit Hit, err error) *Error {
 	t := ett.Prepare()
 	t.SetError(err)
 	t.SetDescription(hit.Description())
+	t.SetContext(curlErrorContext(hit))
 	return &Error{
 		callPath: nil,
 		et:       t,
@@ -2,6 +2,7 @@ package hit_test
 
 import (
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,3 +26,83 @@ func TestError_FailingStepIs(t *testing.T) {
 	require.False(t, hitError.FailingStepIs(Expect().Body().Int8().Equal(16)))
 	require.True(t, hitError.FailingStepIs(Expect().Body().Int8().Equal(17)))
 }
+
+func TestCollectAllFailures(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("collect all failures", func(t *testing.T) {
+		calledAfterExpect := false
+		err := Do(
+			Post(s.URL),
+			CollectAllFailures(),
+			Send().Headers("Content-Type").Add("text/plain"),
+			Send().Body().String("Hello World"),
+			Expect().Status().Equal(http.StatusNotFound),
+			Expect().Headers("Content-Type").Equal("application/json"),
+			Expect().Body().String().Equal("Hello World"),
+			Expect().Body().String().Equal("Hello Universe"),
+			Custom(AfterExpectStep, func(Hit) error {
+				calledAfterExpect = true
+				return nil
+			}),
+		)
+		require.True(t, calledAfterExpect)
+
+		var hitError *Error
+		require.True(t, errors.As(err, &hitError))
+		require.Len(t, hitError.Errors(), 3)
+		require.True(t, hitError.FailingStepIs(Expect().Status().Equal(http.StatusNotFound)))
+		require.True(t, hitError.FailingStepIs(Expect().Headers("Content-Type").Equal("application/json")))
+		require.True(t, hitError.FailingStepIs(Expect().Body().String().Equal("Hello Universe")))
+		require.False(t, hitError.FailingStepIs(Expect().Body().String().Equal("Hello World")))
+
+		require.Contains(t, err.Error(), "3 steps failed")
+		ExpectError(t, hitError.Errors()[0], PtrStr("not equal"), PtrStr("expected: 404"), nil, nil, nil, nil, nil)
+		ExpectError(t, hitError.Errors()[2], PtrStr("not equal"), PtrStr(`expected: "Hello Universe"`), nil, nil, nil, nil, nil)
+	})
+
+	t.Run("single failure", func(t *testing.T) {
+		err := Do(
+			Post(s.URL),
+			CollectAllFailures(),
+			Send().Body().String("Hello World"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("Hello Universe"),
+		)
+		var hitError *Error
+		require.True(t, errors.As(err, &hitError))
+		require.Len(t, hitError.Errors(), 1)
+		require.True(t, hitError.FailingStepIs(Expect().Body().String().Equal("Hello Universe")))
+	})
+
+	t.Run("send failures are not collected", func(t *testing.T) {
+		calledExpect := false
+		err := Do(
+			Post(s.URL),
+			CollectAllFailures(),
+			Send().Custom(func(Hit) error {
+				return errors.New("send failed")
+			}),
+			Expect().Custom(func(Hit) error {
+				calledExpect = true
+				return nil
+			}),
+		)
+		ExpectError(t, err, PtrStr("send failed"))
+		require.False(t, calledExpect)
+	})
+
+	t.Run("without CollectAllFailures", func(t *testing.T) {
+		err := Do(
+			Post(s.URL),
+			Send().Body().String("Hello World"),
+			Expect().Status().Equal(http.StatusNotFound),
+			Expect().Body().String().Equal("Hello Universe"),
+		)
+		var hitError *Error
+		require.True(t, errors.As(err, &hitError))
+		require.Len(t, hitError.Errors(), 1)
+		require.False(t, hitError.FailingStepIs(Expect().Body().String().Equal("Hello Universe")))
+	})
+}
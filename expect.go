@@ -3,6 +3,9 @@ package hit
 import (
 	"io"
 	"io/ioutil"
+	"time"
+
+	"github.com/Eun/go-hit/httpbody"
 )
 
 // IExpect provides assertions on the http response.
@@ -34,6 +37,30 @@ type IExpect interface {
 	//     )
 	Body() IExpectBody
 
+	// Cookies provides assertions on a cookie that was set by the response.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Exists()
+	//     Expect().Cookies("session").Value().Len().GreaterThan(0)
+	//     Expect().Cookies("session").Path().Equal("/")
+	//     Expect().Cookies("session").Secure()
+	//     Expect().Cookies("session").HttpOnly()
+	//     Expect().Cookies("session").SameSite().Equal("Strict")
+	Cookies(name string) IExpectCookie
+
+	// Duration provides assertions on the total duration of the request, from sending the request until the response
+	// body was read completely.
+	//
+	// Usage:
+	//     Expect().Duration().LessThan(500 * time.Millisecond)
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com"),
+	//         Expect().Duration().LessThan(5 * time.Second),
+	//     )
+	Duration() IExpectDuration
+
 	// Headers provides assertions to one specific response headers.
 	//
 	// If you specify the argument you can directly assert a specific header
@@ -41,6 +68,7 @@ type IExpect interface {
 	// Usage:
 	//     Expect().Headers("Content-Type").NotEmpty()
 	//     Expect().Headers("Content-Type").Equal("application/json")
+	//     Expect().Headers("Content-Type").MatchSnapshot("get-user-content-type")
 	//
 	// Example:
 	//     MustDo(
@@ -50,6 +78,31 @@ type IExpect interface {
 	//     )
 	Headers(headerName string) IExpectHeaders
 
+	// AllHeaders provides assertions to all response headers, the assertions apply to the names of the headers
+	// (e.g. Expect().AllHeaders().Contains("Content-Type") expects the header to be present) and MatchSnapshot() will
+	// snapshot all headers.
+	//
+	// Usage:
+	//     Expect().AllHeaders().Contains("Content-Type")
+	//     Expect().AllHeaders().MatchSnapshot("get-user-headers", ".Date")
+	AllHeaders() IExpectHeaders
+
+	// Redirects provides assertions on the redirects that were followed to get the response.
+	//
+	// Usage:
+	//     Expect().Redirects().Count().Equal(1)
+	//     Expect().Redirects().Nth(0).Status().Equal(http.StatusFound)
+	//     Expect().Redirects().Final().URL().Equal("https://example.com/cookies")
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com/cookies/set/session/abc123"),
+	//         Expect().Redirects().Count().Equal(1),
+	//         Expect().Redirects().Nth(0).URL().Equal("https://example.com/cookies/set/session/abc123"),
+	//         Expect().Redirects().Final().URL().Equal("https://example.com/cookies"),
+	//     )
+	Redirects() IExpectRedirects
+
 	// Status provides assertions to the response status code
 	//
 	// Usage:
@@ -62,6 +115,37 @@ type IExpect interface {
 	//     )
 	Status() IExpectInt64
 
+	// TLS provides assertions on the tls connection the response was received over.
+	//
+	// Usage:
+	//     Expect().TLS().Version().Equal("TLS 1.3")
+	//     Expect().TLS().NegotiatedProtocol().Equal("h2")
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Contains("example.com")
+	//     Expect().TLS().PeerCertificate(0).NotAfter().After(time.Now().Add(30 * 24 * time.Hour))
+	//     Expect().TLS().Verified()
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com"),
+	//         Expect().TLS().Version().OneOf("TLS 1.2", "TLS 1.3"),
+	//         Expect().TLS().PeerCertificate(0).Subject().Equal("O=example.com"),
+	//     )
+	TLS() IExpectTLS
+
+	// Timing provides assertions on the timing breakdown of the request.
+	//
+	// Usage:
+	//     Expect().Timing().DNS().LessThan(100 * time.Millisecond)
+	//     Expect().Timing().TimeToFirstByte().LessThan(500 * time.Millisecond)
+	//     Expect().Timing().Total().LessThan(time.Second)
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com"),
+	//         Expect().Timing().TimeToFirstByte().LessThan(5 * time.Second),
+	//     )
+	Timing() IExpectTiming
+
 	// Trailers provides assertions to the response trailers.
 	//
 	// If you specify the argument you can directly assert a specific trailer
@@ -101,25 +185,62 @@ func (exp *expect) Custom(fn Callback) IStep {
 }
 
 func (exp *expect) Body() IExpectBody {
-	return newExpectBody(exp, exp.cleanPath.Push("Body", nil))
+	return newExpectBody(exp, exp.cleanPath.Push("Body", nil), func(hit Hit) *httpbody.HTTPBody {
+		return hit.Response().Body()
+	})
+}
+
+func (exp *expect) Cookies(name string) IExpectCookie {
+	return newExpectCookie(exp.cleanPath.Push("Cookies", []interface{}{name}), name)
+}
+
+func (exp *expect) Duration() IExpectDuration {
+	return newExpectDuration(exp.cleanPath.Push("Duration", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().Total
+	})
 }
 
 func (exp *expect) Headers(headerName string) IExpectHeaders {
 	return newExpectHeader(exp.cleanPath.Push("Headers", []interface{}{headerName}), func(hit Hit) []string {
 		return hit.Response().Header.Values(headerName)
+	}, func(hit Hit) interface{} {
+		return hit.Response().Header.Values(headerName)
 	})
 }
 
+func (exp *expect) AllHeaders() IExpectHeaders {
+	return newExpectHeader(exp.cleanPath.Push("AllHeaders", nil), func(hit Hit) []string {
+		return headerNames(hit.Response().Header)
+	}, func(hit Hit) interface{} {
+		return hit.Response().Header
+	})
+}
+
+func (exp *expect) Redirects() IExpectRedirects {
+	return newExpectRedirects(exp.cleanPath.Push("Redirects", nil))
+}
+
 func (exp *expect) Status() IExpectInt64 {
 	return newExpectInt64(exp.cleanPath.Push("Status", nil), func(hit Hit) int64 {
 		return int64(hit.Response().StatusCode)
 	})
 }
 
+func (exp *expect) TLS() IExpectTLS {
+	return newExpectTLS(exp.cleanPath.Push("TLS", nil))
+}
+
+func (exp *expect) Timing() IExpectTiming {
+	return newExpectTiming(exp.cleanPath.Push("Timing", nil))
+}
+
 func (exp *expect) Trailers(trailerName string) IExpectHeaders {
 	return newExpectHeader(exp.cleanPath.Push("Trailers", []interface{}{trailerName}), func(hit Hit) []string {
 		// we have to read the body to get the trailers
 		_, _ = io.Copy(ioutil.Discard, hit.Response().Body().Reader())
 		return hit.Response().Trailer.Values(trailerName)
+	}, func(hit Hit) interface{} {
+		_, _ = io.Copy(ioutil.Discard, hit.Response().Body().Reader())
+		return hit.Response().Trailer.Values(trailerName)
 	})
 }
@@ -1,5 +1,7 @@
 package hit
 
+import "github.com/Eun/go-hit/httpbody"
+
 // IExpectBody provides assertions on the http response body.
 type IExpectBody interface {
 	// Bytes expects the body to be equal the specified byte slice.
@@ -72,6 +74,25 @@ type IExpectBody interface {
 	//     Expect().Body().JSON().JQ(".Name").Equal("Joe")
 	JSON() IExpectBodyJSON
 
+	// MatchSnapshot expects the body to be equal to the snapshot with the specified name.
+	// The snapshot is stored in SnapshotDir (testdata/__snapshots__/name.snap), it will be created if it does not exist
+	// and rewritten if UpdateSnapshots is set (e.g. by setting the environment variable HIT_UPDATE_SNAPSHOTS=1).
+	// JSON bodies will be compared structurally, redact can be used to mask volatile values (e.g. timestamps or ids)
+	// with jq paths before the comparison.
+	//
+	// Usage:
+	//     Expect().Body().MatchSnapshot("get-user")
+	//     Expect().Body().MatchSnapshot("list-users", ".[].ID", ".[].CreatedAt")
+	MatchSnapshot(name string, redact ...string) IStep
+
+	// Multipart expects the body to be a multipart body (e.g. multipart/mixed or multipart/byteranges).
+	//
+	// Usage:
+	//     Expect().Body().Multipart().Len().Equal(2)
+	//     Expect().Body().Multipart().Part(0).Headers("Content-Type").Equal("application/json")
+	//     Expect().Body().Multipart().Part(0).Body().JSON().JQ(".Name").Equal("Joe")
+	Multipart() IExpectBodyMultipart
+
 	// String expects the body to be equal the specified string.
 	//
 	// Usage:
@@ -113,71 +134,83 @@ type IExpectBody interface {
 	//     Expect().Body().Uint64().Equal(0)
 	//     Expect().Body().Uint64().GreaterThan(5)
 	Uint64() IExpectUint64
+
+	// XML provides assertions for the body in the XML format
+	//
+	// Usage:
+	//     Expect().Body().XML().Equal(`<feed><entry id="1"><title>Hello</title></entry></feed>`)
+	//     Expect().Body().XML().Contains(`<entry id="1"/>`)
+	//     Expect().Body().XML().XPath("/feed/entry[1]/title").Equal("Hello")
+	XML() IExpectBodyXML
 }
 
+type expectBodyCallback func(hit Hit) *httpbody.HTTPBody
+
 type expectBody struct {
-	expect    IExpect
-	cleanPath callPath
+	expect       IExpect
+	cleanPath    callPath
+	bodyCallback expectBodyCallback
 }
 
-func newExpectBody(expect IExpect, cleanPath callPath) IExpectBody {
+func newExpectBody(expect IExpect, cleanPath callPath, bodyCallback expectBodyCallback) *expectBody {
 	return &expectBody{
-		expect:    expect,
-		cleanPath: cleanPath,
+		expect:       expect,
+		cleanPath:    cleanPath,
+		bodyCallback: bodyCallback,
 	}
 }
 
 func (body *expectBody) Bytes() IExpectBytes {
 	return newExpectBytes(body.cleanPath.Push("Bytes", nil), func(hit Hit) []byte {
-		return hit.Response().Body().MustBytes()
+		return body.bodyCallback(hit).MustBytes()
 	})
 }
 
 func (body *expectBody) FormValues(name string) IExpectFormValues {
 	return newExpectFormValues(body.cleanPath.Push("FormValues", []interface{}{name}), func(hit Hit) []string {
-		return hit.Response().Body().MustFormValues().Values(name)
+		return body.bodyCallback(hit).MustFormValues().Values(name)
 	})
 }
 
 func (body *expectBody) Float32() IExpectFloat32 {
 	return newExpectFloat32(body.cleanPath.Push("Float32", nil), func(hit Hit) float32 {
-		return hit.Response().Body().MustFloat32()
+		return body.bodyCallback(hit).MustFloat32()
 	})
 }
 
 func (body *expectBody) Float64() IExpectFloat64 {
 	return newExpectFloat64(body.cleanPath.Push("Float64", nil), func(hit Hit) float64 {
-		return hit.Response().Body().MustFloat64()
+		return body.bodyCallback(hit).MustFloat64()
 	})
 }
 
 func (body *expectBody) Int() IExpectInt {
 	return newExpectInt(body.cleanPath.Push("Int", nil), func(hit Hit) int {
-		return hit.Response().Body().MustInt()
+		return body.bodyCallback(hit).MustInt()
 	})
 }
 
 func (body *expectBody) Int8() IExpectInt8 {
 	return newExpectInt8(body.cleanPath.Push("Int8", nil), func(hit Hit) int8 {
-		return hit.Response().Body().MustInt8()
+		return body.bodyCallback(hit).MustInt8()
 	})
 }
 
 func (body *expectBody) Int16() IExpectInt16 {
 	return newExpectInt16(body.cleanPath.Push("Int16", nil), func(hit Hit) int16 {
-		return hit.Response().Body().MustInt16()
+		return body.bodyCallback(hit).MustInt16()
 	})
 }
 
 func (body *expectBody) Int32() IExpectInt32 {
 	return newExpectInt32(body.cleanPath.Push("Int32", nil), func(hit Hit) int32 {
-		return hit.Response().Body().MustInt32()
+		return body.bodyCallback(hit).MustInt32()
 	})
 }
 
 func (body *expectBody) Int64() IExpectInt64 {
 	return newExpectInt64(body.cleanPath.Push("Int64", nil), func(hit Hit) int64 {
-		return hit.Response().Body().MustInt64()
+		return body.bodyCallback(hit).MustInt64()
 	})
 }
 
@@ -185,38 +218,61 @@ func (body *expectBody) JSON() IExpectBodyJSON {
 	return newExpectBodyJSON(body, body.cleanPath.Push("JSON", nil))
 }
 
+func (body *expectBody) MatchSnapshot(name string, redact ...string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: body.cleanPath.Push("MatchSnapshot", append([]interface{}{name}, stringSliceToInterfaceSlice(redact)...)),
+		Exec: func(hit *hitImpl) error {
+			data, err := body.bodyCallback(hit).Bytes()
+			if err != nil {
+				return err
+			}
+			return matchSnapshot(name, data, redact)
+		},
+	}
+}
+
+func (body *expectBody) Multipart() IExpectBodyMultipart {
+	return newExpectBodyMultipart(body, body.cleanPath.Push("Multipart", nil))
+}
+
 func (body *expectBody) String() IExpectString {
 	return newExpectString(body.cleanPath.Push("String", nil), func(hit Hit) string {
-		return hit.Response().Body().MustString()
+		return body.bodyCallback(hit).MustString()
 	})
 }
 
 func (body *expectBody) Uint() IExpectUint {
 	return newExpectUint(body.cleanPath.Push("Uint", nil), func(hit Hit) uint {
-		return hit.Response().Body().MustUint()
+		return body.bodyCallback(hit).MustUint()
 	})
 }
 
 func (body *expectBody) Uint8() IExpectUint8 {
 	return newExpectUint8(body.cleanPath.Push("Uint8", nil), func(hit Hit) uint8 {
-		return hit.Response().Body().MustUint8()
+		return body.bodyCallback(hit).MustUint8()
 	})
 }
 
 func (body *expectBody) Uint16() IExpectUint16 {
 	return newExpectUint16(body.cleanPath.Push("Uint16", nil), func(hit Hit) uint16 {
-		return hit.Response().Body().MustUint16()
+		return body.bodyCallback(hit).MustUint16()
 	})
 }
 
 func (body *expectBody) Uint32() IExpectUint32 {
 	return newExpectUint32(body.cleanPath.Push("Uint32", nil), func(hit Hit) uint32 {
-		return hit.Response().Body().MustUint32()
+		return body.bodyCallback(hit).MustUint32()
 	})
 }
 
 func (body *expectBody) Uint64() IExpectUint64 {
 	return newExpectUint64(body.cleanPath.Push("Uint64", nil), func(hit Hit) uint64 {
-		return hit.Response().Body().MustUint64()
+		return body.bodyCallback(hit).MustUint64()
 	})
 }
+
+func (body *expectBody) XML() IExpectBodyXML {
+	return newExpectBodyXML(body, body.cleanPath.Push("XML", nil))
+}
@@ -2,9 +2,11 @@ package hit
 
 import (
 	"reflect"
+	"strings"
 
 	"golang.org/x/xerrors"
 
+	"github.com/Eun/go-hit/internal/jsonschema"
 	"github.com/Eun/go-hit/internal/minitest"
 )
 
@@ -48,14 +50,39 @@ type IExpectBodyJSON interface {
 	// Usage:
 	//     Expect().Body().JSON().Len().Equal(10)
 	Len() IExpectInt
+
+	// Schema expects the json body to be valid against the specified JSON Schema (draft 2020-12).
+	// The schema can be a Go value (e.g. map[string]interface{}), a string containing the schema or a path to a
+	// schema file.
+	//
+	// Usage:
+	//     Expect().Body().JSON().Schema(map[string]interface{}{"type": "object", "required": []string{"ID"}})
+	//     Expect().Body().JSON().Schema(`{"type": "object", "required": ["ID"]}`)
+	//     Expect().Body().JSON().Schema("testdata/user.schema.json")
+	//
+	// Example:
+	//     // given the following response: { "ID": 10, "Name": "Joe", "Roles": ["Admin", "User"] }
+	//     MustDo(
+	//         Get("https://example.com/json"),
+	//         Expect().Body().JSON().Schema(`{
+	//             "type": "object",
+	//             "properties": {
+	//                 "ID": {"type": "integer", "minimum": 1},
+	//                 "Name": {"type": "string"},
+	//                 "Roles": {"type": "array", "items": {"enum": ["Admin", "User"]}}
+	//             },
+	//             "required": ["ID", "Name"]
+	//         }`),
+	//     )
+	Schema(schema interface{}) IStep
 }
 
 type expectBodyJSON struct {
-	expectBody IExpectBody
+	expectBody *expectBody
 	cleanPath  callPath
 }
 
-func newExpectBodyJSON(expectBody IExpectBody, cleanPath callPath) IExpectBodyJSON {
+func newExpectBodyJSON(expectBody *expectBody, cleanPath callPath) IExpectBodyJSON {
 	return &expectBodyJSON{
 		expectBody: expectBody,
 		cleanPath:  cleanPath,
@@ -69,7 +96,7 @@ func (v *expectBodyJSON) Equal(data interface{}) IStep {
 		CallPath: v.cleanPath.Push("Equal", []interface{}{data}),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().Decode(&obj); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().Decode(&obj); err != nil {
 				return err
 			}
 
@@ -85,7 +112,7 @@ func (v *expectBodyJSON) NotEqual(data ...interface{}) IStep {
 		CallPath: v.cleanPath.Push("NotEqual", data),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().Decode(&obj); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().Decode(&obj); err != nil {
 				return err
 			}
 			return minitest.NotEqual(obj, data...)
@@ -100,7 +127,7 @@ func (v *expectBodyJSON) Contains(data ...interface{}) IStep {
 		CallPath: v.cleanPath.Push("Contains", data),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().Decode(&obj); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().Decode(&obj); err != nil {
 				return err
 			}
 			return minitest.Contains(obj, data...)
@@ -115,7 +142,7 @@ func (v *expectBodyJSON) NotContains(data ...interface{}) IStep {
 		CallPath: v.cleanPath.Push("NotContains", data),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().Decode(&obj); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().Decode(&obj); err != nil {
 				return err
 			}
 			return minitest.NotContains(obj, data...)
@@ -130,7 +157,7 @@ func (v *expectBodyJSON) JQ(expression ...string) IExpectBodyJSONJQ {
 func (v *expectBodyJSON) Len() IExpectInt {
 	return newExpectInt(v.cleanPath.Push("Len", nil), func(hit Hit) int {
 		var obj interface{}
-		hit.Response().body.JSON().MustDecode(&obj)
+		v.expectBody.bodyCallback(hit).JSON().MustDecode(&obj)
 		if obj == nil {
 			return 0
 		}
@@ -143,3 +170,38 @@ func (v *expectBodyJSON) Len() IExpectInt {
 		}
 	})
 }
+
+func (v *expectBodyJSON) Schema(schema interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Schema", []interface{}{schema}),
+		Exec: func(hit *hitImpl) error {
+			var obj interface{}
+			if err := v.expectBody.bodyCallback(hit).JSON().Decode(&obj); err != nil {
+				return err
+			}
+			return expectJSONSchema(obj, schema)
+		},
+	}
+}
+
+// expectJSONSchema returns an error that lists all violations if obj is not valid against the schema.
+func expectJSONSchema(obj, schema interface{}) error {
+	s, err := jsonschema.New(schema)
+	if err != nil {
+		return err
+	}
+	violations, err := s.Validate(obj)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	lines := make([]string, len(violations))
+	for i := range violations {
+		lines[i] = violations[i].String()
+	}
+	return xerrors.New("does not match the schema\n" + minitest.Format("violations:", strings.Join(lines, "\n")))
+}
@@ -66,15 +66,35 @@ type IExpectBodyJSONJQ interface {
 
 	// JQ runs an additional jq expression
 	JQ(expression ...string) IExpectBodyJSONJQ
+
+	// Schema expects the jq result to be valid against the specified JSON Schema (draft 2020-12).
+	//
+	// see IExpectBodyJSON.Schema() for the supported schema values
+	//
+	// given the following response: { "ID": 10, "Name": "Joe", "Roles": ["Admin", "User"] }
+	// Usage:
+	//     Expect().Body().JSON().JQ(".Roles").Schema(`{"type": "array", "items": {"type": "string"}}`)
+	//
+	// Example:
+	//     // given the following response: { "ID": 10, "Name": "Joe", "Roles": ["Admin", "User"] }
+	//     MustDo(
+	//         Get("https://example.com/json"),
+	//         Expect().Body().JSON().JQ(".Roles").Schema(map[string]interface{}{
+	//             "type":        "array",
+	//             "items":       map[string]interface{}{"type": "string"},
+	//             "uniqueItems": true,
+	//         }),
+	//     )
+	Schema(schema interface{}) IStep
 }
 
 type expectBodyJSONJQ struct {
-	expectBody IExpectBody
+	expectBody *expectBody
 	cleanPath  callPath
 	expression []string
 }
 
-func newExpectBodyJSONJQ(expectBody IExpectBody, cleanPath callPath, expression []string) IExpectBodyJSONJQ {
+func newExpectBodyJSONJQ(expectBody *expectBody, cleanPath callPath, expression []string) IExpectBodyJSONJQ {
 	return &expectBodyJSONJQ{
 		expectBody: expectBody,
 		cleanPath:  cleanPath,
@@ -89,7 +109,7 @@ func (v *expectBodyJSONJQ) Equal(data interface{}) IStep {
 		CallPath: v.cleanPath.Push("Equal", []interface{}{data}),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().JQ(&obj, v.expression...); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().JQ(&obj, v.expression...); err != nil {
 				return err
 			}
 			return minitest.Equal(obj, data)
@@ -104,7 +124,7 @@ func (v *expectBodyJSONJQ) NotEqual(data ...interface{}) IStep {
 		CallPath: v.cleanPath.Push("NotEqual", data),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().JQ(&obj, v.expression...); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().JQ(&obj, v.expression...); err != nil {
 				return err
 			}
 			return minitest.NotEqual(obj, data...)
@@ -119,7 +139,7 @@ func (v *expectBodyJSONJQ) Contains(data ...interface{}) IStep {
 		CallPath: v.cleanPath.Push("Contains", data),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().JQ(&obj, v.expression...); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().JQ(&obj, v.expression...); err != nil {
 				return err
 			}
 			return minitest.Contains(obj, data...)
@@ -134,7 +154,7 @@ func (v *expectBodyJSONJQ) NotContains(data ...interface{}) IStep {
 		CallPath: v.cleanPath.Push("NotContains", data),
 		Exec: func(hit *hitImpl) error {
 			var obj interface{}
-			if err := hit.Response().body.JSON().JQ(&obj, v.expression...); err != nil {
+			if err := v.expectBody.bodyCallback(hit).JSON().JQ(&obj, v.expression...); err != nil {
 				return err
 			}
 			return minitest.NotContains(obj, data...)
@@ -145,7 +165,7 @@ func (v *expectBodyJSONJQ) NotContains(data ...interface{}) IStep {
 func (v *expectBodyJSONJQ) Len() IExpectInt {
 	return newExpectInt(v.cleanPath.Push("Len", nil), func(hit Hit) int {
 		var obj interface{}
-		hit.Response().body.JSON().MustJQ(&obj, v.expression...)
+		v.expectBody.bodyCallback(hit).JSON().MustJQ(&obj, v.expression...)
 		if obj == nil {
 			return 0
 		}
@@ -165,3 +185,18 @@ func (v *expectBodyJSONJQ) JQ(expression ...string) IExpectBodyJSONJQ {
 		v.cleanPath.Push("JQ", stringSliceToInterfaceSlice(expression)), append(v.expression, expression...),
 	)
 }
+
+func (v *expectBodyJSONJQ) Schema(schema interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Schema", []interface{}{schema}),
+		Exec: func(hit *hitImpl) error {
+			var obj interface{}
+			if err := v.expectBody.bodyCallback(hit).JSON().JQ(&obj, v.expression...); err != nil {
+				return err
+			}
+			return expectJSONSchema(obj, schema)
+		},
+	}
+}
@@ -346,3 +346,24 @@ func TestExpectBodyJSONJQ_Len(t *testing.T) {
 		PtrStr("cannot get len for 10"),
 	)
 }
+
+func TestExpectBodyJSONJQ_Schema(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	Test(t,
+		Post(s.URL),
+		Send().Body().String(`{"Roles": ["Admin", "User"]}`),
+		Expect().Body().JSON().JQ(".Roles").Schema(`{"type": "array", "items": {"type": "string"}, "uniqueItems": true}`),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Body().String(`{"Roles": ["Admin", 1]}`),
+			Expect().Body().JSON().JQ(".Roles").Schema(`{"type": "array", "items": {"type": "string"}}`),
+		),
+		PtrStr("does not match the schema"),
+		PtrStr("violations:	/1: /items/type: expected string, but got integer"),
+	)
+}
@@ -1,6 +1,9 @@
 package hit_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -750,3 +753,84 @@ func TestExpectBodyJSON_Len(t *testing.T) {
 		PtrStr("cannot get len for 10"),
 	)
 }
+
+func TestExpectBodyJSON_Schema(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	schema := `{
+		"type": "object",
+		"properties": {
+			"ID": {"type": "integer", "minimum": 1},
+			"Name": {"type": "string"}
+		},
+		"required": ["ID", "Name"]
+	}`
+
+	t.Run("string", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`{"ID": 10, "Name": "Joe"}`),
+			Expect().Body().JSON().Schema(schema),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`{"ID": 0, "Name": 10}`),
+				Expect().Body().JSON().Schema(schema),
+			),
+			PtrStr("does not match the schema"),
+			PtrStr("violations:	/ID: /properties/ID/minimum: 0 is less than the minimum of 1"),
+			PtrStr("/Name: /properties/Name/type: expected string, but got integer"),
+		)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`{"ID": 10}`),
+				Expect().Body().JSON().Schema(map[string]interface{}{
+					"required": []string{"ID", "Name"},
+				}),
+			),
+			PtrStr("does not match the schema"),
+			PtrStr(`violations:	/: /required: missing required property "Name"`),
+		)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "schema")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "user.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(schema), 0o600))
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`{"ID": 10, "Name": "Joe"}`),
+			Expect().Body().JSON().Schema(path),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`{"ID": 10, "Name": "Joe"}`),
+				Expect().Body().JSON().Schema(filepath.Join(dir, "missing.json")),
+			),
+			nil,
+		)
+	})
+
+	t.Run("invalid schema", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`{}`),
+				Expect().Body().JSON().Schema(`{"properties": {"ID": 1}}`),
+			),
+			PtrStr("invalid schema at /properties/ID: schema must be an object or a boolean"),
+		)
+	})
+}
@@ -0,0 +1,105 @@
+package hit
+
+import (
+	"net/http"
+
+	"github.com/Eun/go-hit/httpbody"
+)
+
+// IExpectBodyMultipart provides assertions on a multipart response body (e.g. multipart/mixed or
+// multipart/byteranges).
+type IExpectBodyMultipart interface {
+	// Len provides assertions on the number of parts.
+	//
+	// Usage:
+	//     Expect().Body().Multipart().Len().Equal(2)
+	Len() IExpectInt
+
+	// Part provides assertions on the part with the specified index, the first part has the index 0.
+	//
+	// Usage:
+	//     Expect().Body().Multipart().Part(0).Headers("Content-Type").Equal("application/json")
+	//     Expect().Body().Multipart().Part(0).Body().JSON().JQ(".Name").Equal("Joe")
+	//     Expect().Body().Multipart().Part(1).Body().String().Equal("Hello World")
+	Part(index int) IExpectBodyMultipartPart
+}
+
+// IExpectBodyMultipartPart provides assertions on a single part of a multipart response body.
+type IExpectBodyMultipartPart interface {
+	// Headers provides assertions on the part headers.
+	// If the headerName is omitted the assertions will be performed on the names of all part headers.
+	//
+	// Usage:
+	//     Expect().Body().Multipart().Part(0).Headers("Content-Range").Equal("bytes 0-4/11")
+	//     Expect().Body().Multipart().Part(0).Headers().Contains("Content-Type")
+	Headers(headerName ...string) IExpectHeaders
+
+	// Body provides assertions on the part body, all assertions that are available for the response body can be
+	// used.
+	//
+	// Usage:
+	//     Expect().Body().Multipart().Part(0).Body().String().Equal("Hello")
+	//     Expect().Body().Multipart().Part(1).Body().JSON().JQ(".ID").Equal(10)
+	Body() IExpectBody
+}
+
+type expectBodyMultipart struct {
+	expectBody *expectBody
+	cleanPath  callPath
+}
+
+func newExpectBodyMultipart(expectBody *expectBody, cleanPath callPath) IExpectBodyMultipart {
+	return &expectBodyMultipart{
+		expectBody: expectBody,
+		cleanPath:  cleanPath,
+	}
+}
+
+func (v *expectBodyMultipart) Len() IExpectInt {
+	return newExpectInt(v.cleanPath.Push("Len", nil), func(hit Hit) int {
+		return len(v.expectBody.bodyCallback(hit).MustMultipart().Parts())
+	})
+}
+
+func (v *expectBodyMultipart) Part(index int) IExpectBodyMultipartPart {
+	return newExpectBodyMultipartPart(v, v.cleanPath.Push("Part", []interface{}{index}), index)
+}
+
+type expectBodyMultipartPart struct {
+	multipart *expectBodyMultipart
+	cleanPath callPath
+	index     int
+}
+
+func newExpectBodyMultipartPart(multipart *expectBodyMultipart, cleanPath callPath, index int) IExpectBodyMultipartPart {
+	return &expectBodyMultipartPart{
+		multipart: multipart,
+		cleanPath: cleanPath,
+		index:     index,
+	}
+}
+
+func (v *expectBodyMultipartPart) part(hit Hit) *httpbody.MultipartPart {
+	return v.multipart.expectBody.bodyCallback(hit).MustMultipart().MustPart(v.index)
+}
+
+func (v *expectBodyMultipartPart) Headers(headerName ...string) IExpectHeaders {
+	if header, ok := getLastStringArgument(headerName); ok {
+		return newExpectHeader(v.cleanPath.Push("Headers", stringSliceToInterfaceSlice(headerName)), func(hit Hit) []string {
+			return v.part(hit).Header.Values(header)
+		}, func(hit Hit) interface{} {
+			return v.part(hit).Header.Values(header)
+		})
+	}
+	return newExpectHeader(v.cleanPath.Push("Headers", nil), func(hit Hit) []string {
+		return headerNames(http.Header(v.part(hit).Header))
+	}, func(hit Hit) interface{} {
+		return v.part(hit).Header
+	})
+}
+
+func (v *expectBodyMultipartPart) Body() IExpectBody {
+	return newExpectBody(v.multipart.expectBody.expect, v.cleanPath.Push("Body", nil), func(hit Hit) *httpbody.HTTPBody {
+		return v.part(hit).Body()
+	})
+}
@@ -0,0 +1,147 @@
+package hit_test
+
+import (
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	. "github.com/Eun/go-hit"
+)
+
+// MultipartServer responds with a multipart/mixed batch on /mixed and with a multipart/byteranges body on /ranges.
+func MultipartServer() *httptest.Server {
+	writeParts := func(writer http.ResponseWriter, status int, mediaType string, parts []textproto.MIMEHeader, bodies []string) {
+		mw := multipart.NewWriter(writer)
+		writer.Header().Set("Content-Type", mediaType+"; boundary="+mw.Boundary())
+		writer.WriteHeader(status)
+		for i, h := range parts {
+			w, err := mw.CreatePart(h)
+			if err != nil {
+				panic(err)
+			}
+			if _, err = w.Write([]byte(bodies[i])); err != nil {
+				panic(err)
+			}
+		}
+		if err := mw.Close(); err != nil {
+			panic(err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mixed", func(writer http.ResponseWriter, request *http.Request) {
+		writeParts(writer, http.StatusOK, "multipart/mixed", []textproto.MIMEHeader{
+			{"Content-Type": {"application/json"}},
+			{"Content-Type": {"text/plain"}, "X-Request-Id": {"2"}},
+		}, []string{
+			`{"ID": 10, "Name": "Joe", "Roles": ["Admin", "User"]}`,
+			"Hello World",
+		})
+	})
+	mux.HandleFunc("/ranges", func(writer http.ResponseWriter, request *http.Request) {
+		writeParts(writer, http.StatusPartialContent, "multipart/byteranges", []textproto.MIMEHeader{
+			{"Content-Type": {"text/plain"}, "Content-Range": {"bytes 0-4/11"}},
+			{"Content-Type": {"text/plain"}, "Content-Range": {"bytes 6-10/11"}},
+		}, []string{
+			"Hello",
+			"World",
+		})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestExpectBodyMultipart_Len(t *testing.T) {
+	s := MultipartServer()
+	defer s.Close()
+
+	Test(t,
+		Get(s.URL+"/mixed"),
+		Expect().Body().Multipart().Len().Equal(2),
+	)
+
+	ExpectError(t,
+		Do(
+			Get(s.URL+"/mixed"),
+			Expect().Body().Multipart().Len().Equal(3),
+		),
+		PtrStr("not equal"), nil, nil, nil, nil, nil, nil,
+	)
+
+	js := PrintJSONServer(map[string]interface{}{"ID": 10})
+	defer js.Close()
+
+	ExpectError(t,
+		Do(
+			Get(js.URL),
+			Expect().Body().Multipart().Len().Equal(0),
+		),
+		PtrStr("body is not multipart"),
+	)
+}
+
+func TestExpectBodyMultipart_Part(t *testing.T) {
+	s := MultipartServer()
+	defer s.Close()
+
+	t.Run("mixed", func(t *testing.T) {
+		Test(t,
+			Get(s.URL+"/mixed"),
+			Expect().Body().Multipart().Part(0).Headers("Content-Type").Equal("application/json"),
+			Expect().Body().Multipart().Part(0).Body().JSON().Equal(map[string]interface{}{
+				"ID":    10,
+				"Name":  "Joe",
+				"Roles": []string{"Admin", "User"},
+			}),
+			Expect().Body().Multipart().Part(0).Body().JSON().JQ(".Name").Equal("Joe"),
+			Expect().Body().Multipart().Part(1).Headers().Contains("X-Request-Id"),
+			Expect().Body().Multipart().Part(1).Headers("X-Request-Id").First().Equal("2"),
+			Expect().Body().Multipart().Part(1).Body().String().Equal("Hello World"),
+			Expect().Body().Multipart().Part(1).Body().String().Contains("World"),
+		)
+	})
+
+	t.Run("byteranges", func(t *testing.T) {
+		Test(t,
+			Get(s.URL+"/ranges"),
+			Expect().Status().Equal(http.StatusPartialContent),
+			Expect().Body().Multipart().Len().Equal(2),
+			Expect().Body().Multipart().Part(0).Headers("Content-Range").Equal("bytes 0-4/11"),
+			Expect().Body().Multipart().Part(0).Body().String().Equal("Hello"),
+			Expect().Body().Multipart().Part(1).Headers("Content-Range").Equal("bytes 6-10/11"),
+			Expect().Body().Multipart().Part(1).Body().String().Equal("World"),
+		)
+	})
+
+	t.Run("failing expectation", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL+"/mixed"),
+				Expect().Body().Multipart().Part(1).Body().String().Equal("Hello Earth"),
+			),
+			PtrStr("not equal"),
+			PtrStr(`expected: "Hello Earth"`),
+			PtrStr(`actual: "Hello World"`),
+			nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("missing part", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL+"/mixed"),
+				Expect().Body().Multipart().Part(2).Body().String().Equal("Hello World"),
+			),
+			PtrStr("unable to find part 2, body has 2 parts"),
+		)
+
+		ExpectError(t,
+			Do(
+				Get(s.URL+"/mixed"),
+				Expect().Body().Multipart().Part(2).Headers("Content-Type").Equal("text/plain"),
+			),
+			PtrStr("unable to find part 2, body has 2 parts"),
+		)
+	})
+}
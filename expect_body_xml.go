@@ -0,0 +1,236 @@
+package hit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"unicode"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/httpbody"
+	"github.com/Eun/go-hit/internal/minitest"
+	"github.com/Eun/go-hit/internal/xpath"
+)
+
+// IExpectBodyXML provides assertions on the http response xml body.
+type IExpectBodyXML interface {
+	// Equal expects the xml body to be semantically equal to the specified value.
+	// The value can be a string or []byte containing xml, any other value will be marshaled with xml.Marshal.
+	// Whitespace around text, comments and the order of attributes are ignored, namespaces are only compared if the
+	// expected value has a namespace.
+	//
+	// given the following response: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Expect().Body().XML().Equal(`<feed><entry id="1"><title>Hello</title></entry></feed>`)
+	//     Expect().Body().XML().Equal(Feed{Entries: []Entry{{ID: 1, Title: "Hello"}}})
+	//
+	// Example:
+	//     // given the following response: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Expect().Body().XML().Equal(`<feed>
+	//             <entry id="1"><title>Hello</title></entry>
+	//             <entry id="2"><title>World</title></entry>
+	//         </feed>`),
+	//     )
+	Equal(data interface{}) IStep
+
+	// NotEqual expects the xml body to be not semantically equal to the specified values.
+	//
+	// see Equal() for usage and examples
+	NotEqual(data ...interface{}) IStep
+
+	// Contains expects the xml body to contain the specified elements.
+	// An element is contained if any element in the body has the same name, all of the expected attributes, the same
+	// text (if the expected element has text) and contains all of the expected child elements.
+	//
+	// given the following response: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Expect().Body().XML().Contains(`<entry><title>Hello</title></entry>`)
+	//     Expect().Body().XML().Contains(Entry{ID: 1, Title: "Hello"})
+	//
+	// Example:
+	//     // given the following response: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Expect().Body().XML().Contains(`<entry id="2"><title>World</title></entry>`),
+	//     )
+	Contains(data ...interface{}) IStep
+
+	// NotContains expects the xml body to not contain the specified elements.
+	//
+	// see Contains() for usage and examples
+	NotContains(data ...interface{}) IStep
+
+	// XPath evaluates an XPath 1.0 expression on the xml body, the result can be asserted.
+	// Unprefixed names match elements regardless of their namespace, prefixes are resolved with the namespace
+	// declarations of the body.
+	//
+	// given the following response: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Expect().Body().XML().XPath("/feed/entry[1]/title").Equal("Hello")
+	//     Expect().Body().XML().XPath("/feed/entry[1]/@id").Int().Equal(1)
+	//     Expect().Body().XML().XPath("//entry").Len().Equal(1)
+	//
+	// Example:
+	//     // given the following response: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Expect().Body().XML().XPath("/feed/entry[1]/title").String().Equal("Hello"),
+	//         Expect().Body().XML().XPath("/feed/entry[last()]/@id").Int().Equal(2),
+	//         Expect().Body().XML().XPath("//entry").Len().Equal(2),
+	//     )
+	XPath(expression string) IExpectBodyXMLXPath
+}
+
+type expectBodyXML struct {
+	expectBody *expectBody
+	cleanPath  callPath
+}
+
+func newExpectBodyXML(expectBody *expectBody, cleanPath callPath) IExpectBodyXML {
+	return &expectBodyXML{
+		expectBody: expectBody,
+		cleanPath:  cleanPath,
+	}
+}
+
+func (v *expectBodyXML) Equal(data interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Equal", []interface{}{data}),
+		Exec: func(hit *hitImpl) error {
+			doc, err := xmlBody(v.expectBody.bodyCallback(hit))
+			if err != nil {
+				return err
+			}
+			expected, err := xmlValue(data)
+			if err != nil {
+				return err
+			}
+			if xpath.Equal(doc, expected) {
+				return nil
+			}
+			return xmlNotEqualError(doc, expected)
+		},
+	}
+}
+
+func (v *expectBodyXML) NotEqual(data ...interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("NotEqual", data),
+		Exec: func(hit *hitImpl) error {
+			doc, err := xmlBody(v.expectBody.bodyCallback(hit))
+			if err != nil {
+				return err
+			}
+			for _, d := range data {
+				expected, err := xmlValue(d)
+				if err != nil {
+					return err
+				}
+				if xpath.Equal(doc, expected) {
+					return xerrors.New(minitest.Format("should not be", xpath.Format(expected)))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectBodyXML) Contains(data ...interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Contains", data),
+		Exec: func(hit *hitImpl) error {
+			doc, err := xmlBody(v.expectBody.bodyCallback(hit))
+			if err != nil {
+				return err
+			}
+			for _, d := range data {
+				expected, err := xmlValue(d)
+				if err != nil {
+					return err
+				}
+				if !xpath.Contains(doc, expected) {
+					return xerrors.New(minitest.Format("body does not contain", xpath.Format(expected)))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectBodyXML) NotContains(data ...interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("NotContains", data),
+		Exec: func(hit *hitImpl) error {
+			doc, err := xmlBody(v.expectBody.bodyCallback(hit))
+			if err != nil {
+				return err
+			}
+			for _, d := range data {
+				expected, err := xmlValue(d)
+				if err != nil {
+					return err
+				}
+				if xpath.Contains(doc, expected) {
+					return xerrors.New(minitest.Format("body contains", xpath.Format(expected)))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectBodyXML) XPath(expression string) IExpectBodyXMLXPath {
+	return newExpectBodyXMLXPath(v.expectBody, v.cleanPath.Push("XPath", []interface{}{expression}), expression)
+}
+
+// xmlBody parses the body as xml.
+func xmlBody(body *httpbody.HTTPBody) (*xpath.Node, error) {
+	return xpath.Parse(body.Reader())
+}
+
+// xmlValue parses data if it is a string or a []byte, any other value will be marshaled with xml.Marshal.
+func xmlValue(data interface{}) (*xpath.Node, error) {
+	var buf []byte
+	switch v := data.(type) {
+	case string:
+		buf = []byte(v)
+	case []byte:
+		buf = v
+	default:
+		var err error
+		buf, err = xml.Marshal(data)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to marshal %T to xml: %w", data, err)
+		}
+	}
+	return xpath.Parse(bytes.NewReader(buf))
+}
+
+func xmlNotEqualError(actual, expected *xpath.Node) error {
+	actualXML, expectedXML := xpath.Format(actual), xpath.Format(expected)
+	var sb strings.Builder
+	sb.WriteString("not equal\n")
+	sb.WriteString(minitest.Format("expected:", expectedXML))
+	sb.WriteString(minitest.Format("actual:  ", actualXML))
+	if diff := cmp.Diff(strings.Split(expectedXML, "\n"), strings.Split(actualXML, "\n")); diff != "" {
+		lines := strings.Split(diff, "\n")
+		for i := range lines {
+			// cmp uses non-breaking spaces for the indentation
+			lines[i] = strings.TrimLeftFunc(lines[i], unicode.IsSpace)
+		}
+		sb.WriteString(minitest.Format("diff:    ", strings.Join(lines, "\n")))
+	}
+	return xerrors.New(sb.String())
+}
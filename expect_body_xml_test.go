@@ -0,0 +1,264 @@
+package hit_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	. "github.com/Eun/go-hit"
+)
+
+const atomFeed = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Feed</title>
+  <entry id="1" lang="en">
+    <title>Hello</title>
+    <rating>4.5</rating>
+  </entry>
+  <entry lang="de" id="2">
+    <title>World</title>
+    <rating>3</rating>
+  </entry>
+</feed>`
+
+type atomEntry struct {
+	XMLName xml.Name `xml:"entry"`
+	ID      int      `xml:"id,attr"`
+	Title   string   `xml:"title"`
+}
+
+func TestExpectBodyXML_Equal(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("string", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().Equal(`<feed xmlns="http://www.w3.org/2005/Atom"><title>Example Feed</title>`+
+				`<entry lang="en" id="1"><title>Hello</title><rating>4.5</rating></entry>`+
+				`<entry id="2" lang="de"><title> World </title><rating>3</rating></entry></feed>`),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`<a x="1"><b>Hello</b></a>`),
+				Expect().Body().XML().Equal(`<a x="1"><b>World</b></a>`),
+			),
+			PtrStr("not equal"),
+			PtrStr(`expected: <a x="1">`),
+			PtrStr(`<b>World</b>`),
+			PtrStr(`</a>`),
+			PtrStr(`actual: <a x="1">`),
+			PtrStr(`<b>Hello</b>`),
+			PtrStr(`</a>`),
+			PtrStr("diff: []string{"),
+			nil, nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`<entry id="1">  <title>Hello</title>  </entry>`),
+			Expect().Body().XML().Equal(atomEntry{ID: 1, Title: "Hello"}),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`<entry id="2"><title>Hello</title></entry>`),
+				Expect().Body().XML().Equal(&atomEntry{ID: 1, Title: "Hello"}),
+			),
+			PtrStr("not equal"), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("invalid xml", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`Hello World`),
+				Expect().Body().XML().Equal(`<a/>`),
+			),
+			PtrStr("unable to parse xml: document has no root element"),
+		)
+	})
+}
+
+func TestExpectBodyXML_NotEqual(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	Test(t,
+		Post(s.URL),
+		Send().Body().String(atomFeed),
+		Expect().Body().XML().NotEqual(`<feed/>`, atomEntry{ID: 1, Title: "Hello"}),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Body().String(`<a><b/></a>`),
+			Expect().Body().XML().NotEqual(`<a/>`, `<a> <b></b> </a>`),
+		),
+		PtrStr("should not be <a>"),
+		PtrStr("<b/>"),
+		PtrStr("</a>"),
+	)
+}
+
+func TestExpectBodyXML_Contains(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	Test(t,
+		Post(s.URL),
+		Send().Body().String(atomFeed),
+		Expect().Body().XML().Contains(
+			`<entry id="2"><title>World</title></entry>`,
+			`<entry lang="en"/>`,
+			atomEntry{ID: 1, Title: "Hello"},
+		),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().Contains(atomEntry{ID: 2, Title: "Hello"}),
+		),
+		PtrStr(`body does not contain <entry id="2">`),
+		PtrStr("<title>Hello</title>"),
+		PtrStr("</entry>"),
+	)
+}
+
+func TestExpectBodyXML_NotContains(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	Test(t,
+		Post(s.URL),
+		Send().Body().String(atomFeed),
+		Expect().Body().XML().NotContains(`<entry id="3"/>`, atomEntry{ID: 2, Title: "Hello"}),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().NotContains(`<rating>3</rating>`),
+		),
+		PtrStr("body contains <rating>3</rating>"),
+	)
+}
+
+func TestExpectBodyXML_XPath(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("Equal", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().XPath("/feed/entry[1]/title").Equal("Hello"),
+			Expect().Body().XML().XPath("/feed/entry/title").Equal([]string{"Hello", "World"}),
+			Expect().Body().XML().XPath("/feed/entry[2]/@id").Equal(2),
+			Expect().Body().XML().XPath("/feed/entry[3]").Equal(nil),
+			Expect().Body().XML().XPath("count(//entry)").Equal(2),
+			Expect().Body().XML().XPath("//entry[@lang = 'de']/title = 'World'").Equal(true),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(atomFeed),
+				Expect().Body().XML().XPath("/feed/entry[1]/title").Equal("World"),
+			),
+			PtrStr("not equal"), nil, nil, nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("NotEqual", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().XPath("/feed/entry[1]/title").NotEqual("World", "Earth"),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(atomFeed),
+				Expect().Body().XML().XPath("/feed/entry[1]/title").NotEqual("Hello"),
+			),
+			PtrStr(`should not be "Hello"`),
+		)
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().XPath("/feed/entry[1]/title").Contains("Hel"),
+			Expect().Body().XML().XPath("/feed/entry/title").Contains("World"),
+			Expect().Body().XML().XPath("/feed/entry/title").NotContains("Earth"),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(atomFeed),
+				Expect().Body().XML().XPath("/feed/entry/title").Contains("Earth"),
+			),
+			nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(atomFeed),
+			Expect().Body().XML().XPath("/feed/entry[1]/title").String().Equal("Hello"),
+			Expect().Body().XML().XPath("/feed/entry/title").String().Equal("Hello"),
+			Expect().Body().XML().XPath("/feed/entry[last()]/@id").Int().Equal(2),
+			Expect().Body().XML().XPath("sum(//rating)").Float64().Equal(7.5),
+			Expect().Body().XML().XPath("//entry").Len().Equal(2),
+			Expect().Body().XML().XPath("string(//entry[1]/title)").Len().Equal(5),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(atomFeed),
+				Expect().Body().XML().XPath("/feed/entry[1]/title").Int().Equal(1),
+			),
+			PtrStr(`"Hello" is not a number`),
+		)
+	})
+
+	t.Run("namespaces", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">`+
+				`<s:Body><GetPriceResponse xmlns="urn:shop"><Price>9.99</Price></GetPriceResponse></s:Body>`+
+				`</s:Envelope>`),
+			Expect().Body().XML().XPath("/s:Envelope/s:Body/GetPriceResponse/Price").Float64().Equal(9.99),
+			Expect().Body().XML().XPath("//Body/*/Price").Equal("9.99"),
+			Expect().Body().XML().Contains(`<GetPriceResponse xmlns="urn:shop"><Price>9.99</Price></GetPriceResponse>`),
+			Expect().Body().XML().NotContains(`<GetPriceResponse xmlns="urn:other"/>`),
+		)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(atomFeed),
+				Expect().Body().XML().XPath("/feed/entry[").Equal("Hello"),
+			),
+			PtrStr(`invalid xpath expression "/feed/entry[": unexpected end of expression`),
+		)
+	})
+}
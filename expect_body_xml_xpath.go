@@ -0,0 +1,244 @@
+package hit
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/minitest"
+	"github.com/Eun/go-hit/internal/xpath"
+)
+
+// IExpectBodyXMLXPath provides assertions on the result of an XPath expression.
+type IExpectBodyXMLXPath interface {
+	// Equal expects the XPath result to be equal to the specified value.
+	// A single selected node will be compared by its text, multiple selected nodes will be compared as a []string.
+	//
+	// given the following response: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Expect().Body().XML().XPath("/feed/entry/title").Equal("Hello")
+	//     Expect().Body().XML().XPath("/feed/entry/@id").Equal(1)
+	//     Expect().Body().XML().XPath("count(//entry)").Equal(1)
+	//
+	// Example:
+	//     // given the following response: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Expect().Body().XML().XPath("/feed/entry[1]/title").Equal("Hello"),
+	//         Expect().Body().XML().XPath("/feed/entry/title").Equal([]string{"Hello", "World"}),
+	//         Expect().Body().XML().XPath("count(//entry)").Equal(2),
+	//     )
+	Equal(data interface{}) IStep
+
+	// NotEqual expects the XPath result to be not equal to the specified values.
+	//
+	// see Equal() for usage and examples
+	NotEqual(data ...interface{}) IStep
+
+	// Contains expects the XPath result to contain the specified values.
+	//
+	// given the following response: <feed><entry id="1"><title>Hello</title></entry></feed>
+	// Usage:
+	//     Expect().Body().XML().XPath("/feed/entry/title").Contains("Hel")
+	//
+	// Example:
+	//     // given the following response: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Expect().Body().XML().XPath("/feed/entry[1]/title").Contains("Hel"),
+	//         Expect().Body().XML().XPath("/feed/entry/title").Contains("World"),
+	//     )
+	Contains(data ...interface{}) IStep
+
+	// NotContains expects the XPath result to not contain the specified values.
+	//
+	// see Contains() for usage and examples
+	NotContains(data ...interface{}) IStep
+
+	// Len provides assertions on the number of selected nodes (or the length of a string result).
+	//
+	// Usage:
+	//     Expect().Body().XML().XPath("//entry").Len().Equal(2)
+	Len() IExpectInt
+
+	// String provides assertions on the XPath result as a string (the text of the first selected node).
+	//
+	// Usage:
+	//     Expect().Body().XML().XPath("/feed/entry[1]/title").String().Equal("Hello")
+	String() IExpectString
+
+	// Int provides assertions on the XPath result as an int.
+	//
+	// Usage:
+	//     Expect().Body().XML().XPath("/feed/entry[1]/@id").Int().Equal(1)
+	Int() IExpectInt
+
+	// Float64 provides assertions on the XPath result as a float64.
+	//
+	// Usage:
+	//     Expect().Body().XML().XPath("sum(//price)").Float64().GreaterThan(9.99)
+	Float64() IExpectFloat64
+}
+
+type expectBodyXMLXPath struct {
+	expectBody *expectBody
+	cleanPath  callPath
+	expression string
+}
+
+func newExpectBodyXMLXPath(expectBody *expectBody, cleanPath callPath, expression string) IExpectBodyXMLXPath {
+	return &expectBodyXMLXPath{
+		expectBody: expectBody,
+		cleanPath:  cleanPath,
+		expression: expression,
+	}
+}
+
+// evaluate evaluates the expression on the response body.
+func (v *expectBodyXMLXPath) evaluate(hit Hit) (interface{}, error) {
+	expr, err := xpath.Compile(v.expression)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := xmlBody(v.expectBody.bodyCallback(hit))
+	if err != nil {
+		return nil, err
+	}
+	return expr.Evaluate(doc)
+}
+
+// value evaluates the expression and converts node sets into go values: nil for an empty node set, the text of the
+// node for a single node and a []string for multiple nodes.
+func (v *expectBodyXMLXPath) value(hit Hit) (interface{}, error) {
+	result, err := v.evaluate(hit)
+	if err != nil {
+		return nil, err
+	}
+	ns, ok := result.(xpath.NodeSet)
+	if !ok {
+		return result, nil
+	}
+	switch len(ns) {
+	case 0:
+		return nil, nil
+	case 1:
+		return ns[0].String(), nil
+	}
+	values := make([]string, len(ns))
+	for i, n := range ns {
+		values[i] = n.String()
+	}
+	return values, nil
+}
+
+func (v *expectBodyXMLXPath) Equal(data interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Equal", []interface{}{data}),
+		Exec: func(hit *hitImpl) error {
+			obj, err := v.value(hit)
+			if err != nil {
+				return err
+			}
+			return minitest.Equal(obj, data)
+		},
+	}
+}
+
+func (v *expectBodyXMLXPath) NotEqual(data ...interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("NotEqual", data),
+		Exec: func(hit *hitImpl) error {
+			obj, err := v.value(hit)
+			if err != nil {
+				return err
+			}
+			return minitest.NotEqual(obj, data...)
+		},
+	}
+}
+
+func (v *expectBodyXMLXPath) Contains(data ...interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Contains", data),
+		Exec: func(hit *hitImpl) error {
+			obj, err := v.value(hit)
+			if err != nil {
+				return err
+			}
+			return minitest.Contains(obj, data...)
+		},
+	}
+}
+
+func (v *expectBodyXMLXPath) NotContains(data ...interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("NotContains", data),
+		Exec: func(hit *hitImpl) error {
+			obj, err := v.value(hit)
+			if err != nil {
+				return err
+			}
+			return minitest.NotContains(obj, data...)
+		},
+	}
+}
+
+func (v *expectBodyXMLXPath) Len() IExpectInt {
+	return newExpectInt(v.cleanPath.Push("Len", nil), func(hit Hit) int {
+		result, err := v.evaluate(hit)
+		if err != nil {
+			panic(err)
+		}
+		switch t := result.(type) {
+		case xpath.NodeSet:
+			return len(t)
+		case string:
+			return utf8.RuneCountInString(t)
+		default:
+			panic(xerrors.Errorf("cannot get len for %#v", result))
+		}
+	})
+}
+
+func (v *expectBodyXMLXPath) String() IExpectString {
+	return newExpectString(v.cleanPath.Push("String", nil), func(hit Hit) string {
+		result, err := v.evaluate(hit)
+		if err != nil {
+			panic(err)
+		}
+		return xpath.String(result)
+	})
+}
+
+func (v *expectBodyXMLXPath) Int() IExpectInt {
+	return newExpectInt(v.cleanPath.Push("Int", nil), func(hit Hit) int {
+		return int(v.number(hit))
+	})
+}
+
+func (v *expectBodyXMLXPath) Float64() IExpectFloat64 {
+	return newExpectFloat64(v.cleanPath.Push("Float64", nil), func(hit Hit) float64 {
+		return v.number(hit)
+	})
+}
+
+func (v *expectBodyXMLXPath) number(hit Hit) float64 {
+	result, err := v.evaluate(hit)
+	if err != nil {
+		panic(err)
+	}
+	f := xpath.Number(result)
+	if math.IsNaN(f) {
+		panic(xerrors.Errorf("%q is not a number", xpath.String(result)))
+	}
+	return f
+}
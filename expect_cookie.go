@@ -0,0 +1,213 @@
+package hit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Eun/go-hit/internal/minitest"
+)
+
+// IExpectCookie provides assertions on a cookie that was set by the response (using the Set-Cookie header).
+// If the cookie was set multiple times the last one will be used.
+type IExpectCookie interface {
+	// Exists expects the cookie to be set.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Exists()
+	Exists() IStep
+
+	// NotExists expects the cookie to be not set.
+	//
+	// Usage:
+	//     Expect().Cookies("session").NotExists()
+	NotExists() IStep
+
+	// Value provides assertions on the cookie value.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Value().Len().GreaterThan(0)
+	//     Expect().Cookies("theme").Value().Equal("dark")
+	Value() IExpectString
+
+	// Path provides assertions on the Path attribute.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Path().Equal("/")
+	Path() IExpectString
+
+	// Domain provides assertions on the Domain attribute.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Domain().Equal("example.com")
+	Domain() IExpectString
+
+	// Expires provides assertions on the Expires attribute, the time is zero if the attribute was not set.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Expires().Within(24 * time.Hour)
+	//     Expect().Cookies("session").Expires().Empty()
+	Expires() IExpectTime
+
+	// MaxAge provides assertions on the Max-Age attribute.
+	// The value follows http.Cookie: 0 means the attribute was not set, a negative value means Max-Age=0 (or less).
+	//
+	// Usage:
+	//     Expect().Cookies("session").MaxAge().Equal(3600)
+	MaxAge() IExpectInt
+
+	// Secure expects the cookie to have the Secure attribute.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Secure()
+	Secure() IStep
+
+	// HttpOnly expects the cookie to have the HttpOnly attribute.
+	//
+	// Usage:
+	//     Expect().Cookies("session").HttpOnly()
+	HttpOnly() IStep //nolint:stylecheck,revive // use the same name as http.Cookie
+
+	// SameSite provides assertions on the SameSite attribute, the value is either "Strict", "Lax", "None" or "" (if
+	// the attribute was not set or has an unknown value).
+	//
+	// Usage:
+	//     Expect().Cookies("session").SameSite().Equal("Strict")
+	SameSite() IExpectString
+}
+
+type expectCookie struct {
+	cleanPath callPath
+	name      string
+}
+
+func newExpectCookie(cleanPath callPath, name string) IExpectCookie {
+	return &expectCookie{
+		cleanPath: cleanPath,
+		name:      name,
+	}
+}
+
+// findCookie returns the last cookie with the specified name, nil if no cookie was found.
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	var found *http.Cookie
+	for _, c := range cookies {
+		if c.Name == name {
+			found = c
+		}
+	}
+	return found
+}
+
+func (c *expectCookie) lookup(hit Hit) *http.Cookie {
+	return findCookie(hit.Response().Cookies(), c.name)
+}
+
+// cookie returns the cookie, it panics if the cookie was not set.
+func (c *expectCookie) cookie(hit Hit) *http.Cookie {
+	cookie := c.lookup(hit)
+	if cookie == nil {
+		panic(minitest.Errorf("cookie %q was not set", c.name))
+	}
+	return cookie
+}
+
+func (c *expectCookie) Exists() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: c.cleanPath.Push("Exists", nil),
+		Exec: func(hit *hitImpl) error {
+			if c.lookup(hit) == nil {
+				return minitest.Errorf("cookie %q was not set", c.name)
+			}
+			return nil
+		},
+	}
+}
+
+func (c *expectCookie) NotExists() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: c.cleanPath.Push("NotExists", nil),
+		Exec: func(hit *hitImpl) error {
+			if c.lookup(hit) != nil {
+				return minitest.Errorf("cookie %q was set", c.name)
+			}
+			return nil
+		},
+	}
+}
+
+func (c *expectCookie) Value() IExpectString {
+	return newExpectString(c.cleanPath.Push("Value", nil), func(hit Hit) string {
+		return c.cookie(hit).Value
+	})
+}
+
+func (c *expectCookie) Path() IExpectString {
+	return newExpectString(c.cleanPath.Push("Path", nil), func(hit Hit) string {
+		return c.cookie(hit).Path
+	})
+}
+
+func (c *expectCookie) Domain() IExpectString {
+	return newExpectString(c.cleanPath.Push("Domain", nil), func(hit Hit) string {
+		return c.cookie(hit).Domain
+	})
+}
+
+func (c *expectCookie) Expires() IExpectTime {
+	return newExpectTime(c.cleanPath.Push("Expires", nil), func(hit Hit) time.Time {
+		return c.cookie(hit).Expires
+	})
+}
+
+func (c *expectCookie) MaxAge() IExpectInt {
+	return newExpectInt(c.cleanPath.Push("MaxAge", nil), func(hit Hit) int {
+		return c.cookie(hit).MaxAge
+	})
+}
+
+func (c *expectCookie) Secure() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: c.cleanPath.Push("Secure", nil),
+		Exec: func(hit *hitImpl) error {
+			if !c.cookie(hit).Secure {
+				return minitest.Errorf("cookie %q is not secure", c.name)
+			}
+			return nil
+		},
+	}
+}
+
+func (c *expectCookie) HttpOnly() IStep { //nolint:stylecheck,revive // use the same name as http.Cookie
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: c.cleanPath.Push("HttpOnly", nil),
+		Exec: func(hit *hitImpl) error {
+			if !c.cookie(hit).HttpOnly {
+				return minitest.Errorf("cookie %q is not http only", c.name)
+			}
+			return nil
+		},
+	}
+}
+
+func (c *expectCookie) SameSite() IExpectString {
+	return newExpectString(c.cleanPath.Push("SameSite", nil), func(hit Hit) string {
+		switch c.cookie(hit).SameSite {
+		case http.SameSiteStrictMode:
+			return "Strict"
+		case http.SameSiteLaxMode:
+			return "Lax"
+		case http.SameSiteNoneMode:
+			return "None"
+		default:
+			return ""
+		}
+	})
+}
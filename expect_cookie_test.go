@@ -0,0 +1,185 @@
+package hit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestExpectCookie(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	session := (&http.Cookie{
+		Name:     "session",
+		Value:    "abc123",
+		Path:     "/",
+		Domain:   "example.com",
+		Expires:  expires,
+		MaxAge:   3600,
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	}).String()
+
+	t.Run("attributes", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add("theme=light", session, "theme=dark"),
+			Expect().Cookies("session").Exists(),
+			Expect().Cookies("session").Value().Equal("abc123"),
+			Expect().Cookies("session").Path().Equal("/"),
+			Expect().Cookies("session").Domain().Equal("example.com"),
+			Expect().Cookies("session").Expires().Equal(expires),
+			Expect().Cookies("session").Expires().Within(2*time.Hour),
+			Expect().Cookies("session").MaxAge().Equal(3600),
+			Expect().Cookies("session").Secure(),
+			Expect().Cookies("session").HttpOnly(),
+			Expect().Cookies("session").SameSite().Equal("Strict"),
+			Expect().Cookies("theme").Value().Equal("dark"),
+			Expect().Cookies("theme").Expires().Empty(),
+			Expect().Cookies("theme").SameSite().Equal(""),
+			Expect().Cookies("user").NotExists(),
+		)
+	})
+
+	t.Run("missing attributes", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Headers("Set-Cookie").Add("theme=dark"),
+				Expect().Cookies("theme").Secure(),
+			),
+			PtrStr(`cookie "theme" is not secure`),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Headers("Set-Cookie").Add("theme=dark"),
+				Expect().Cookies("theme").HttpOnly(),
+			),
+			PtrStr(`cookie "theme" is not http only`),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Headers("Set-Cookie").Add("theme=dark; SameSite=Lax"),
+				Expect().Cookies("theme").SameSite().Equal("Strict"),
+			),
+			PtrStr("not equal"), nil, nil, nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("not set", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Expect().Cookies("session").Exists(),
+			),
+			PtrStr(`cookie "session" was not set`),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Expect().Cookies("session").Value().Equal("abc123"),
+			),
+			PtrStr(`cookie "session" was not set`),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Headers("Set-Cookie").Add(session),
+				Expect().Cookies("session").NotExists(),
+			),
+			PtrStr(`cookie "session" was set`),
+		)
+	})
+}
+
+func TestExpectTime(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	expires := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	cookie := "session=abc123; Expires=" + expires.Format(http.TimeFormat)
+
+	Test(t,
+		Post(s.URL),
+		Send().Headers("Set-Cookie").Add(cookie),
+		Expect().Cookies("session").Expires().Equal(expires),
+		Expect().Cookies("session").Expires().NotEqual(expires.Add(time.Second), time.Time{}),
+		Expect().Cookies("session").Expires().Before(expires.Add(time.Second)),
+		Expect().Cookies("session").Expires().After(expires.Add(-time.Second)),
+		Expect().Cookies("session").Expires().NotEmpty(),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add(cookie),
+			Expect().Cookies("session").Expires().Equal(expires.Add(time.Hour)),
+		),
+		PtrStr("expected 2030-01-02T03:04:05Z to be equal to 2030-01-02T04:04:05Z"),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add(cookie),
+			Expect().Cookies("session").Expires().NotEqual(expires),
+		),
+		PtrStr("should not be 2030-01-02T03:04:05Z"),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add(cookie),
+			Expect().Cookies("session").Expires().Before(expires),
+		),
+		PtrStr("expected 2030-01-02T03:04:05Z to be before 2030-01-02T03:04:05Z"),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add(cookie),
+			Expect().Cookies("session").Expires().After(expires),
+		),
+		PtrStr("expected 2030-01-02T03:04:05Z to be after 2030-01-02T03:04:05Z"),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add(cookie),
+			Expect().Cookies("session").Expires().Within(24*time.Hour),
+		),
+		PtrStr("expected 2030-01-02T03:04:05Z to be within 24h0m0s from now"),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add(cookie),
+			Expect().Cookies("session").Expires().Empty(),
+		),
+		PtrStr("expected 2030-01-02T03:04:05Z to be the zero time"),
+	)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Set-Cookie").Add("session=abc123"),
+			Expect().Cookies("session").Expires().NotEmpty(),
+		),
+		PtrStr("expected time to be not the zero time"),
+	)
+}
@@ -0,0 +1,114 @@
+package hit
+
+import (
+	"time"
+
+	"github.com/Eun/go-hit/internal/minitest"
+)
+
+// IExpectDuration provides assertions for the time.Duration type.
+type IExpectDuration interface {
+	// LessThan expects the duration to be less than the specified value.
+	//
+	// Usage:
+	//     Expect().Duration().LessThan(500 * time.Millisecond)
+	LessThan(value time.Duration) IStep
+
+	// LessOrEqualThan expects the duration to be less or equal than the specified value.
+	LessOrEqualThan(value time.Duration) IStep
+
+	// GreaterThan expects the duration to be greater than the specified value.
+	GreaterThan(value time.Duration) IStep
+
+	// GreaterOrEqualThan expects the duration to be greater or equal than the specified value.
+	GreaterOrEqualThan(value time.Duration) IStep
+
+	// Between expects the duration to be between the specified min and max value (inclusive, min <= duration <= max).
+	//
+	// Usage:
+	//     Expect().Timing().TimeToFirstByte().Between(10*time.Millisecond, time.Second)
+	Between(min, max time.Duration) IStep
+}
+
+type expectDurationValueCallback func(hit Hit) time.Duration
+type expectDuration struct {
+	cleanPath     callPath
+	valueCallback expectDurationValueCallback
+}
+
+func newExpectDuration(cleanPath callPath, valueCallback expectDurationValueCallback) IExpectDuration {
+	return &expectDuration{
+		cleanPath:     cleanPath,
+		valueCallback: valueCallback,
+	}
+}
+
+func (v *expectDuration) LessThan(value time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("LessThan", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			if d := v.valueCallback(hit); d >= value {
+				return minitest.Errorf("expected %s to be less than %s", d, value)
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectDuration) LessOrEqualThan(value time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("LessOrEqualThan", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			if d := v.valueCallback(hit); d > value {
+				return minitest.Errorf("expected %s to be less or equal than %s", d, value)
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectDuration) GreaterThan(value time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("GreaterThan", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			if d := v.valueCallback(hit); d <= value {
+				return minitest.Errorf("expected %s to be greater than %s", d, value)
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectDuration) GreaterOrEqualThan(value time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("GreaterOrEqualThan", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			if d := v.valueCallback(hit); d < value {
+				return minitest.Errorf("expected %s to be greater or equal than %s", d, value)
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectDuration) Between(min, max time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Between", []interface{}{min, max}),
+		Exec: func(hit *hitImpl) error {
+			if d := v.valueCallback(hit); d < min || d > max {
+				return minitest.Errorf("expected %s to be between %s and %s", d, min, max)
+			}
+			return nil
+		},
+	}
+}
@@ -78,6 +78,13 @@ type IExpectFormValues interface {
 	// Usage:
 	//     Expect().Body().FormValues("username").Nth(0).NotEqual("joe")
 	Nth(n int) IExpectHeaderValue
+
+	// MatchSnapshot expects the form values to be equal to the snapshot with the specified name.
+	// The snapshot will be stored as json, see IExpectBody.MatchSnapshot() for details.
+	//
+	// Usage:
+	//     Expect().Body().FormValues("username").MatchSnapshot("create-user-username")
+	MatchSnapshot(name string, redact ...string) IStep
 }
 
 // since we reuse IExpectHeaders here, make sure IExpectFormValues has everything IExpectHeader has.
@@ -87,5 +94,8 @@ func newExpectFormValues(cleanPath callPath, valueCallback expectHeaderValueCall
 	return &expectHeader{
 		cleanPath:     cleanPath,
 		valueCallback: valueCallback,
+		snapshotCallback: func(hit Hit) interface{} {
+			return valueCallback(hit)
+		},
 	}
 }
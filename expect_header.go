@@ -1,6 +1,10 @@
 package hit
 
 import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
 	"github.com/Eun/go-hit/internal/minitest"
 )
 
@@ -165,19 +169,34 @@ type IExpectHeaders interface {
 	//         Expect().Headers("Content-Type").Nth(0).NotEqual("application/json"),
 	//     )
 	Nth(n int) IExpectHeaderValue
+
+	// MatchSnapshot expects the header values to be equal to the snapshot with the specified name.
+	// The snapshot will be stored as json, see IExpectBody.MatchSnapshot() for details.
+	// redact can be used to mask volatile values with jq paths before the comparison.
+	//
+	// Usage:
+	//     Expect().AllHeaders().MatchSnapshot("get-user-headers", ".Date")
+	//     Expect().Headers("Content-Type").MatchSnapshot("get-user-content-type")
+	MatchSnapshot(name string, redact ...string) IStep
 }
 
 type expectHeaderValueCallback func(hit Hit) []string
 
+type expectHeaderSnapshotCallback func(hit Hit) interface{}
+
 type expectHeader struct {
-	cleanPath     callPath
-	valueCallback expectHeaderValueCallback
+	cleanPath        callPath
+	valueCallback    expectHeaderValueCallback
+	snapshotCallback expectHeaderSnapshotCallback
 }
 
-func newExpectHeader(cleanPath callPath, valueCallback expectHeaderValueCallback) IExpectHeaders {
+func newExpectHeader(
+	cleanPath callPath, valueCallback expectHeaderValueCallback, snapshotCallback expectHeaderSnapshotCallback,
+) IExpectHeaders {
 	return &expectHeader{
-		cleanPath:     cleanPath,
-		valueCallback: valueCallback,
+		cleanPath:        cleanPath,
+		valueCallback:    valueCallback,
+		snapshotCallback: snapshotCallback,
 	}
 }
 
@@ -313,3 +332,28 @@ func (hdr *expectHeader) Nth(n int) IExpectHeaderValue {
 		return &v[n]
 	})
 }
+
+func (hdr *expectHeader) MatchSnapshot(name string, redact ...string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: hdr.cleanPath.Push("MatchSnapshot", append([]interface{}{name}, stringSliceToInterfaceSlice(redact)...)),
+		Exec: func(hit *hitImpl) error {
+			data, err := json.Marshal(hdr.snapshotCallback(hit))
+			if err != nil {
+				return err
+			}
+			return matchSnapshot(name, data, redact)
+		},
+	}
+}
+
+// headerNames returns the sorted names of all headers.
+func headerNames(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
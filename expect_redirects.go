@@ -0,0 +1,102 @@
+package hit
+
+import (
+	"net/http"
+
+	"github.com/Eun/go-hit/internal/minitest"
+)
+
+// IExpectRedirects provides assertions on the redirects that were followed to get the response.
+type IExpectRedirects interface {
+	// Count expects the number of followed redirects to be equal to the specified value.
+	//
+	// Usage:
+	//     Expect().Redirects().Count().Equal(2)
+	Count() IExpectInt
+
+	// Nth provides assertions on the redirect response with the specified index, the first redirect has the index 0.
+	//
+	// Usage:
+	//     Expect().Redirects().Nth(0).Status().Equal(http.StatusFound)
+	//     Expect().Redirects().Nth(0).URL().Equal("https://example.com/login")
+	Nth(index int) IExpectRedirect
+
+	// Final provides assertions on the final response, the one that was returned after all redirects were followed.
+	//
+	// Usage:
+	//     Expect().Redirects().Final().URL().Equal("https://example.com/profile")
+	Final() IExpectRedirect
+}
+
+// IExpectRedirect provides assertions on a response that was part of a redirect chain.
+type IExpectRedirect interface {
+	// Status provides assertions on the status code of the response.
+	//
+	// Usage:
+	//     Expect().Redirects().Nth(0).Status().Equal(http.StatusMovedPermanently)
+	Status() IExpectInt64
+
+	// URL provides assertions on the url that was requested to get the response.
+	//
+	// Usage:
+	//     Expect().Redirects().Nth(0).URL().Equal("https://example.com/login")
+	URL() IExpectString
+}
+
+type expectRedirects struct {
+	cleanPath callPath
+}
+
+func newExpectRedirects(cleanPath callPath) IExpectRedirects {
+	return &expectRedirects{
+		cleanPath: cleanPath,
+	}
+}
+
+func (r *expectRedirects) Count() IExpectInt {
+	return newExpectInt(r.cleanPath.Push("Count", nil), func(hit Hit) int {
+		return len(hit.Response().Redirects())
+	})
+}
+
+func (r *expectRedirects) Nth(index int) IExpectRedirect {
+	return newExpectRedirect(r.cleanPath.Push("Nth", []interface{}{index}), func(hit Hit) *http.Response {
+		redirects := hit.Response().Redirects()
+		if index < 0 || index >= len(redirects) {
+			panic(minitest.Errorf("unable to find redirect %d, got %d redirects", index, len(redirects)))
+		}
+		return redirects[index]
+	})
+}
+
+func (r *expectRedirects) Final() IExpectRedirect {
+	return newExpectRedirect(r.cleanPath.Push("Final", nil), func(hit Hit) *http.Response {
+		return hit.Response().Response
+	})
+}
+
+type expectRedirectCallback func(hit Hit) *http.Response
+
+type expectRedirect struct {
+	cleanPath        callPath
+	responseCallback expectRedirectCallback
+}
+
+func newExpectRedirect(cleanPath callPath, responseCallback expectRedirectCallback) IExpectRedirect {
+	return &expectRedirect{
+		cleanPath:        cleanPath,
+		responseCallback: responseCallback,
+	}
+}
+
+func (r *expectRedirect) Status() IExpectInt64 {
+	return newExpectInt64(r.cleanPath.Push("Status", nil), func(hit Hit) int64 {
+		return int64(r.responseCallback(hit).StatusCode)
+	})
+}
+
+func (r *expectRedirect) URL() IExpectString {
+	return newExpectString(r.cleanPath.Push("URL", nil), func(hit Hit) string {
+		return r.responseCallback(hit).Request.URL.String()
+	})
+}
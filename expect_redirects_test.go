@@ -0,0 +1,54 @@
+package hit_test
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestExpectRedirects(t *testing.T) {
+	s := RedirectServer()
+	defer s.Close()
+
+	t.Run("chain", func(t *testing.T) {
+		Test(t,
+			Get(s.URL+"/a"),
+			Expect().Redirects().Count().Equal(2),
+			Expect().Redirects().Nth(0).Status().Equal(http.StatusFound),
+			Expect().Redirects().Nth(0).URL().Equal(s.URL+"/a"),
+			Expect().Redirects().Nth(1).Status().Equal(http.StatusMovedPermanently),
+			Expect().Redirects().Nth(1).URL().Equal(s.URL+"/b"),
+			Expect().Redirects().Final().Status().Equal(http.StatusOK),
+			Expect().Redirects().Final().URL().Equal(s.URL+"/c"),
+		)
+	})
+
+	t.Run("no redirects", func(t *testing.T) {
+		Test(t,
+			Get(s.URL+"/c"),
+			Expect().Redirects().Count().Equal(0),
+			Expect().Redirects().Final().URL().Equal(s.URL+"/c"),
+		)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL+"/a"),
+				Expect().Redirects().Nth(2).Status().Equal(http.StatusOK),
+			),
+			PtrStr("unable to find redirect 2, got 2 redirects"),
+		)
+	})
+
+	t.Run("wrong status", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL+"/a"),
+				Expect().Redirects().Nth(0).Status().Equal(http.StatusMovedPermanently),
+			),
+			PtrStr("not equal"), PtrStr("expected: 301"), PtrStr("actual: 302"), nil, nil, nil, nil,
+		)
+	})
+}
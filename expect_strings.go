@@ -0,0 +1,99 @@
+package hit
+
+//nolint:dupl // the methods of IExpectStrings and IExpectHeaders are the same however the comments are different.
+// IExpectStrings provides assertions on a list of strings.
+type IExpectStrings interface {
+	// Contains expects the list to contain all of the specified values.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Contains("example.com")
+	Contains(values ...interface{}) IStep
+
+	// NotContains expects the list to not contain all of the specified values.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().NotContains("example.com")
+	NotContains(values ...interface{}) IStep
+
+	// OneOf expects the list to contain one of the specified values.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().OneOf("example.com", "www.example.com")
+	OneOf(values ...interface{}) IStep
+
+	// NotOneOf expects the list to not contain one of the specified values.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().NotOneOf("example.com", "www.example.com")
+	NotOneOf(values ...interface{}) IStep
+
+	// Empty expects the list to be empty.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Empty()
+	Empty() IStep
+
+	// NotEmpty expects the list to be not empty.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().NotEmpty()
+	NotEmpty() IStep
+
+	// Len provides assertions on the length of the list.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Len().GreaterThan(0)
+	Len() IExpectInt
+
+	// Equal expects the list to be equal the specified values.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Equal("example.com")
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Equal("example.com", "www.example.com")
+	Equal(value ...interface{}) IStep
+
+	// NotEqual expects the list to be not equal the specified values.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().NotEqual("example.com")
+	//     Expect().TLS().PeerCertificate(0).DNSNames().NotEqual("example.com", "www.example.com")
+	NotEqual(value ...interface{}) IStep
+
+	// First provides assertions for the first value of the list.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().First().Equal("example.com")
+	First() IExpectHeaderValue
+
+	// Last provides assertions for the last value of the list.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Last().Equal("example.com")
+	Last() IExpectHeaderValue
+
+	// Nth provides assertions for the nth value of the list. (0 = first value)
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Nth(0).Equal("example.com")
+	Nth(n int) IExpectHeaderValue
+
+	// MatchSnapshot expects the list to be equal to the snapshot with the specified name.
+	// The snapshot will be stored as json, see IExpectBody.MatchSnapshot() for details.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().MatchSnapshot("dns-names")
+	MatchSnapshot(name string, redact ...string) IStep
+}
+
+// since we reuse IExpectHeaders here, make sure IExpectStrings has everything IExpectHeader has.
+var _ IExpectHeaders = IExpectStrings(nil)
+
+func newExpectStrings(cleanPath callPath, valueCallback expectHeaderValueCallback) IExpectStrings {
+	return &expectHeader{
+		cleanPath:     cleanPath,
+		valueCallback: valueCallback,
+		snapshotCallback: func(hit Hit) interface{} {
+			return valueCallback(hit)
+		},
+	}
+}
@@ -0,0 +1,165 @@
+package hit
+
+import (
+	"time"
+
+	"github.com/Eun/go-hit/internal/minitest"
+)
+
+// IExpectTime provides assertions for the time.Time type.
+type IExpectTime interface {
+	// Equal expects the time to be equal to the specified value.
+	Equal(value time.Time) IStep
+
+	// NotEqual expects the time to be not equal to the specified values.
+	NotEqual(values ...time.Time) IStep
+
+	// Before expects the time to be before the specified value.
+	Before(value time.Time) IStep
+
+	// After expects the time to be after the specified value.
+	After(value time.Time) IStep
+
+	// Within expects the time to be within the specified duration from now, a negative duration can be used to
+	// expect a time in the past.
+	//
+	// Usage:
+	//     Expect().Cookies("session").Expires().Within(24 * time.Hour)
+	Within(d time.Duration) IStep
+
+	// Empty expects the time to be the zero time.
+	Empty() IStep
+
+	// NotEmpty expects the time to be not the zero time.
+	NotEmpty() IStep
+}
+
+type expectTimeValueCallback func(hit Hit) time.Time
+type expectTime struct {
+	cleanPath     callPath
+	valueCallback expectTimeValueCallback
+}
+
+func newExpectTime(cleanPath callPath, valueCallback expectTimeValueCallback) IExpectTime {
+	return &expectTime{
+		cleanPath:     cleanPath,
+		valueCallback: valueCallback,
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "zero time"
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func (v *expectTime) Equal(value time.Time) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Equal", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			t := v.valueCallback(hit)
+			if !t.Equal(value) {
+				return minitest.Errorf("expected %s to be equal to %s", formatTime(t), formatTime(value))
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectTime) NotEqual(values ...time.Time) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("NotEqual", timeSliceToInterfaceSlice(values)),
+		Exec: func(hit *hitImpl) error {
+			t := v.valueCallback(hit)
+			for _, value := range values {
+				if t.Equal(value) {
+					return minitest.Errorf("should not be %s", formatTime(t))
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectTime) Before(value time.Time) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Before", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			t := v.valueCallback(hit)
+			if !t.Before(value) {
+				return minitest.Errorf("expected %s to be before %s", formatTime(t), formatTime(value))
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectTime) After(value time.Time) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("After", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			t := v.valueCallback(hit)
+			if !t.After(value) {
+				return minitest.Errorf("expected %s to be after %s", formatTime(t), formatTime(value))
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectTime) Within(d time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Within", []interface{}{d}),
+		Exec: func(hit *hitImpl) error {
+			t := v.valueCallback(hit)
+			now := time.Now()
+			from, to := now, now.Add(d)
+			if d < 0 {
+				from, to = to, from
+			}
+			if t.Before(from) || t.After(to) {
+				return minitest.Errorf("expected %s to be within %s from now", formatTime(t), d)
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectTime) Empty() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Empty", nil),
+		Exec: func(hit *hitImpl) error {
+			if t := v.valueCallback(hit); !t.IsZero() {
+				return minitest.Errorf("expected %s to be the zero time", formatTime(t))
+			}
+			return nil
+		},
+	}
+}
+
+func (v *expectTime) NotEmpty() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("NotEmpty", nil),
+		Exec: func(hit *hitImpl) error {
+			if v.valueCallback(hit).IsZero() {
+				return minitest.Errorf("expected time to be not the zero time")
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,91 @@
+package hit
+
+import (
+	"time"
+)
+
+// IExpectTiming provides assertions on the timing breakdown of the request.
+type IExpectTiming interface {
+	// DNS provides assertions on the time spent for the dns lookup.
+	//
+	// Usage:
+	//     Expect().Timing().DNS().LessThan(100 * time.Millisecond)
+	DNS() IExpectDuration
+
+	// Connect provides assertions on the time spent to establish the connection.
+	//
+	// Usage:
+	//     Expect().Timing().Connect().LessThan(100 * time.Millisecond)
+	Connect() IExpectDuration
+
+	// TLSHandshake provides assertions on the time spent for the tls handshake.
+	//
+	// Usage:
+	//     Expect().Timing().TLSHandshake().LessThan(200 * time.Millisecond)
+	TLSHandshake() IExpectDuration
+
+	// TimeToFirstByte provides assertions on the time from sending the request until the first byte of the response
+	// was received.
+	//
+	// Usage:
+	//     Expect().Timing().TimeToFirstByte().LessThan(500 * time.Millisecond)
+	TimeToFirstByte() IExpectDuration
+
+	// BodyRead provides assertions on the time spent to read the response body after the first byte was received.
+	//
+	// Usage:
+	//     Expect().Timing().BodyRead().LessThan(time.Second)
+	BodyRead() IExpectDuration
+
+	// Total provides assertions on the time from sending the request until the response body was read completely.
+	//
+	// Usage:
+	//     Expect().Timing().Total().LessThan(time.Second)
+	Total() IExpectDuration
+}
+
+type expectTiming struct {
+	cleanPath callPath
+}
+
+func newExpectTiming(cleanPath callPath) IExpectTiming {
+	return &expectTiming{
+		cleanPath: cleanPath,
+	}
+}
+
+func (t *expectTiming) DNS() IExpectDuration {
+	return newExpectDuration(t.cleanPath.Push("DNS", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().DNS
+	})
+}
+
+func (t *expectTiming) Connect() IExpectDuration {
+	return newExpectDuration(t.cleanPath.Push("Connect", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().Connect
+	})
+}
+
+func (t *expectTiming) TLSHandshake() IExpectDuration {
+	return newExpectDuration(t.cleanPath.Push("TLSHandshake", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().TLSHandshake
+	})
+}
+
+func (t *expectTiming) TimeToFirstByte() IExpectDuration {
+	return newExpectDuration(t.cleanPath.Push("TimeToFirstByte", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().TimeToFirstByte
+	})
+}
+
+func (t *expectTiming) BodyRead() IExpectDuration {
+	return newExpectDuration(t.cleanPath.Push("BodyRead", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().BodyRead
+	})
+}
+
+func (t *expectTiming) Total() IExpectDuration {
+	return newExpectDuration(t.cleanPath.Push("Total", nil), func(hit Hit) time.Duration {
+		return hit.Response().Timing().Total
+	})
+}
@@ -0,0 +1,115 @@
+package hit_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/Eun/go-hit"
+)
+
+const slowServerDelay = 50 * time.Millisecond
+
+// SlowServer waits before sending the headers and waits again before sending the rest of the body.
+func SlowServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(slowHandler))
+}
+
+func slowHandler(writer http.ResponseWriter, request *http.Request) {
+	time.Sleep(slowServerDelay)
+	writer.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(writer, "Hello")
+	writer.(http.Flusher).Flush()
+	time.Sleep(slowServerDelay)
+	_, _ = io.WriteString(writer, " World")
+}
+
+func TestExpectTiming(t *testing.T) {
+	t.Run("http", func(t *testing.T) {
+		s := SlowServer()
+		defer s.Close()
+
+		// use a new transport, so a new connection will be established
+		transport := &http.Transport{}
+		defer transport.CloseIdleConnections()
+
+		Test(t,
+			Get(strings.Replace(s.URL, "127.0.0.1", "localhost", 1)),
+			HTTPClient(&http.Client{Transport: transport}),
+			Expect().Body().String().Equal("Hello World"),
+			Expect().Duration().GreaterOrEqualThan(2*slowServerDelay),
+			Expect().Duration().LessThan(time.Minute),
+			Expect().Timing().DNS().GreaterThan(0),
+			Expect().Timing().Connect().GreaterThan(0),
+			Expect().Timing().TLSHandshake().Between(0, 0),
+			Expect().Timing().TimeToFirstByte().Between(slowServerDelay, time.Minute),
+			Expect().Timing().BodyRead().GreaterOrEqualThan(slowServerDelay),
+			Expect().Timing().Total().GreaterOrEqualThan(2*slowServerDelay),
+			Expect().Timing().Total().LessOrEqualThan(time.Minute),
+		)
+	})
+
+	t.Run("https", func(t *testing.T) {
+		s := httptest.NewTLSServer(http.HandlerFunc(slowHandler))
+		defer s.Close()
+
+		Test(t,
+			Get(s.URL),
+			HTTPClient(s.Client()),
+			Expect().Timing().DNS().Between(0, 0),
+			Expect().Timing().TLSHandshake().GreaterThan(0),
+			Expect().Timing().TimeToFirstByte().GreaterOrEqualThan(slowServerDelay),
+		)
+	})
+
+	t.Run("body read is measured before expecting the timing", func(t *testing.T) {
+		s := SlowServer()
+		defer s.Close()
+
+		Test(t,
+			Get(s.URL),
+			Expect().Timing().BodyRead().GreaterOrEqualThan(slowServerDelay),
+			Expect().Body().String().Equal("Hello World"),
+		)
+	})
+
+	t.Run("steps before the timing expectation are not measured", func(t *testing.T) {
+		s := SlowServer()
+		defer s.Close()
+
+		Test(t,
+			Get(s.URL),
+			Custom(BeforeExpectStep, func(Hit) error {
+				time.Sleep(10 * slowServerDelay)
+				return nil
+			}),
+			Expect().Timing().Total().LessThan(10*slowServerDelay),
+			Expect().Body().String().Equal("Hello World"),
+			Expect().Timing().BodyRead().LessThan(10*slowServerDelay),
+		)
+	})
+
+	t.Run("failing", func(t *testing.T) {
+		s := SlowServer()
+		defer s.Close()
+
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				Expect().Duration().LessThan(slowServerDelay),
+			),
+			nil,
+		)
+
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				Expect().Timing().TimeToFirstByte().Between(time.Minute, time.Hour),
+			),
+			nil,
+		)
+	})
+}
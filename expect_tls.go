@@ -0,0 +1,208 @@
+package hit
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/Eun/go-hit/internal/minitest"
+)
+
+// IExpectTLS provides assertions on the tls connection the response was received over.
+type IExpectTLS interface {
+	// Version provides assertions on the negotiated tls version, the version is either "TLS 1.0", "TLS 1.1",
+	// "TLS 1.2", "TLS 1.3", "SSL 3.0" or the hexadecimal representation for unknown versions (e.g. "0x0305").
+	//
+	// Usage:
+	//     Expect().TLS().Version().Equal("TLS 1.3")
+	//     Expect().TLS().Version().OneOf("TLS 1.2", "TLS 1.3")
+	Version() IExpectString
+
+	// CipherSuite provides assertions on the name of the negotiated cipher suite.
+	//
+	// Usage:
+	//     Expect().TLS().CipherSuite().Equal("TLS_AES_128_GCM_SHA256")
+	CipherSuite() IExpectString
+
+	// NegotiatedProtocol provides assertions on the protocol that was negotiated using ALPN.
+	//
+	// Usage:
+	//     Expect().TLS().NegotiatedProtocol().Equal("h2")
+	NegotiatedProtocol() IExpectString
+
+	// PeerCertificate provides assertions on the certificate with the specified index that was sent by the server,
+	// the first certificate (index 0) is the leaf certificate.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).Subject().Contains("example.com")
+	//     Expect().TLS().PeerCertificate(0).NotAfter().After(time.Now().Add(30 * 24 * time.Hour))
+	PeerCertificate(index int) IExpectCertificate
+
+	// Verified expects the certificate chain of the server to be verified.
+	// Certificates will not be verified if the tls configuration has InsecureSkipVerify set.
+	//
+	// Usage:
+	//     Expect().TLS().Verified()
+	Verified() IStep
+}
+
+// IExpectCertificate provides assertions on a x509 certificate.
+type IExpectCertificate interface {
+	// Subject provides assertions on the subject of the certificate, the subject is formatted as a RFC 2253
+	// distinguished name (e.g. "CN=example.com,O=Example").
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).Subject().Equal("CN=example.com,O=Example")
+	Subject() IExpectString
+
+	// Issuer provides assertions on the issuer of the certificate, the issuer is formatted as a RFC 2253
+	// distinguished name (e.g. "CN=Example CA,O=Example").
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).Issuer().Contains("Example CA")
+	Issuer() IExpectString
+
+	// DNSNames provides assertions on the dns names of the subject alternative name extension.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).DNSNames().Contains("example.com")
+	DNSNames() IExpectStrings
+
+	// NotBefore provides assertions on the time the certificate becomes valid.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).NotBefore().Before(time.Now())
+	NotBefore() IExpectTime
+
+	// NotAfter provides assertions on the time the certificate expires.
+	//
+	// Usage:
+	//     Expect().TLS().PeerCertificate(0).NotAfter().After(time.Now().Add(30 * 24 * time.Hour))
+	//     Expect().TLS().PeerCertificate(0).NotAfter().Within(365 * 24 * time.Hour)
+	NotAfter() IExpectTime
+}
+
+type expectTLS struct {
+	cleanPath callPath
+}
+
+func newExpectTLS(cleanPath callPath) IExpectTLS {
+	return &expectTLS{
+		cleanPath: cleanPath,
+	}
+}
+
+// connectionState returns the tls connection state of the response, it panics if the response was not received over
+// a tls connection.
+func connectionState(hit Hit) *tls.ConnectionState {
+	state := hit.Response().TLS
+	if state == nil {
+		panic(minitest.Errorf("response was not received over a tls connection"))
+	}
+	return state
+}
+
+// tlsVersionName returns the name of the specified tls version.
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionSSL30: //nolint:staticcheck // SSL 3.0 is deprecated, but we still want to name it
+		return "SSL 3.0"
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	default:
+		return fmt.Sprintf("0x%04X", version)
+	}
+}
+
+func (v *expectTLS) Version() IExpectString {
+	return newExpectString(v.cleanPath.Push("Version", nil), func(hit Hit) string {
+		return tlsVersionName(connectionState(hit).Version)
+	})
+}
+
+func (v *expectTLS) CipherSuite() IExpectString {
+	return newExpectString(v.cleanPath.Push("CipherSuite", nil), func(hit Hit) string {
+		return tls.CipherSuiteName(connectionState(hit).CipherSuite)
+	})
+}
+
+func (v *expectTLS) NegotiatedProtocol() IExpectString {
+	return newExpectString(v.cleanPath.Push("NegotiatedProtocol", nil), func(hit Hit) string {
+		return connectionState(hit).NegotiatedProtocol
+	})
+}
+
+func (v *expectTLS) PeerCertificate(index int) IExpectCertificate {
+	return newExpectCertificate(v.cleanPath.Push("PeerCertificate", []interface{}{index}), func(hit Hit) *x509.Certificate {
+		certificates := connectionState(hit).PeerCertificates
+		if index < 0 || index >= len(certificates) {
+			panic(minitest.Errorf("unable to find peer certificate %d, got %d certificates", index, len(certificates)))
+		}
+		return certificates[index]
+	})
+}
+
+func (v *expectTLS) Verified() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     ExpectStep,
+		CallPath: v.cleanPath.Push("Verified", nil),
+		Exec: func(hit *hitImpl) error {
+			if len(connectionState(hit).VerifiedChains) == 0 {
+				return minitest.Errorf("tls connection was not verified")
+			}
+			return nil
+		},
+	}
+}
+
+type expectCertificateCallback func(hit Hit) *x509.Certificate
+
+type expectCertificate struct {
+	cleanPath           callPath
+	certificateCallback expectCertificateCallback
+}
+
+func newExpectCertificate(cleanPath callPath, certificateCallback expectCertificateCallback) IExpectCertificate {
+	return &expectCertificate{
+		cleanPath:           cleanPath,
+		certificateCallback: certificateCallback,
+	}
+}
+
+func (v *expectCertificate) Subject() IExpectString {
+	return newExpectString(v.cleanPath.Push("Subject", nil), func(hit Hit) string {
+		return v.certificateCallback(hit).Subject.String()
+	})
+}
+
+func (v *expectCertificate) Issuer() IExpectString {
+	return newExpectString(v.cleanPath.Push("Issuer", nil), func(hit Hit) string {
+		return v.certificateCallback(hit).Issuer.String()
+	})
+}
+
+func (v *expectCertificate) DNSNames() IExpectStrings {
+	return newExpectStrings(v.cleanPath.Push("DNSNames", nil), func(hit Hit) []string {
+		return v.certificateCallback(hit).DNSNames
+	})
+}
+
+func (v *expectCertificate) NotBefore() IExpectTime {
+	return newExpectTime(v.cleanPath.Push("NotBefore", nil), func(hit Hit) time.Time {
+		return v.certificateCallback(hit).NotBefore
+	})
+}
+
+func (v *expectCertificate) NotAfter() IExpectTime {
+	return newExpectTime(v.cleanPath.Push("NotAfter", nil), func(hit Hit) time.Time {
+		return v.certificateCallback(hit).NotAfter
+	})
+}
@@ -0,0 +1,120 @@
+package hit_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TLSServer() *httptest.Server {
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusNoContent)
+	}))
+	s.EnableHTTP2 = true
+	s.StartTLS()
+	return s
+}
+
+func TestExpectTLS(t *testing.T) {
+	s := TLSServer()
+	defer s.Close()
+
+	t.Run("connection", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			HTTPClient(s.Client()),
+			Expect().TLS().Version().Equal("TLS 1.3"),
+			Expect().TLS().CipherSuite().OneOf("TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"),
+			Expect().TLS().NegotiatedProtocol().Equal("h2"),
+			Expect().TLS().Verified(),
+		)
+	})
+
+	t.Run("peer certificate", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			HTTPClient(s.Client()),
+			Expect().TLS().PeerCertificate(0).Subject().Equal("O=Acme Co"),
+			Expect().TLS().PeerCertificate(0).Issuer().Equal("O=Acme Co"),
+			Expect().TLS().PeerCertificate(0).DNSNames().Contains("example.com"),
+			Expect().TLS().PeerCertificate(0).NotBefore().Before(time.Now()),
+			Expect().TLS().PeerCertificate(0).NotAfter().After(time.Now().Add(30*24*time.Hour)),
+		)
+
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				HTTPClient(s.Client()),
+				Expect().TLS().PeerCertificate(0).NotAfter().Within(30*24*time.Hour),
+			),
+			nil,
+		)
+
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				HTTPClient(s.Client()),
+				Expect().TLS().PeerCertificate(1).Subject().Equal("O=Acme Co"),
+			),
+			PtrStr("unable to find peer certificate 1, got 1 certificates"),
+		)
+	})
+
+	t.Run("max version", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			HTTPClient(&http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs:    s.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+						MaxVersion: tls.VersionTLS12,
+					},
+				},
+			}),
+			Expect().TLS().Version().Equal("TLS 1.2"),
+			Expect().TLS().NegotiatedProtocol().Equal(""),
+		)
+	})
+
+	t.Run("not verified", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				HTTPClient(&http.Client{
+					Transport: &http.Transport{
+						TLSClientConfig: &tls.Config{
+							InsecureSkipVerify: true, //nolint:gosec // we want to test an unverified connection
+						},
+					},
+				}),
+				Expect().TLS().Verified(),
+			),
+			PtrStr("tls connection was not verified"),
+		)
+	})
+
+	t.Run("no tls", func(t *testing.T) {
+		es := EchoServer()
+		defer es.Close()
+
+		ExpectError(t,
+			Do(
+				Post(es.URL),
+				Expect().TLS().Version().Equal("TLS 1.3"),
+			),
+			PtrStr("response was not received over a tls connection"),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(es.URL),
+				Expect().TLS().Verified(),
+			),
+			PtrStr("response was not received over a tls connection"),
+		)
+	})
+}
@@ -1,5 +1,7 @@
 package hit
 
+import "golang.org/x/xerrors"
+
 // This file exposes functionality that should only be accessible during tests
 
 // CleanStep is a step that runs during the clean step phase, cast to uint8 to avoid linter problems in step.go.
@@ -10,3 +12,12 @@ type CallPath = callPath
 
 // NewCallPath creates a new callPath.
 var NewCallPath = newCallPath
+
+// DigestAuthorization computes the Authorization header value for the specified WWW-Authenticate Digest challenge.
+func DigestAuthorization(challenge, method, uri, username, password, cnonce string) (string, error) {
+	c := findDigestChallenge([]string{challenge})
+	if c == nil {
+		return "", xerrors.New("no supported digest challenge")
+	}
+	return c.authorization(method, uri, nil, username, password, cnonce)
+}
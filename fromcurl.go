@@ -0,0 +1,166 @@
+package hit
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/curl"
+)
+
+// FromCurl creates the steps for the specified curl command line.
+// The supported flags are -X, -H, -d, --data-raw, --data-binary, --data-urlencode, -F, -u, -A, -e, -b, -G, -I,
+// --compressed, -k and --url. Flags that do not affect the request (e.g. -s, -v or -L) will be ignored.
+// Reading cookies from a file (-b without a =) is not supported.
+//
+// Example:
+//     MustDo(
+//         FromCurl(`curl -X POST 'https://example.com/post' -H 'Content-Type: application/json' --data-raw '{"Name":"Joe"}'`),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+func FromCurl(cmd string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     combineStep,
+		CallPath: newCallPath("FromCurl", nil),
+		Exec: func(hit *hitImpl) error {
+			req, err := curl.Parse(cmd)
+			if err != nil {
+				return err
+			}
+			hit.InsertSteps(curlSteps(req)...)
+			return nil
+		},
+	}
+}
+
+func curlSteps(req *curl.Request) []IStep {
+	steps := []IStep{
+		Method(req.Method, req.URL),
+	}
+	if req.HasUser {
+		steps = append(steps, Request().URL().User().Username(req.Username))
+		if req.Password != "" {
+			steps = append(steps, Request().URL().User().Password(req.Password))
+		}
+	}
+	seen := make(map[string]bool)
+	for _, h := range req.Headers {
+		// with --compressed curl negotiates the encoding by itself, the command might request encodings that are not
+		// supported (e.g. br)
+		if req.Compressed && strings.EqualFold(h.Name, "Accept-Encoding") {
+			continue
+		}
+		// curl replaces a header (e.g. the default User-Agent) instead of adding another value
+		name := http.CanonicalHeaderKey(h.Name)
+		if !seen[name] {
+			seen[name] = true
+			steps = append(steps, deleteHeaderStep(name))
+		}
+		steps = append(steps, Send().Headers(h.Name).Add(h.Value))
+	}
+	if req.Body != nil {
+		steps = append(steps, Send().Body().Bytes(req.Body))
+	}
+	if req.Insecure {
+		steps = append(steps, InsecureSkipVerify())
+	}
+	if req.Compressed {
+		steps = append(steps, compressedStep())
+	}
+	return steps
+}
+
+// deleteHeaderStep removes all values of the specified request header.
+func deleteHeaderStep(name string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: nil,
+		Exec: func(hit *hitImpl) error {
+			hit.Request().Header.Del(name)
+			return nil
+		},
+	}
+}
+
+// compressedStep uses a compressedTransport for the request.
+func compressedStep() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: nil,
+		Exec: func(hit *hitImpl) error {
+			client := *hit.client
+			transport := client.Transport
+			if transport == nil {
+				transport = http.DefaultTransport
+			}
+			if _, ok := transport.(*compressedTransport); ok {
+				return nil
+			}
+			client.Transport = &compressedTransport{transport: transport}
+			hit.client = &client
+			return nil
+		},
+	}
+}
+
+// compressedTransport is a http.RoundTripper that behaves like curl --compressed: it requests a compressed response
+// and decompresses it, even if the underlying transport has disabled the compression.
+type compressedTransport struct {
+	transport http.RoundTripper
+}
+
+func (t *compressedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	res, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	encoding := strings.ToLower(strings.TrimSpace(res.Header.Get("Content-Encoding")))
+	if encoding != "gzip" && encoding != "deflate" {
+		return res, nil
+	}
+	res.Body = &decompressingBody{body: res.Body, encoding: encoding}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	return res, nil
+}
+
+// decompressingBody decompresses the body on the first read, so empty bodies (e.g. for HEAD requests) do not fail.
+type decompressingBody struct {
+	body     io.ReadCloser
+	encoding string
+	reader   io.Reader
+}
+
+func (b *decompressingBody) Read(p []byte) (int, error) {
+	if b.reader == nil {
+		var err error
+		if b.encoding == "gzip" {
+			b.reader, err = gzip.NewReader(b.body)
+		} else {
+			b.reader, err = zlib.NewReader(b.body)
+		}
+		if err == io.EOF {
+			return 0, io.EOF
+		}
+		if err != nil {
+			return 0, xerrors.Errorf("unable to decompress %s body: %w", b.encoding, err)
+		}
+	}
+	return b.reader.Read(p)
+}
+
+func (b *decompressingBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,129 @@
+package hit_test
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestFromCurl(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("method, headers and body", func(t *testing.T) {
+		Test(t,
+			FromCurl(`curl -sS -X PUT '`+s.URL+`/users/1' -H 'Content-Type: application/json' --data-raw '{"Name":"Joe"}'`),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Headers("Content-Type").Equal("application/json"),
+			Expect().Body().JSON().Equal(map[string]interface{}{"Name": "Joe"}),
+			Expect().Custom(func(hit Hit) error {
+				require.Equal(t, http.MethodPut, hit.Request().Method)
+				require.Equal(t, "/users/1", hit.Request().URL.Path)
+				return nil
+			}),
+		)
+	})
+
+	t.Run("user", func(t *testing.T) {
+		Test(t,
+			FromCurl(`curl -u joe:secret `+s.URL),
+			Expect().Headers("Authorization").Equal("Basic am9lOnNlY3JldA=="),
+		)
+	})
+
+	t.Run("user agent", func(t *testing.T) {
+		Test(t,
+			FromCurl(`curl -A go-hit `+s.URL),
+			Expect().Headers("User-Agent").Equal("go-hit"),
+		)
+		Test(t,
+			FromCurl(`curl -H 'User-Agent: go-hit' `+s.URL),
+			Expect().Headers("User-Agent").Equal("go-hit"),
+		)
+	})
+
+	t.Run("repeated headers", func(t *testing.T) {
+		Test(t,
+			FromCurl(`curl -H 'X-Name: Joe' -H 'X-Name: Alice' `+s.URL),
+			Expect().Headers("X-Name").Equal("Joe", "Alice"),
+		)
+	})
+
+	t.Run("combined with other steps", func(t *testing.T) {
+		Test(t,
+			FromCurl(`curl `+s.URL+` -d 'Hello World'`),
+			Send().Headers("X-Name").Add("Joe"),
+			Expect().Headers("X-Name").Equal("Joe"),
+			Expect().Headers("Content-Type").Equal("application/x-www-form-urlencoded"),
+			Expect().Body().String().Equal("Hello World"),
+		)
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusNoContent)
+		}))
+		defer tlsServer.Close()
+
+		err := Do(
+			FromCurl(`curl ` + tlsServer.URL),
+		)
+		require.Error(t, err)
+
+		Test(t,
+			FromCurl(`curl -k `+tlsServer.URL),
+			Expect().Status().Equal(http.StatusNoContent),
+		)
+
+		// the default transport must not be modified
+		if cfg := http.DefaultTransport.(*http.Transport).TLSClientConfig; cfg != nil {
+			require.False(t, cfg.InsecureSkipVerify)
+		}
+	})
+
+	t.Run("compressed", func(t *testing.T) {
+		gzipServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.Header().Set("X-Accept-Encoding", request.Header.Get("Accept-Encoding"))
+			if request.Header.Get("Accept-Encoding") == "" {
+				_, _ = writer.Write([]byte("Hello World"))
+				return
+			}
+			writer.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(writer)
+			_, _ = gz.Write([]byte("Hello World"))
+			_ = gz.Close()
+		}))
+		defer gzipServer.Close()
+
+		Test(t,
+			FromCurl(`curl --compressed -H 'Accept-Encoding: br' `+gzipServer.URL),
+			Expect().Headers("X-Accept-Encoding").Equal("gzip, deflate"),
+			Expect().Headers("Content-Encoding").Empty(),
+			Expect().Body().String().Equal("Hello World"),
+		)
+
+		// the transport must not decompress the response by itself
+		Test(t,
+			HTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}}),
+			FromCurl(`curl --compressed `+gzipServer.URL),
+			Expect().Body().String().Equal("Hello World"),
+		)
+
+		Test(t,
+			FromCurl(`curl --compressed -I `+gzipServer.URL),
+			Expect().Status().Equal(http.StatusOK),
+		)
+	})
+
+	t.Run("invalid command", func(t *testing.T) {
+		ExpectError(t,
+			Do(FromCurl(`wget `+s.URL)),
+			PtrStr(`command must start with curl`),
+		)
+	})
+}
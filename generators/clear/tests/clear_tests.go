@@ -15,6 +15,10 @@ import (
 
 	"io"
 
+	"net/http"
+
+	"time"
+
 	"github.com/dave/jennifer/jen"
 	"golang.org/x/xerrors"
 
@@ -26,6 +30,12 @@ const maxDepth = 5
 
 var hitStepType = reflect.TypeOf((*hit.IStep)(nil)).Elem()
 
+// functions that access the file system or the network, the specific test would execute them with the sample values
+var sideEffectFuncs = map[string]bool{
+	"SendBodyMultipartFile":           true,
+	"SendAuthOAuth2ClientCredentials": true,
+}
+
 func getDefaultValueRepresentation(t reflect.Type, isVariadic bool) string {
 	v := reflect.Zero(t)
 
@@ -67,6 +77,29 @@ func getDefaultValueRepresentation(t reflect.Type, isVariadic bool) string {
 			return `"Foo", "Baz"`
 		}
 		return `[]interface{}{"Foo", "Baz"}`
+	case http.Header:
+		return `http.Header{"Foo": []string{"Bar"}}`
+	case *http.Cookie:
+		return `&http.Cookie{Name: "Foo", Value: "Bar"}`
+	case []*http.Cookie:
+		if isVariadic {
+			return `&http.Cookie{Name: "Foo", Value: "Bar"}, &http.Cookie{Name: "Foo", Value: "Baz"}`
+		}
+		return `[]*http.Cookie{&http.Cookie{Name: "Foo", Value: "Bar"}, &http.Cookie{Name: "Foo", Value: "Baz"}}`
+	case time.Time:
+		return `time.Unix(1, 0)`
+	case []time.Time:
+		if isVariadic {
+			return `time.Unix(1, 0), time.Unix(2, 0)`
+		}
+		return `[]time.Time{time.Unix(1, 0), time.Unix(2, 0)}`
+	case time.Duration:
+		return `time.Second`
+	case []time.Duration:
+		if isVariadic {
+			return `time.Second, 2 * time.Second`
+		}
+		return `[]time.Duration{time.Second, 2 * time.Second}`
 	default:
 		if t.Implements(reflect.TypeOf((*io.Reader)(nil)).Elem()) {
 			return `bytes.NewReader(nil)`
@@ -119,6 +152,29 @@ func getSampleValueRepresentation(t reflect.Type, isVariadic bool) string {
 			return `"Hello", "Earth"`
 		}
 		return `[]interface{}{"Hello", "Earth"}`
+	case http.Header:
+		return `http.Header{"Hello": []string{"World"}}`
+	case *http.Cookie:
+		return `&http.Cookie{Name: "Hello", Value: "World"}`
+	case []*http.Cookie:
+		if isVariadic {
+			return `&http.Cookie{Name: "Hello", Value: "World"}, &http.Cookie{Name: "Hello", Value: "Earth"}`
+		}
+		return `[]*http.Cookie{&http.Cookie{Name: "Hello", Value: "World"}, &http.Cookie{Name: "Hello", Value: "Earth"}}`
+	case time.Time:
+		return `time.Unix(3, 0)`
+	case []time.Time:
+		if isVariadic {
+			return `time.Unix(3, 0), time.Unix(4, 0)`
+		}
+		return `[]time.Time{time.Unix(3, 0), time.Unix(4, 0)}`
+	case time.Duration:
+		return `time.Minute`
+	case []time.Duration:
+		if isVariadic {
+			return `time.Minute, 2 * time.Minute`
+		}
+		return `[]time.Duration{time.Minute, 2 * time.Minute}`
 	default:
 		if t.Implements(reflect.TypeOf((*io.Reader)(nil)).Elem()) {
 			return `bytes.NewReader([]byte{1, 2, 3})`
@@ -184,7 +240,7 @@ func generateForStruct(f *jen.File, options generateOpts) error {
 		}
 	}
 
-	specificTest := true
+	specificTest := !sideEffectFuncs[options.CallPath.Join("")]
 
 	lastArg := make([]reflect.Type, rfn.Type().NumIn())
 	for i := 0; i < len(lastArg); i++ {
@@ -227,6 +283,18 @@ func generateForStruct(f *jen.File, options generateOpts) error {
 		}
 	}
 
+	if specificTest {
+		// the specific test can only distinguish the steps if they have different arguments
+		var defaultRepresentation, sampleRepresentation bytes.Buffer
+		if err := defaultStmtLine.Render(&defaultRepresentation); err != nil {
+			return err
+		}
+		if err := sampleStmtLine.Render(&sampleRepresentation); err != nil {
+			return err
+		}
+		specificTest = defaultRepresentation.String() != sampleRepresentation.String()
+	}
+
 	var genericLineRepresentation bytes.Buffer
 	if err := genericLine.Render(&genericLineRepresentation); err != nil {
 		return err
@@ -412,6 +480,8 @@ func main() {
 	f.Op(`import . "github.com/Eun/go-hit"`)
 	f.Op(`import "github.com/stretchr/testify/require"`)
 	f.Op(`import "errors"`)
+	f.Op(`import "net/http"`)
+	f.Op(`import "time"`)
 
 	f.Comment("⚠️⚠️⚠️ This file was autogenerated by generators/clear/tests ⚠️⚠️⚠️ //")
 
@@ -0,0 +1,601 @@
+// Command openapi generates hit test skeletons for all operations of an OpenAPI 3 document.
+//
+// Usage:
+//     go run github.com/Eun/go-hit/generators/openapi -spec openapi.yaml -out api_test.go -package api_test
+//
+// One test will be generated for every operation, the request will be filled with the examples of the document
+// (path, query and header parameters, the json request body) and the response will be expected to have the first
+// documented success status. If the response has an example object, the keys of the example will be expected in the
+// json body.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/generators/helpers"
+	"github.com/Eun/go-hit/internal/jsonpointer"
+	"github.com/Eun/go-hit/internal/openapi"
+)
+
+// maxDepth limits the depth of the examples that will be generated from schemas.
+const maxDepth = 8
+
+func main() {
+	spec := flag.String("spec", "", "path to the OpenAPI document (yaml or json)")
+	out := flag.String("out", "openapi_gen_test.go", "file to write the tests to")
+	pkg := flag.String("package", "", "package name of the generated file (defaults to the directory name + _test)")
+	baseURL := flag.String("base-url", "", "url of the server to test (defaults to the first server of the document)")
+	flag.Parse()
+
+	if *spec == "" {
+		flag.Usage()
+		log.Fatal("-spec is required")
+	}
+	if err := generate(*spec, *out, *pkg, *baseURL); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(spec, out, pkg, baseURL string) error {
+	doc, err := openapi.Load(spec)
+	if err != nil {
+		return err
+	}
+
+	if pkg == "" {
+		abs, err := filepath.Abs(out)
+		if err != nil {
+			return err
+		}
+		pkg = packageName(filepath.Base(filepath.Dir(abs))) + "_test"
+	}
+	if baseURL == "" {
+		baseURL = serverURL(doc)
+	}
+
+	f := jen.NewFile(pkg)
+	f.Op(`import . "github.com/Eun/go-hit"`)
+	f.Comment("⚠️⚠️⚠️ This file was autogenerated by generators/openapi ⚠️⚠️⚠️ //")
+	f.Line()
+	f.Comment("baseURL is the url of the server the tests will be run against.")
+	f.Var().Id("baseURL").Op("=").Lit(baseURL)
+
+	names := make(map[string]int)
+	for _, op := range doc.Operations() {
+		g := &generator{doc: doc}
+		steps, err := g.steps(op)
+		if err != nil {
+			return xerrors.Errorf("unable to generate the test for %s: %w", op.String(), err)
+		}
+
+		name := testName(op)
+		names[name]++
+		if n := names[name]; n > 1 {
+			name += strconv.Itoa(n)
+		}
+
+		f.Line()
+		f.Commentf("%s tests %s.", name, op.String())
+		if summary, ok := op.Raw["summary"].(string); ok && summary != "" {
+			f.Comment(summary)
+		}
+		f.Func().Id(name).Params(jen.Id("t").Op("*").Qual("testing", "T")).Block(
+			jen.Id("Test").CallFunc(func(g *jen.Group) {
+				g.Id("t")
+				for _, step := range steps {
+					g.Line().Add(step)
+				}
+				g.Line()
+			}),
+		)
+	}
+
+	return helpers.WriteJenFile(out, f)
+}
+
+func serverURL(doc *openapi.Document) string {
+	servers, _ := doc.Raw()["servers"].([]interface{})
+	if len(servers) > 0 {
+		if m, ok := servers[0].(map[string]interface{}); ok {
+			if u, ok := m["url"].(string); ok && u != "" {
+				return strings.TrimSuffix(resolveServerVariables(u, m), "/")
+			}
+		}
+	}
+	return "http://localhost"
+}
+
+var templateVariable = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// resolveServerVariables replaces the variables of the server url with their default values.
+func resolveServerVariables(u string, server map[string]interface{}) string {
+	variables, _ := server["variables"].(map[string]interface{})
+	return templateVariable.ReplaceAllStringFunc(u, func(s string) string {
+		v, _ := variables[strings.Trim(s, "{}")].(map[string]interface{})
+		if def, ok := v["default"].(string); ok {
+			return def
+		}
+		return s
+	})
+}
+
+var nonIdentifier = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func packageName(s string) string {
+	s = strings.ToLower(nonIdentifier.ReplaceAllString(s, ""))
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		return "api"
+	}
+	return s
+}
+
+// initialisms are words that are written in upper case in go identifiers.
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true, "SQL": true,
+	"TLS": true, "UID": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// testName returns the name of the test function for the operation, e.g. TestGetUserByID.
+func testName(op *openapi.Operation) string {
+	s := op.ID
+	if s == "" {
+		s = strings.ToLower(op.Method) + " " + templateVariable.ReplaceAllString(op.Path, "by $1")
+	}
+	var sb strings.Builder
+	sb.WriteString("Test")
+	for _, part := range nonIdentifier.Split(s, -1) {
+		for _, word := range splitCamelCase(part) {
+			if initialisms[strings.ToUpper(word)] {
+				sb.WriteString(strings.ToUpper(word))
+				continue
+			}
+			r := []rune(word)
+			sb.WriteRune(unicode.ToUpper(r[0]))
+			sb.WriteString(string(r[1:]))
+		}
+	}
+	return sb.String()
+}
+
+// splitCamelCase splits s before every upper case letter that follows a lower case letter or a digit,
+// e.g. petId becomes pet and Id.
+func splitCamelCase(s string) []string {
+	var words []string
+	r := []rune(s)
+	start := 0
+	for i := 1; i < len(r); i++ {
+		if unicode.IsUpper(r[i]) && (unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1])) {
+			words = append(words, string(r[start:i]))
+			start = i
+		}
+	}
+	if start < len(r) {
+		words = append(words, string(r[start:]))
+	}
+	return words
+}
+
+type generator struct {
+	doc *openapi.Document
+}
+
+// steps generates the steps of the test for the operation.
+func (g *generator) steps(op *openapi.Operation) ([]jen.Code, error) {
+	var steps []jen.Code
+	if op.ID != "" {
+		steps = append(steps, jen.Id("Description").Call(jen.Lit(op.ID)))
+	}
+
+	path := op.Path
+	var query, headers []jen.Code
+	for _, p := range op.Parameters() {
+		value, ok := g.parameterExample(p)
+		if !ok {
+			if p.In == "path" {
+				// keep the template variable, so the test fails with an obvious url
+				continue
+			}
+			if !p.Required {
+				continue
+			}
+			value = "TODO"
+		}
+		switch p.In {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.Name+"}", value)
+		case "query":
+			query = append(query,
+				jen.Id("Request").Call().Dot("URL").Call().Dot("Query").Call(jen.Lit(p.Name)).Dot("Add").Call(jen.Lit(value)),
+			)
+		case "header":
+			headers = append(headers,
+				jen.Id("Send").Call().Dot("Headers").Call(jen.Lit(p.Name)).Dot("Add").Call(jen.Lit(value)),
+			)
+		case "cookie":
+			headers = append(headers,
+				jen.Id("Send").Call().Dot("Headers").Call(jen.Lit("Cookie")).Dot("Add").Call(jen.Lit(p.Name+"="+value)),
+			)
+		}
+	}
+
+	url := jen.Id("baseURL").Op("+").Lit(path)
+	if fn := methodFunc(op.Method); fn != "" {
+		steps = append(steps, jen.Id(fn).Call(url))
+	} else {
+		steps = append(steps, jen.Id("Method").Call(jen.Lit(op.Method), url))
+	}
+	steps = append(steps, query...)
+	steps = append(steps, headers...)
+
+	body, err := g.requestBody(op)
+	if err != nil {
+		return nil, err
+	}
+	steps = append(steps, body...)
+
+	expect, err := g.expect(op)
+	if err != nil {
+		return nil, err
+	}
+	return append(steps, expect...), nil
+}
+
+func methodFunc(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch, http.MethodHead,
+		http.MethodOptions, http.MethodTrace:
+		return strings.Title(strings.ToLower(method))
+	}
+	return ""
+}
+
+func (g *generator) parameterExample(p *openapi.Parameter) (string, bool) {
+	param, _, err := g.doc.Lookup(p.Pointer)
+	if err != nil {
+		return "", false
+	}
+	v, ok := mediaExample(param)
+	if !ok && p.Schema != "" {
+		v, ok = g.schemaExample(p.Schema, 0)
+	}
+	if !ok {
+		return "", false
+	}
+	switch t := v.(type) {
+	case string:
+		return t, true
+	case []interface{}:
+		parts := make([]string, len(t))
+		for i := range t {
+			parts[i] = fmt.Sprint(t[i])
+		}
+		return strings.Join(parts, ","), true
+	default:
+		return fmt.Sprint(t), true
+	}
+}
+
+// mediaExample returns the example of a parameter or media type object (example or the first of examples).
+func mediaExample(m map[string]interface{}) (interface{}, bool) {
+	if v, ok := m["example"]; ok {
+		return v, true
+	}
+	examples, _ := m["examples"].(map[string]interface{})
+	keys := make([]string, 0, len(examples))
+	for k := range examples {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if e, ok := examples[k].(map[string]interface{}); ok {
+			if v, ok := e["value"]; ok {
+				return v, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// schemaExample generates an example value for the schema at the pointer, explicit examples and default values are
+// preferred over generated values.
+//
+//nolint:gocognit // the schema keywords are easier to follow in one function
+func (g *generator) schemaExample(pointer string, depth int) (interface{}, bool) {
+	if depth > maxDepth {
+		return nil, false
+	}
+	schema, pointer, err := g.doc.Lookup(pointer)
+	if err != nil {
+		return nil, false
+	}
+	if v, ok := schema["example"]; ok {
+		return v, true
+	}
+	if examples, ok := schema["examples"].([]interface{}); ok && len(examples) > 0 {
+		return examples[0], true
+	}
+	for _, keyword := range []string{"const", "default"} {
+		if v, ok := schema[keyword]; ok {
+			return v, true
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[0], true
+	}
+	for _, keyword := range []string{"allOf", "oneOf", "anyOf"} {
+		list, ok := schema[keyword].([]interface{})
+		if !ok || len(list) == 0 {
+			continue
+		}
+		if keyword != "allOf" {
+			return g.schemaExample(jsonpointer.Join(pointer, keyword, "0"), depth+1)
+		}
+		merged := make(map[string]interface{})
+		for i := range list {
+			v, ok := g.schemaExample(jsonpointer.Join(pointer, keyword, strconv.Itoa(i)), depth+1)
+			if m, isMap := v.(map[string]interface{}); ok && isMap {
+				for k, v := range m {
+					merged[k] = v
+				}
+			}
+		}
+		return merged, true
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		properties, _ := schema["properties"].(map[string]interface{})
+		obj := make(map[string]interface{}, len(properties))
+		for name := range properties {
+			if v, ok := g.schemaExample(jsonpointer.Join(pointer, "properties", name), depth+1); ok {
+				obj[name] = v
+			}
+		}
+		return obj, true
+	case "array":
+		if _, ok := schema["items"]; !ok {
+			return []interface{}{}, true
+		}
+		if v, ok := g.schemaExample(pointer+"/items", depth+1); ok {
+			return []interface{}{v}, true
+		}
+		return []interface{}{}, true
+	case "string":
+		return stringExample(schema), true
+	case "integer", "number":
+		if v, ok := schema["minimum"]; ok {
+			return v, true
+		}
+		return json.Number("1"), true
+	case "boolean":
+		return true, true
+	}
+	return nil, false
+}
+
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	if _, ok := schema["items"]; ok {
+		return "array"
+	}
+	return ""
+}
+
+func stringExample(schema map[string]interface{}) string {
+	switch format, _ := schema["format"].(string); format {
+	case "date":
+		return "2020-01-01"
+	case "date-time":
+		return "2020-01-01T00:00:00Z"
+	case "email":
+		return "joe@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	}
+	return "string"
+}
+
+// requestBody generates the steps for the json request body of the operation.
+func (g *generator) requestBody(op *openapi.Operation) ([]jen.Code, error) {
+	if _, ok := op.Raw["requestBody"]; !ok {
+		return nil, nil
+	}
+	body, pointer, err := g.doc.Lookup(op.Pointer + "/requestBody")
+	if err != nil {
+		return nil, err
+	}
+	key, media := jsonMedia(body)
+	if media == nil {
+		return nil, nil
+	}
+	v, ok := mediaExample(media)
+	if !ok {
+		if _, hasSchema := media["schema"]; hasSchema {
+			v, ok = g.schemaExample(jsonpointer.Join(pointer, "content", key, "schema"), 0)
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+	return []jen.Code{
+		jen.Id("Send").Call().Dot("Headers").Call(jen.Lit("Content-Type")).Dot("Add").Call(jen.Lit(key)),
+		jen.Id("Send").Call().Dot("Body").Call().Dot("JSON").Call(value(v)),
+	}, nil
+}
+
+// jsonMedia returns the first json media type of the content.
+func jsonMedia(parent map[string]interface{}) (string, map[string]interface{}) {
+	content, _ := parent["content"].(map[string]interface{})
+	keys := make([]string, 0, len(content))
+	for k := range content {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		mediaType := strings.ToLower(strings.TrimSpace(strings.Split(k, ";")[0]))
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			m, _ := content[k].(map[string]interface{})
+			return k, m
+		}
+	}
+	return "", nil
+}
+
+// expect generates the expect steps for the first documented success response of the operation.
+func (g *generator) expect(op *openapi.Operation) ([]jen.Code, error) {
+	responses, _ := op.Raw["responses"].(map[string]interface{})
+	status := successStatus(responses)
+	if status == "" {
+		return nil, nil
+	}
+
+	var steps []jen.Code
+	if code, err := strconv.Atoi(status); err == nil {
+		steps = append(steps, jen.Id("Expect").Call().Dot("Status").Call().Dot("Equal").Call(statusCode(code)))
+	} else {
+		min := int(status[0]-'0') * 100
+		steps = append(steps, jen.Id("Expect").Call().Dot("Status").Call().Dot("Between").Call(
+			jen.Lit(min), jen.Lit(min+99),
+		))
+	}
+
+	response, _, err := g.doc.Lookup(jsonpointer.Join(op.Pointer, "responses", status))
+	if err != nil {
+		return nil, err
+	}
+	_, media := jsonMedia(response)
+	if media == nil {
+		return steps, nil
+	}
+	example, ok := mediaExample(media)
+	if !ok {
+		return steps, nil
+	}
+	obj, ok := example.(map[string]interface{})
+	if !ok || len(obj) == 0 {
+		return steps, nil
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		steps = append(steps, jen.Id("Expect").Call().Dot("Body").Call().Dot("JSON").Call().Dot("Contains").Call(jen.Lit(k)))
+	}
+	return steps, nil
+}
+
+// successStatus returns the lowest documented 2XX status (or range), if there is no success status the lowest
+// documented status will be returned.
+func successStatus(responses map[string]interface{}) string {
+	keys := make([]string, 0, len(responses))
+	for k := range responses {
+		if k != "default" {
+			keys = append(keys, strings.ToUpper(k))
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.HasPrefix(k, "2") {
+			return k
+		}
+	}
+	if len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
+
+var statusNames = map[int]string{
+	http.StatusOK:                   "StatusOK",
+	http.StatusCreated:              "StatusCreated",
+	http.StatusAccepted:             "StatusAccepted",
+	http.StatusNonAuthoritativeInfo: "StatusNonAuthoritativeInfo",
+	http.StatusNoContent:            "StatusNoContent",
+	http.StatusResetContent:         "StatusResetContent",
+	http.StatusPartialContent:       "StatusPartialContent",
+	http.StatusMovedPermanently:     "StatusMovedPermanently",
+	http.StatusFound:                "StatusFound",
+	http.StatusSeeOther:             "StatusSeeOther",
+	http.StatusNotModified:          "StatusNotModified",
+	http.StatusTemporaryRedirect:    "StatusTemporaryRedirect",
+	http.StatusPermanentRedirect:    "StatusPermanentRedirect",
+	http.StatusBadRequest:           "StatusBadRequest",
+	http.StatusUnauthorized:         "StatusUnauthorized",
+	http.StatusForbidden:            "StatusForbidden",
+	http.StatusNotFound:             "StatusNotFound",
+	http.StatusConflict:             "StatusConflict",
+}
+
+func statusCode(code int) jen.Code {
+	if name, ok := statusNames[code]; ok {
+		return jen.Qual("net/http", name)
+	}
+	return jen.Lit(code)
+}
+
+// value converts the decoded json value into go code.
+func value(v interface{}) jen.Code {
+	switch t := v.(type) {
+	case nil:
+		return jen.Nil()
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return jen.Map(jen.String()).Interface().ValuesFunc(func(g *jen.Group) {
+			for _, k := range keys {
+				g.Line().Lit(k).Op(":").Add(value(t[k]))
+			}
+			if len(keys) > 0 {
+				g.Line()
+			}
+		})
+	case []interface{}:
+		return jen.Index().Interface().ValuesFunc(func(g *jen.Group) {
+			for _, item := range t {
+				g.Add(value(item))
+			}
+		})
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return jen.Lit(int(i))
+		}
+		if f, err := t.Float64(); err == nil {
+			return jen.Lit(f)
+		}
+		return jen.Lit(t.String())
+	default:
+		return jen.Lit(t)
+	}
+}
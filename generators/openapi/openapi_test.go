@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerate(t *testing.T) {
+	// generate into a temporary directory inside the module, so the generated tests can be compiled against go-hit
+	dir, err := ioutil.TempDir(".", "generated")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	out := filepath.Join(dir, "petstore_test.go")
+	require.NoError(t, generate(filepath.Join("testdata", "petstore.yaml"), out, "petstore_test", ""))
+
+	actual, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+
+	golden := filepath.Join("testdata", "petstore_test.go.golden")
+	if *update {
+		require.NoError(t, ioutil.WriteFile(golden, actual, 0o600))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(actual))
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not found")
+	}
+	// vet compiles the generated tests without running them
+	args := []string{"vet"}
+	if _, err := os.Stat(filepath.Join("..", "..", "vendor", "modules.txt")); err == nil {
+		// resolve go-hit and its dependencies from the vendor directory, they might not be downloadable
+		args = append(args, "-mod=vendor")
+	}
+	output, err := exec.Command(goBin, append(args, "./"+dir)...).CombinedOutput()
+	require.NoError(t, err, string(output))
+}
@@ -0,0 +1,463 @@
+package hit
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// HARRecorder records every request and response that was executed by hit in the HTTP Archive (HAR) 1.2 format.
+// A HARRecorder can be shared across multiple (even concurrent) Do calls.
+//
+// Example:
+//     recorder := NewHARRecorder()
+//     MustDo(
+//         Description("Get the index page"),
+//         RecordHAR(recorder),
+//         Get("https://example.com/index.html"),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+//     if err := recorder.WriteFile(filepath.Join(os.TempDir(), "example.har")); err != nil {
+//         panic(err)
+//     }
+type HARRecorder struct {
+	mu      sync.Mutex
+	pages   []harPage
+	entries []harEntry
+}
+
+// NewHARRecorder creates a new HARRecorder.
+func NewHARRecorder() *HARRecorder {
+	return &HARRecorder{}
+}
+
+// RecordHAR records the request and the response to the specified HARRecorder, the entry will be recorded even if
+// a later step fails.
+// The Description() will be used as the title of the page the entry belongs to.
+// Request headers and cookies that contain credentials (Authorization, Proxy-Authorization, Cookie and headers set by
+// Send().Auth()) and the password of the url will be redacted.
+// To record all requests of a Session, pass it as a step to NewSession().
+//
+// Example:
+//     recorder := NewHARRecorder()
+//     session := NewSession(RecordHAR(recorder))
+//     session.MustDo(
+//         Get("https://example.com/index.html"),
+//     )
+func RecordHAR(recorder *HARRecorder) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("RecordHAR", nil),
+		Exec: func(hit *hitImpl) error {
+			hit.har = recorder
+			return nil
+		},
+	}
+}
+
+// Len returns the number of recorded entries.
+func (r *HARRecorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.entries)
+}
+
+// WriteTo writes the recorded entries as HAR (JSON) to the specified writer.
+func (r *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	pages := make([]harPage, len(r.pages))
+	copy(pages, r.pages)
+	entries := make([]harEntry, len(r.entries))
+	copy(entries, r.entries)
+	r.mu.Unlock()
+
+	// concurrent Do calls might finish in any order
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedDateTime.Before(entries[j].StartedDateTime)
+	})
+
+	buf, err := json.MarshalIndent(harFile{
+		Log: harLog{
+			Version: "1.2",
+			Creator: harCreator{
+				Name:    "go-hit",
+				Version: "",
+			},
+			Pages:   pages,
+			Entries: entries,
+		},
+	}, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(buf)
+	return int64(n), err
+}
+
+// WriteFile writes the recorded entries as HAR (JSON) to the specified file.
+func (r *HARRecorder) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := r.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// page returns the id of the page for the specified description, a new page will be created if the description was
+// not used before.
+func (r *HARRecorder) page(description string, started time.Time) string {
+	if description == "" {
+		return ""
+	}
+	for _, p := range r.pages {
+		if p.Title == description {
+			return p.ID
+		}
+	}
+	id := "page_" + strconv.Itoa(len(r.pages)+1)
+	r.pages = append(r.pages, harPage{
+		StartedDateTime: started,
+		ID:              id,
+		Title:           description,
+		PageTimings: harPageTimings{
+			OnContentLoad: -1,
+			OnLoad:        -1,
+		},
+	})
+	return id
+}
+
+// record records the current request and response of the hit instance.
+// wait is the time until the response headers were received, it will only be used if there is no response.
+func (r *HARRecorder) record(hit *hitImpl, started time.Time, wait time.Duration, requestErr error) {
+	entry := harEntry{
+		StartedDateTime: started,
+		Time:            durationToMilliseconds(wait),
+		Request:         harRequestFromHit(hit),
+		Response: harResponse{
+			HTTPVersion: "",
+			Cookies:     []harCookie{},
+			Headers:     []harNameValue{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Cache: struct{}{},
+		Timings: harTimings{
+			Blocked: -1,
+			DNS:     -1,
+			Connect: -1,
+			Send:    0,
+			Wait:    durationToMilliseconds(wait),
+			Receive: 0,
+			SSL:     -1,
+		},
+		Comment: hit.description,
+	}
+	if hit.response != nil {
+		entry.Response = harResponseFromHit(hit)
+		entry.Timings = harTimingsFromTiming(hit.response.Timing())
+		entry.Time = entry.Timings.total()
+	}
+	if requestErr != nil {
+		entry.Response.Comment = requestErr.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry.PageRef = r.page(hit.description, started)
+	r.entries = append(r.entries, entry)
+}
+
+// harTimingsFromTiming converts the Timing into harTimings, phases that did not happen (e.g. the dns lookup for a
+// reused connection) will be -1.
+func harTimingsFromTiming(t Timing) harTimings {
+	optional := func(d time.Duration) float64 {
+		if d <= 0 {
+			return -1
+		}
+		return durationToMilliseconds(d)
+	}
+	timings := harTimings{
+		Blocked: -1,
+		DNS:     optional(t.DNS),
+		// connect includes the ssl time
+		Connect: optional(t.Connect + t.TLSHandshake),
+		Send:    0,
+		Receive: durationToMilliseconds(t.BodyRead),
+		SSL:     optional(t.TLSHandshake),
+	}
+	wait := t.TimeToFirstByte - t.DNS - t.Connect - t.TLSHandshake
+	if wait < 0 {
+		wait = 0
+	}
+	timings.Wait = durationToMilliseconds(wait)
+	return timings
+}
+
+// total returns the sum of all phases, ssl is already included in connect.
+func (t *harTimings) total() float64 {
+	total := t.Send + t.Wait + t.Receive
+	for _, v := range []float64{t.Blocked, t.DNS, t.Connect} {
+		if v > 0 {
+			total += v
+		}
+	}
+	return total
+}
+
+func harRequestFromHit(hit *hitImpl) harRequest {
+	req := hit.request.Request
+	r := harRequest{
+		Method:      req.Method,
+		URL:         redactURL(req.URL).String(),
+		HTTPVersion: req.Proto,
+		Cookies:     harRedactedCookies(req.Cookies()),
+		Headers:     harHeaders(redactHeaders(req.Header, hit.recordingRedactions()...)),
+		QueryString: []harNameValue{},
+		HeadersSize: -1,
+		BodySize:    0,
+	}
+	if r.HTTPVersion == "" {
+		r.HTTPVersion = "HTTP/1.1"
+	}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			r.QueryString = append(r.QueryString, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(r.QueryString, func(i, j int) bool {
+		return r.QueryString[i].Name < r.QueryString[j].Name
+	})
+
+	body, err := hit.request.Body().Bytes()
+	if err != nil || len(body) == 0 {
+		return r
+	}
+	r.BodySize = len(body)
+	r.PostData = &harPostData{
+		MimeType: req.Header.Get("Content-Type"),
+		Params:   []harNameValue{},
+		Text:     string(body),
+	}
+	return r
+}
+
+func harResponseFromHit(hit *hitImpl) harResponse {
+	res := hit.response.Response
+	r := harResponse{
+		Status:      res.StatusCode,
+		StatusText:  http.StatusText(res.StatusCode),
+		HTTPVersion: res.Proto,
+		Cookies:     harCookies(res.Cookies()),
+		Headers:     harHeaders(res.Header),
+		RedirectURL: res.Header.Get("Location"),
+		HeadersSize: -1,
+		BodySize:    -1,
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	r.Content.MimeType = contentType
+	if r.Content.MimeType == "" {
+		r.Content.MimeType = "application/octet-stream"
+	}
+
+	body, err := hit.response.Body().Bytes()
+	if err != nil {
+		r.Content.Comment = err.Error()
+		return r
+	}
+	r.BodySize = len(body)
+	r.Content.Size = len(body)
+	if isTextContent(contentType, body) {
+		r.Content.Text = string(body)
+	} else {
+		r.Content.Text = base64.StdEncoding.EncodeToString(body)
+		r.Content.Encoding = "base64"
+	}
+	return r
+}
+
+// isTextContent reports whether the body can be stored as text in the HAR file.
+func isTextContent(contentType string, body []byte) bool {
+	if !utf8.Valid(body) {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+	switch mediaType {
+	case "application/octet-stream", "application/zip", "application/gzip", "application/pdf":
+		return false
+	}
+	return true
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := []harNameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			// an empty User-Agent is used to prevent the default User-Agent, it will not be sent
+			if value == "" && http.CanonicalHeaderKey(name) == "User-Agent" {
+				continue
+			}
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+	return headers
+}
+
+func harCookies(cookies []*http.Cookie) []harCookie {
+	result := make([]harCookie, 0, len(cookies))
+	for _, c := range cookies {
+		hc := harCookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Domain:   c.Domain,
+			HTTPOnly: c.HttpOnly,
+			Secure:   c.Secure,
+		}
+		if !c.Expires.IsZero() {
+			expires := c.Expires
+			hc.Expires = &expires
+		}
+		result = append(result, hc)
+	}
+	return result
+}
+
+// harRedactedCookies converts the request cookies, the values will be redacted.
+func harRedactedCookies(cookies []*http.Cookie) []harCookie {
+	result := harCookies(cookies)
+	for i := range result {
+		result[i].Value = "[REDACTED]"
+	}
+	return result
+}
+
+func durationToMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Pages   []harPage  `json:"pages"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harPage struct {
+	StartedDateTime time.Time      `json:"startedDateTime"`
+	ID              string         `json:"id"`
+	Title           string         `json:"title"`
+	PageTimings     harPageTimings `json:"pageTimings"`
+}
+
+type harPageTimings struct {
+	OnContentLoad float64 `json:"onContentLoad"`
+	OnLoad        float64 `json:"onLoad"`
+}
+
+type harEntry struct {
+	PageRef         string      `json:"pageref,omitempty"`
+	StartedDateTime time.Time   `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harCookie    `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+	Comment     string         `json:"comment,omitempty"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harPostData struct {
+	MimeType string         `json:"mimeType"`
+	Params   []harNameValue `json:"params"`
+	Text     string         `json:"text"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harCookie struct {
+	Name     string     `json:"name"`
+	Value    string     `json:"value"`
+	Path     string     `json:"path,omitempty"`
+	Domain   string     `json:"domain,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	HTTPOnly bool       `json:"httpOnly,omitempty"`
+	Secure   bool       `json:"secure,omitempty"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
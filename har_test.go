@@ -0,0 +1,218 @@
+package hit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestHARRecorder(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	recorder := NewHARRecorder()
+
+	Test(t,
+		Description("create user"),
+		RecordHAR(recorder),
+		Post(s.URL+"/users?page=1"),
+		Send().Headers("Content-Type").Add("application/json"),
+		Send().Body().String(`{"Name":"Joe"}`),
+		Expect().Status().Equal(http.StatusOK),
+	)
+
+	// failing steps will be recorded, too
+	err := Do(
+		RecordHAR(recorder),
+		Post(s.URL),
+		Send().Body().Bytes([]byte{0xff, 0xfe}),
+		Expect().Status().Equal(http.StatusNotFound),
+	)
+	require.Error(t, err)
+
+	// connection errors
+	err = Do(
+		RecordHAR(recorder),
+		Get("http://127.0.0.1:0"),
+	)
+	require.Error(t, err)
+
+	require.Equal(t, 3, recorder.Len())
+
+	var buf bytes.Buffer
+	_, err = recorder.WriteTo(&buf)
+	require.NoError(t, err)
+
+	var har struct {
+		Log struct {
+			Version string
+			Creator struct {
+				Name string
+			}
+			Pages []struct {
+				ID    string
+				Title string
+			}
+			Entries []struct {
+				PageRef string
+				Comment string
+				Time    float64
+				Request struct {
+					Method      string
+					URL         string
+					QueryString []struct{ Name, Value string }
+					PostData    struct {
+						MimeType string
+						Text     string
+					}
+				}
+				Response struct {
+					Status  int
+					Comment string
+					Headers []struct{ Name, Value string }
+					Content struct {
+						Size     int
+						MimeType string
+						Text     string
+						Encoding string
+					}
+				}
+				Timings struct {
+					Wait float64
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+	require.Equal(t, "1.2", har.Log.Version)
+	require.Equal(t, "go-hit", har.Log.Creator.Name)
+	require.Len(t, har.Log.Pages, 1)
+	require.Equal(t, "create user", har.Log.Pages[0].Title)
+	require.Len(t, har.Log.Entries, 3)
+
+	entry := har.Log.Entries[0]
+	require.Equal(t, har.Log.Pages[0].ID, entry.PageRef)
+	require.Equal(t, "create user", entry.Comment)
+	require.Equal(t, http.MethodPost, entry.Request.Method)
+	require.Equal(t, s.URL+"/users?page=1", entry.Request.URL)
+	require.Equal(t, []struct{ Name, Value string }{{"page", "1"}}, entry.Request.QueryString)
+	require.Equal(t, "application/json", entry.Request.PostData.MimeType)
+	require.Equal(t, `{"Name":"Joe"}`, entry.Request.PostData.Text)
+	require.Equal(t, http.StatusOK, entry.Response.Status)
+	require.Contains(t, entry.Response.Headers, struct{ Name, Value string }{"Content-Type", "application/json"})
+	require.Equal(t, `{"Name":"Joe"}`, entry.Response.Content.Text)
+	require.Equal(t, 14, entry.Response.Content.Size)
+	require.True(t, entry.Time >= entry.Timings.Wait)
+
+	entry = har.Log.Entries[1]
+	require.Empty(t, entry.PageRef)
+	require.Equal(t, http.StatusOK, entry.Response.Status)
+	require.Equal(t, "base64", entry.Response.Content.Encoding)
+	require.Equal(t, "//4=", entry.Response.Content.Text)
+
+	entry = har.Log.Entries[2]
+	require.Equal(t, 0, entry.Response.Status)
+	require.Contains(t, entry.Response.Comment, "127.0.0.1:0")
+}
+
+func TestHARRecorder_Session(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	recorder := NewHARRecorder()
+	session := NewSession(
+		BaseURL(s.URL),
+		RecordHAR(recorder),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = session.Do(
+				Post("/"),
+				Send().Body().String("Hello World"),
+			)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 10, recorder.Len())
+
+	dir, err := ioutil.TempDir("", "har")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "session.har")
+	require.NoError(t, recorder.WriteFile(path))
+	buf, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, json.Valid(buf))
+}
+
+func TestHARRecorder_TimingsAndRedaction(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer s.Close()
+
+	recorder := NewHARRecorder()
+	Test(t,
+		RecordHAR(recorder),
+		HTTPClient(s.Client()),
+		Get(strings.Replace(s.URL, "https://", "https://joe:supersecret@", 1)),
+		Send().Auth().Bearer("supersecret"),
+		Send().Headers("Cookie").Add("session=supersecret"),
+		Send().Headers("X-Name").Add("Joe"),
+	)
+
+	var buf bytes.Buffer
+	_, err := recorder.WriteTo(&buf)
+	require.NoError(t, err)
+	require.NotContains(t, buf.String(), "supersecret")
+
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Time    float64
+				Request struct {
+					URL     string
+					Headers []struct{ Name, Value string }
+					Cookies []struct{ Name, Value string }
+				}
+				Timings struct {
+					Blocked, DNS, Connect, Send, Wait, Receive, SSL float64
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+	require.Len(t, har.Log.Entries, 1)
+	entry := har.Log.Entries[0]
+
+	require.Equal(t, strings.Replace(s.URL, "https://", "https://joe:%5BREDACTED%5D@", 1), entry.Request.URL)
+	require.Equal(t, []struct{ Name, Value string }{
+		{"Authorization", "Bearer [REDACTED]"},
+		{"Cookie", "[REDACTED]"},
+		{"X-Name", "Joe"},
+	}, entry.Request.Headers)
+	require.Equal(t, []struct{ Name, Value string }{{"session", "[REDACTED]"}}, entry.Request.Cookies)
+
+	timings := entry.Timings
+	// no dns lookup is necessary for an ip address
+	require.Equal(t, float64(-1), timings.DNS)
+	require.Greater(t, timings.Connect, float64(0))
+	require.Greater(t, timings.SSL, float64(0))
+	require.LessOrEqual(t, timings.SSL, timings.Connect)
+	require.GreaterOrEqual(t, timings.Wait, float64(0))
+	require.InDelta(t, timings.Connect+timings.Wait+timings.Receive, entry.Time, 0.001)
+}
@@ -39,7 +39,10 @@ package hit
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"golang.org/x/xerrors"
 )
@@ -118,6 +121,14 @@ type Hit interface {
 
 	// Context returns the current request context
 	Context() context.Context
+
+	// Variable returns the variable with the specified name, the second return value reports whether the variable
+	// exists.
+	// Variables are shared across all Do calls of a Session.
+	Variable(name string) (interface{}, bool)
+
+	// SetVariable sets the variable with the specified name to the specified value.
+	SetVariable(name string, value interface{})
 }
 
 type hitImpl struct {
@@ -129,6 +140,17 @@ type hitImpl struct {
 	state       StepTime
 	baseURL     string
 	description string
+	retry       *RetryPolicy
+	variables   *variables
+	stdout      io.Writer
+	har         *HARRecorder
+
+	collectAllFailures bool
+	includeCurlInError bool
+	failures           []*Error
+	redactedHeaders    []string
+	cassette           *cassetteTransport
+	transports         []clonedTransport
 }
 
 func (hit *hitImpl) Request() *HTTPRequest {
@@ -159,6 +181,69 @@ func (hit *hitImpl) SetHTTPClient(client *http.Client) error {
 	return nil
 }
 
+// redactHeader marks the specified request header to contain credentials, its values will be redacted in the
+// Debug() output, curl commands and recordings.
+func (hit *hitImpl) redactHeader(name string) {
+	hit.redactedHeaders = append(hit.redactedHeaders, name)
+}
+
+// credentialHeaders are request headers that always contain credentials, they will be redacted in recordings
+// (Cassette() and RecordHAR()).
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+// recordingRedactions returns the request headers that will be redacted in recordings, the credentialHeaders and
+// the headers marked by redactHeader.
+func (hit *hitImpl) recordingRedactions() []string {
+	return append(append([]string{}, credentialHeaders...), hit.redactedHeaders...)
+}
+
+// redactedRequestHeader returns a copy of the request header with all headers marked by redactHeader redacted.
+func (hit *hitImpl) redactedRequestHeader() http.Header {
+	return redactHeaders(hit.Request().Header, hit.redactedHeaders...)
+}
+
+// redactHeaders returns a copy of the header where the values of the specified headers are replaced, only the
+// authentication scheme (e.g. Basic or Bearer) will be kept.
+// If none of the headers are present the original header will be returned.
+func redactHeaders(header http.Header, names ...string) http.Header {
+	redacted := header
+	cloned := false
+	for _, name := range names {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+		if !cloned {
+			redacted = header.Clone()
+			cloned = true
+		}
+		redactedValues := make([]string, len(values))
+		for i, value := range values {
+			redactedValues[i] = "[REDACTED]"
+			// keep the authentication scheme
+			if n := strings.IndexByte(value, ' '); n > 0 && !strings.ContainsAny(value[:n], "=;") {
+				redactedValues[i] = value[:n] + " [REDACTED]"
+			}
+		}
+		redacted[http.CanonicalHeaderKey(name)] = redactedValues
+	}
+	return redacted
+}
+
+// redactURL returns a copy of the url where the password of the user info is replaced.
+// If the url contains no password the original url will be returned.
+func redactURL(u *url.URL) *url.URL {
+	if u == nil || u.User == nil {
+		return u
+	}
+	if _, ok := u.User.Password(); !ok {
+		return u
+	}
+	redacted := *u
+	redacted.User = url.UserPassword(u.User.Username(), "[REDACTED]")
+	return &redacted
+}
+
 func (hit *hitImpl) BaseURL() string {
 	return hit.baseURL
 }
@@ -197,7 +282,9 @@ nextStep:
 
 		hit.currentStep = stepsToRun[i]
 		if err := execStep(hit, stepsToRun[i]); err != nil {
-			return err
+			if !hit.collectFailure(err) {
+				return err
+			}
 		}
 		executedSteps = append(executedSteps, stepsToRun[i])
 
@@ -216,6 +303,16 @@ nextStep:
 	return nil
 }
 
+// collectFailure collects the specified error if CollectAllFailures() was used and the error occurred during the
+// ExpectStep or AfterExpectStep, it returns false if the error was not collected.
+func (hit *hitImpl) collectFailure(err *Error) bool {
+	if !hit.collectAllFailures || (hit.state != ExpectStep && hit.state != AfterExpectStep) {
+		return false
+	}
+	hit.failures = append(hit.failures, err)
+	return true
+}
+
 func stepsAreEqual(a, b []IStep) bool {
 	if len(a) != len(b) {
 		return false
@@ -297,3 +394,11 @@ func (hit *hitImpl) SetDescription(description string) {
 func (hit *hitImpl) Context() context.Context {
 	return hit.request.Context()
 }
+
+func (hit *hitImpl) Variable(name string) (interface{}, bool) {
+	return hit.variables.Get(name)
+}
+
+func (hit *hitImpl) SetVariable(name string, value interface{}) {
+	hit.variables.Set(name, value)
+}
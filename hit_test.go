@@ -9,6 +9,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/cookiejar"
 	"net/http/httptest"
 	"net/url"
 	"os"
@@ -372,6 +373,45 @@ func TestHTTPClient(t *testing.T) {
 	)
 }
 
+func TestCookieJar(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(writer http.ResponseWriter, request *http.Request) {
+		http.SetCookie(writer, &http.Cookie{Name: "session", Value: "abc123", Path: "/"})
+		writer.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/profile", func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = io.WriteString(writer, request.Header.Get("Cookie"))
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+	client := &http.Client{}
+
+	Test(t,
+		Get(s.URL+"/login"),
+		HTTPClient(client),
+		CookieJar(jar),
+		Expect().Cookies("session").Value().Equal("abc123"),
+	)
+
+	Test(t,
+		Get(s.URL+"/profile"),
+		HTTPClient(client),
+		CookieJar(jar),
+		Expect().Body().String().Equal("session=abc123"),
+	)
+
+	// the client itself should not be modified
+	require.Nil(t, client.Jar)
+	Test(t,
+		Get(s.URL+"/profile"),
+		HTTPClient(client),
+		Expect().Body().String().Equal(""),
+	)
+}
+
 func TestCombineSteps(t *testing.T) {
 	s := EchoServer()
 	defer s.Close()
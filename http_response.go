@@ -10,7 +10,9 @@ import (
 type HTTPResponse struct {
 	Hit Hit
 	*http.Response
-	body *httpbody.HTTPBody
+	body      *httpbody.HTTPBody
+	redirects []*http.Response
+	timing    *timingRecorder
 }
 
 func newHTTPResponse(hit Hit, response *http.Response) *HTTPResponse {
@@ -27,3 +29,17 @@ func newHTTPResponse(hit Hit, response *http.Response) *HTTPResponse {
 func (r *HTTPResponse) Body() *httpbody.HTTPBody {
 	return r.body
 }
+
+// Redirects returns the responses of all redirects that were followed to get this response, in the order they
+// occurred. The bodies of these responses are already closed.
+func (r *HTTPResponse) Redirects() []*http.Response {
+	return r.redirects
+}
+
+// Timing returns the timing breakdown of the request.
+func (r *HTTPResponse) Timing() Timing {
+	if r.timing == nil {
+		return Timing{}
+	}
+	return r.timing.Timing()
+}
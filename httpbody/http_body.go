@@ -24,6 +24,7 @@ import (
 // HTTPBody provides setters and getters for the http body.
 type HTTPBody struct {
 	factory doppelgangerreader.DoppelgangerFactory
+	source  io.Reader
 	headers http.Header
 }
 
@@ -47,6 +48,12 @@ func (body *HTTPBody) SetReader(r io.Reader) {
 		body.factory = nil
 	}
 	body.factory = doppelgangerreader.NewFactory(r)
+	body.source = r
+}
+
+// Source returns the reader that was used to set the body, it returns nil if no body was set.
+func (body *HTTPBody) Source() io.Reader {
+	return body.source
 }
 
 // SetBytes sets the body to the specified byte slice.
@@ -458,6 +465,20 @@ func (body *HTTPBody) MustFormValues() *URLValues {
 	return v
 }
 
+// Multipart returns the body as a multipart body.
+func (body *HTTPBody) Multipart() (*Multipart, error) {
+	return ParseMultipart(body)
+}
+
+// MustMultipart returns the body as a multipart body, it panics on failure.
+func (body *HTTPBody) MustMultipart() *Multipart {
+	v, err := body.Multipart()
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 // Bool returns the body as an bool.
 func (body *HTTPBody) Bool() (bool, error) {
 	s, err := body.String()
@@ -483,7 +504,7 @@ func (body *HTTPBody) JSON() *HTTPBodyJSON {
 
 // XML treats the body as XML encoded data.
 func (body *HTTPBody) XML() *HTTPBodyXML {
-	return newHTTPBodyXML(body)
+	return newHTTPBodyXML(body.Reader, body.SetBytes)
 }
 
 // GetBestFittingObject tries its best to return an appropriate object for the body.
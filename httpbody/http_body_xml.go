@@ -2,25 +2,129 @@ package httpbody
 
 import (
 	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/converter"
+	"github.com/Eun/go-hit/internal/xpath"
 )
 
 // HTTPBodyXML provides XML functions for the HTTPBody.
 type HTTPBodyXML struct { //nolint:revive //ignore type name will be used as httpbody.HTTPBodyXML by other packages
-	body *HTTPBody
+	body    func() io.ReadCloser
+	setBody func([]byte)
 }
 
-func newHTTPBodyXML(body *HTTPBody) *HTTPBodyXML {
+func newHTTPBodyXML(body func() io.ReadCloser, setBody func([]byte)) *HTTPBodyXML {
 	return &HTTPBodyXML{
-		body: body,
+		body:    body,
+		setBody: setBody,
+	}
+}
+
+// Decode decodes the body as XML.
+func (x *HTTPBodyXML) Decode(container interface{}) error {
+	return xml.NewDecoder(x.body()).Decode(container)
+}
+
+// MustDecode decodes the body as XML, it will panic if something goes wrong.
+func (x *HTTPBodyXML) MustDecode(container interface{}) {
+	if err := x.Decode(container); err != nil {
+		panic(err)
 	}
 }
 
-// Set sets the body to the specified json data.
-func (jsn *HTTPBodyXML) Set(data interface{}) error {
+// Set sets the body to the specified xml data.
+func (x *HTTPBodyXML) Set(data interface{}) error {
+	if x.setBody == nil {
+		return xerrors.New("setBody is nil")
+	}
 	buf, err := xml.Marshal(data)
 	if err != nil {
 		return err
 	}
-	jsn.body.SetBytes(buf)
+	x.setBody(buf)
 	return nil
 }
+
+// XPath evaluates an XPath 1.0 expression on the XML body, the result will be stored into container.
+//
+// If the expression selects nodes and the container is a struct (or implements xml.Unmarshaler) the first selected
+// element will be decoded into the container, slices will receive all selected nodes. For any other container the
+// text of the first selected node will be converted into the container.
+// Results of expressions that do not select nodes (e.g. count(//entry)) will be converted into the container.
+func (x *HTTPBodyXML) XPath(container interface{}, expression string) error {
+	expr, err := xpath.Compile(expression)
+	if err != nil {
+		return err
+	}
+	doc, err := xpath.Parse(x.body())
+	if err != nil {
+		return err
+	}
+	result, err := expr.Evaluate(doc)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(container)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return xerrors.Errorf("container must be a non nil pointer, got %T", container)
+	}
+
+	ns, ok := result.(xpath.NodeSet)
+	if !ok {
+		return converter.Convert(result, container)
+	}
+	return storeNodes(ns, rv.Elem())
+}
+
+// MustXPath evaluates an XPath 1.0 expression on the XML body, the result will be stored into container, if an error
+// occurs it will panic.
+func (x *HTTPBodyXML) MustXPath(container interface{}, expression string) {
+	if err := x.XPath(container, expression); err != nil {
+		panic(err)
+	}
+}
+
+var xmlUnmarshalerType = reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()
+
+// decodesElements reports whether values of the type should be decoded from the xml of an element.
+func decodesElements(t reflect.Type) bool {
+	if reflect.PtrTo(t).Implements(xmlUnmarshalerType) {
+		return true
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func storeNodes(ns xpath.NodeSet, v reflect.Value) error {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(v.Type(), len(ns), len(ns))
+		for i, n := range ns {
+			if err := storeNode(n, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	}
+
+	if len(ns) == 0 {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	return storeNode(ns[0], v)
+}
+
+func storeNode(n *xpath.Node, v reflect.Value) error {
+	if decodesElements(v.Type()) && n.Type == xpath.ElementNode {
+		return xml.NewDecoder(strings.NewReader(xpath.XML(n))).Decode(v.Addr().Interface())
+	}
+	return converter.Convert(n.String(), v.Addr().Interface())
+}
@@ -3,6 +3,8 @@ package httpbody
 import (
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestHttpBodyXml_Set(t *testing.T) {
@@ -33,3 +35,114 @@ func TestHttpBodyXml_Set(t *testing.T) {
 		})
 	}
 }
+
+const testFeed = `<feed xmlns="http://www.w3.org/2005/Atom">
+  <title>Example Feed</title>
+  <entry id="1"><title>Hello</title><rating>4.5</rating></entry>
+  <entry id="2"><title>World</title><rating>3</rating></entry>
+</feed>`
+
+type testEntry struct {
+	ID    int    `xml:"id,attr"`
+	Title string `xml:"title"`
+}
+
+func TestHttpBodyXml_Decode(t *testing.T) {
+	type Feed struct {
+		Title   string      `xml:"title"`
+		Entries []testEntry `xml:"entry"`
+	}
+
+	testServer(testFeed, func(body *HTTPBody) {
+		var feed Feed
+		require.NoError(t, body.XML().Decode(&feed))
+		require.Equal(t, Feed{
+			Title:   "Example Feed",
+			Entries: []testEntry{{ID: 1, Title: "Hello"}, {ID: 2, Title: "World"}},
+		}, feed)
+	})
+
+	testServer(`Hello World`, func(body *HTTPBody) {
+		var feed Feed
+		require.Error(t, body.XML().Decode(&feed))
+	})
+}
+
+func TestHttpBodyXml_MustDecode(t *testing.T) {
+	require.Panics(t, func() {
+		testServer(`Hello World`, func(body *HTTPBody) {
+			var s string
+			body.XML().MustDecode(&s)
+		})
+	})
+}
+
+func TestHttpBodyXml_XPath(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			var title string
+			require.NoError(t, body.XML().XPath(&title, "/feed/entry[2]/title"))
+			require.Equal(t, "World", title)
+		})
+	})
+
+	t.Run("int", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			var id int
+			require.NoError(t, body.XML().XPath(&id, "/feed/entry[2]/@id"))
+			require.Equal(t, 2, id)
+		})
+	})
+
+	t.Run("number", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			var sum float64
+			require.NoError(t, body.XML().XPath(&sum, "sum(//rating)"))
+			require.Equal(t, 7.5, sum)
+
+			var count int
+			require.NoError(t, body.XML().XPath(&count, "count(//entry)"))
+			require.Equal(t, 2, count)
+		})
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			var entry testEntry
+			require.NoError(t, body.XML().XPath(&entry, "/feed/entry[1]"))
+			require.Equal(t, testEntry{ID: 1, Title: "Hello"}, entry)
+		})
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			var entries []testEntry
+			require.NoError(t, body.XML().XPath(&entries, "//entry"))
+			require.Equal(t, []testEntry{{ID: 1, Title: "Hello"}, {ID: 2, Title: "World"}}, entries)
+
+			var titles []string
+			require.NoError(t, body.XML().XPath(&titles, "//entry/title"))
+			require.Equal(t, []string{"Hello", "World"}, titles)
+		})
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			title := "Hello"
+			require.NoError(t, body.XML().XPath(&title, "/feed/entry[3]/title"))
+			require.Equal(t, "", title)
+		})
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		testServer(testFeed, func(body *HTTPBody) {
+			var title string
+			require.EqualError(t, body.XML().XPath(&title, "/feed/entry["),
+				`invalid xpath expression "/feed/entry[": unexpected end of expression`)
+			require.Error(t, body.XML().XPath(title, "/feed/title"))
+			require.Panics(t, func() {
+				body.XML().MustXPath(&title, "/feed/entry[")
+			})
+		})
+	})
+}
@@ -0,0 +1,177 @@
+package httpbody
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// MultipartPart is a single part of a multipart body.
+type MultipartPart struct {
+	Header textproto.MIMEHeader
+	Data   []byte
+}
+
+// Body returns the part's content as a HTTPBody, modifications will not be written back to the multipart body.
+func (p *MultipartPart) Body() *HTTPBody {
+	return NewHTTPBody(bytes.NewReader(p.Data), http.Header(p.Header))
+}
+
+// Multipart is a multipart representation that works with HTTPBody.
+// Every modification rewrites the body and sets the Content-Type header (including the boundary).
+type Multipart struct {
+	body      *HTTPBody
+	mediaType string
+	boundary  string
+	parts     []*MultipartPart
+}
+
+// Boundary returns the boundary that separates the parts.
+func (m *Multipart) Boundary() string {
+	return m.boundary
+}
+
+// Parts returns all parts.
+func (m *Multipart) Parts() []*MultipartPart {
+	return m.parts
+}
+
+// Part returns the part with the specified index, the first part has the index 0.
+func (m *Multipart) Part(index int) (*MultipartPart, error) {
+	if index < 0 || index >= len(m.parts) {
+		return nil, xerrors.Errorf("unable to find part %d, body has %d parts", index, len(m.parts))
+	}
+	return m.parts[index], nil
+}
+
+// MustPart returns the part with the specified index, it panics if the part does not exist.
+func (m *Multipart) MustPart(index int) *MultipartPart {
+	p, err := m.Part(index)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// AddField adds a form field with the specified value.
+func (m *Multipart) AddField(name, value string) error {
+	return m.AddPart(name, nil, strings.NewReader(value))
+}
+
+// AddFile adds a file with the specified file name to the field, the content is read from r.
+func (m *Multipart) AddFile(field, fileName string, r io.Reader) error {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", multipart.FileContentDisposition(field, fileName))
+	h.Set("Content-Type", "application/octet-stream")
+	return m.AddPart(field, h, r)
+}
+
+// AddPart adds a part with the specified headers to the field, the content is read from r.
+// If the header does not contain a Content-Disposition it will be set to form-data with the field name.
+func (m *Multipart) AddPart(field string, header textproto.MIMEHeader, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if header == nil {
+		header = make(textproto.MIMEHeader)
+	}
+	if header.Get("Content-Disposition") == "" {
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(field)))
+	}
+	m.parts = append(m.parts, &MultipartPart{
+		Header: header,
+		Data:   data,
+	})
+	return m.encode()
+}
+
+func (m *Multipart) encode() error {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(m.boundary); err != nil {
+		return err
+	}
+	for _, p := range m.parts {
+		pw, err := w.CreatePart(p.Header)
+		if err != nil {
+			return err
+		}
+		if _, err = pw.Write(p.Data); err != nil {
+			return err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	m.body.SetBytes(buf.Bytes())
+	if m.body.headers != nil {
+		m.body.headers.Set("Content-Type", mime.FormatMediaType(m.mediaType, map[string]string{"boundary": m.boundary}))
+	}
+	return nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// ErrNotMultipart is returned by ParseMultipart if the body is not a multipart body.
+var ErrNotMultipart = xerrors.New("body is not multipart")
+
+// NewMultipart returns an empty multipart/form-data body with a random boundary, the first modification replaces the
+// current content of the HTTPBody.
+func NewMultipart(body *HTTPBody) *Multipart {
+	return &Multipart{
+		body:      body,
+		mediaType: "multipart/form-data",
+		boundary:  multipart.NewWriter(ioutil.Discard).Boundary(),
+	}
+}
+
+// ParseMultipart takes a HTTPBody and parses the parts, it returns a pointer to Multipart.
+// If the Content-Type header does not describe a multipart body (or the boundary is missing) ErrNotMultipart will be
+// returned.
+func ParseMultipart(body *HTTPBody) (*Multipart, error) {
+	mediaType, params, err := mime.ParseMediaType(body.headers.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return nil, ErrNotMultipart
+	}
+	m := &Multipart{
+		body: body,
+	}
+	m.mediaType = mediaType
+	m.boundary = params["boundary"]
+
+	buf, err := body.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return m, nil
+	}
+
+	r := multipart.NewReader(bytes.NewReader(buf), m.boundary)
+	for {
+		p, err := r.NextRawPart()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(p)
+		if err != nil {
+			return nil, err
+		}
+		m.parts = append(m.parts, &MultipartPart{
+			Header: p.Header,
+			Data:   data,
+		})
+	}
+}
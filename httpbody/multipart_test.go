@@ -0,0 +1,70 @@
+package httpbody
+
+import (
+	"net/http"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultipart(t *testing.T) {
+	headers := make(http.Header)
+	body := NewHTTPBody(nil, headers)
+
+	m := NewMultipart(body)
+	require.NoError(t, m.AddField("username", "joe"))
+	require.NoError(t, m.AddFile("avatar", "avatar.png", strings.NewReader("Hello Avatar")))
+	require.NoError(t, m.AddPart("metadata", textproto.MIMEHeader{"Content-Type": {"application/json"}}, strings.NewReader(`{"ID":10}`)))
+	require.Equal(t, "multipart/form-data; boundary="+m.Boundary(), headers.Get("Content-Type"))
+
+	// parse the body again and add another field
+	m = body.MustMultipart()
+	require.NoError(t, m.AddField("password", "secret"))
+
+	m = body.MustMultipart()
+	require.Len(t, m.Parts(), 4)
+	require.Equal(t, `form-data; name="username"`, m.Parts()[0].Header.Get("Content-Disposition"))
+	require.Equal(t, "joe", string(m.Parts()[0].Data))
+	require.Equal(t, `form-data; name="avatar"; filename="avatar.png"`, m.Parts()[1].Header.Get("Content-Disposition"))
+	require.Equal(t, "application/octet-stream", m.Parts()[1].Header.Get("Content-Type"))
+	require.Equal(t, "Hello Avatar", string(m.Parts()[1].Data))
+	require.Equal(t, `form-data; name="metadata"`, m.Parts()[2].Header.Get("Content-Disposition"))
+	require.Equal(t, "application/json", m.Parts()[2].Header.Get("Content-Type"))
+	require.Equal(t, "secret", string(m.Parts()[3].Data))
+}
+
+func TestParseMultipart_NotMultipart(t *testing.T) {
+	tests := []http.Header{
+		{},
+		{"Content-Type": {"application/json"}},
+		{"Content-Type": {"multipart/form-data"}},
+		{"Content-Type": {"text/plain; boundary=foo123"}},
+	}
+	for _, headers := range tests {
+		_, err := NewHTTPBody(strings.NewReader(`{"Name": "Joe"}`), headers).Multipart()
+		require.Equal(t, ErrNotMultipart, err, "Content-Type: %s", headers.Get("Content-Type"))
+	}
+}
+
+func TestMultipart_Part(t *testing.T) {
+	headers := http.Header{"Content-Type": {`multipart/mixed; boundary="foo123"`}}
+	body := NewHTTPBody(strings.NewReader("--foo123\r\n"+
+		"Content-Type: application/json\r\n\r\n"+
+		`{"Name": "Joe"}`+"\r\n"+
+		"--foo123--\r\n"), headers)
+
+	m := body.MustMultipart()
+	require.Len(t, m.Parts(), 1)
+
+	p, err := m.Part(0)
+	require.NoError(t, err)
+	require.Equal(t, "application/json", p.Header.Get("Content-Type"))
+	var v map[string]interface{}
+	require.NoError(t, p.Body().JSON().Decode(&v))
+	require.Equal(t, map[string]interface{}{"Name": "Joe"}, v)
+
+	_, err = m.Part(1)
+	require.EqualError(t, err, "unable to find part 1, body has 1 parts")
+}
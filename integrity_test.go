@@ -66,6 +66,7 @@ func TestIntegrityClearExpect(t *testing.T) {
 	ImplementsAllFunctionsOf(t, hit.Expect().Body().Int(), hit.Clear().Expect().Body().Int())
 	ImplementsAllFunctionsOf(t, hit.Expect().Body().JSON(), hit.Clear().Expect().Body().JSON())
 	ImplementsAllFunctionsOf(t, hit.Expect().Body().String(), hit.Clear().Expect().Body().String())
+	ImplementsAllFunctionsOf(t, hit.Expect().AllHeaders(), hit.Clear().Expect().AllHeaders())
 	ImplementsAllFunctionsOf(t, hit.Expect().Headers(""), hit.Clear().Expect().Headers())
 	ImplementsAllFunctionsOf(t, hit.Expect().Trailers(""), hit.Clear().Expect().Trailers())
 	ImplementsAllFunctionsOf(t, hit.Expect().Status(), hit.Clear().Expect().Status())
@@ -0,0 +1,145 @@
+// Package curl provides functions to convert http requests to curl command lines.
+package curl
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Command renders the specified request and body as a curl command line that can be pasted into a shell.
+//
+// Because curl is not able to send trailers, the trailers will be sent as headers.
+func Command(req *http.Request, body []byte) string {
+	args := []string{"curl"}
+
+	switch {
+	case req.Method == http.MethodHead:
+		args = append(args, "--head")
+	case req.Method == "" || (req.Method == http.MethodGet && len(body) == 0):
+	default:
+		args = append(args, "-X", Quote(req.Method))
+	}
+
+	if req.URL != nil {
+		u := *req.URL
+		if u.User != nil {
+			args = append(args, "-u", Quote(userInfo(u.User)))
+			u.User = nil
+		}
+		args = append(args, Quote(u.String()))
+	}
+
+	if req.Host != "" {
+		args = append(args, "-H", Quote("Host: "+req.Host))
+	}
+	args = append(args, headers(req.Header)...)
+	args = append(args, headers(req.Trailer)...)
+
+	switch {
+	case len(body) == 0:
+	case body[0] == '@':
+		// curl would read the body from a file with --data-binary, --data-raw sends it as is
+		args = append(args, "--data-raw", Quote(string(body)))
+	default:
+		args = append(args, "--data-binary", Quote(string(body)))
+	}
+
+	return strings.Join(args, " ")
+}
+
+func userInfo(u *url.Userinfo) string {
+	if password, ok := u.Password(); ok {
+		return u.Username() + ":" + password
+	}
+	return u.Username()
+}
+
+func headers(h http.Header) []string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var args []string
+	for _, name := range names {
+		for _, value := range h[name] {
+			switch {
+			case value != "":
+				args = append(args, "-H", Quote(name+": "+value))
+			case strings.EqualFold(name, "User-Agent"):
+				// an empty user agent means no user agent, "Name:" removes the header in curl
+				args = append(args, "-H", Quote(name+":"))
+			default:
+				// "Name;" sends an empty header in curl
+				args = append(args, "-H", Quote(name+";"))
+			}
+		}
+	}
+	return args
+}
+
+// Quote quotes the specified string so it can be used as one argument in a posix shell.
+// Strings that contain non printable characters will be quoted using ANSI-C quoting ($'...').
+func Quote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if isSafe(s) {
+		return s
+	}
+	if isPrintable(s) {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("$'")
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\'' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c == '\n':
+			sb.WriteString(`\n`)
+		case c == '\r':
+			sb.WriteString(`\r`)
+		case c == '\t':
+			sb.WriteString(`\t`)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&sb, `\x%02x`, c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('\'')
+	return sb.String()
+}
+
+func isSafe(s string) bool {
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("-_./:@%+=,", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isPrintable(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	for _, r := range s {
+		if r < 0x20 && r != '\n' && r != '\t' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,516 @@
+package curl
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Header represents a header that should be sent.
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Request represents a parsed curl command.
+type Request struct {
+	Method   string
+	URL      string
+	Headers  []Header
+	Body     []byte
+	Username string
+	Password string
+	// HasUser reports whether the user was specified (-u).
+	HasUser bool
+	// Insecure reports whether TLS verification should be skipped (-k).
+	Insecure bool
+	// Compressed reports whether a compressed response was requested (--compressed).
+	Compressed bool
+}
+
+type parser struct {
+	req      Request
+	method   string
+	data     []string
+	hasData  bool
+	form     *multipart.Writer
+	formBody bytes.Buffer
+	get      bool
+	head     bool
+}
+
+// ignoredBoolFlags are flags without arguments that will be ignored because they have no effect on the request.
+//nolint:gochecknoglobals // lookup table
+var ignoredBoolFlags = map[string]bool{
+	"-s": true, "--silent": true,
+	"-S": true, "--show-error": true,
+	"-v": true, "--verbose": true,
+	"-L": true, "--location": true,
+	"-i": true, "--include": true,
+	"-f": true, "--fail": true,
+	"-#": true, "--progress-bar": true,
+	"--http1.1": true, "--http2": true,
+}
+
+// ignoredArgFlags are flags with an argument that will be ignored because they have no effect on the request.
+//nolint:gochecknoglobals // lookup table
+var ignoredArgFlags = map[string]bool{
+	"-o": true, "--output": true,
+	"-m": true, "--max-time": true,
+	"--connect-timeout": true,
+}
+
+// argFlags are the short flags that require an argument.
+const argFlags = "XHdFuAebom"
+
+// Parse parses the specified curl command line.
+//
+// Supported flags are -X, -H, -d, --data-raw, --data-binary, --data-urlencode, -F, -u, -A, -e, -b, -G, -I,
+// --compressed, -k and --url.
+func Parse(cmd string) (*Request, error) {
+	args, err := Split(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, xerrors.New("command must start with curl")
+	}
+
+	var p parser
+	args = args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			p.req.URL = arg
+			continue
+		}
+
+		for _, name := range expandFlags(arg) {
+			name, value, hasValue := splitFlag(name)
+			if ignoredBoolFlags[name] {
+				continue
+			}
+			if !hasValue && flagRequiresArgument(name) {
+				if i+1 >= len(args) {
+					return nil, xerrors.Errorf("missing argument for %s", name)
+				}
+				i++
+				value = args[i]
+			}
+			if err := p.apply(name, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return p.finish()
+}
+
+// expandFlags expands combined short flags (e.g. -sSL) into single flags (-s, -S, -L).
+// A flag that requires an argument ends the expansion, the rest will be used as its argument (e.g. -sXPOST).
+func expandFlags(arg string) []string {
+	if strings.HasPrefix(arg, "--") || len(arg) <= 2 {
+		return []string{arg}
+	}
+	var flags []string
+	for i := 1; i < len(arg); i++ {
+		if strings.IndexByte(argFlags, arg[i]) >= 0 {
+			return append(flags, "-"+arg[i:])
+		}
+		flags = append(flags, "-"+string(arg[i]))
+	}
+	return flags
+}
+
+// splitFlag splits short flags with an argument (e.g. -XPOST) and --flag=value arguments.
+func splitFlag(arg string) (name, value string, hasValue bool) {
+	if strings.HasPrefix(arg, "--") {
+		if i := strings.IndexRune(arg, '='); i > 0 {
+			return arg[:i], arg[i+1:], true
+		}
+		return arg, "", false
+	}
+	if len(arg) > 2 && strings.ContainsRune(argFlags, rune(arg[1])) {
+		return arg[:2], arg[2:], true
+	}
+	return arg, "", false
+}
+
+func flagRequiresArgument(name string) bool {
+	if ignoredArgFlags[name] {
+		return true
+	}
+	if len(name) == 2 {
+		return strings.ContainsRune(argFlags, rune(name[1]))
+	}
+	switch name {
+	case "--request", "--header", "--data", "--data-raw", "--data-binary", "--data-ascii", "--data-urlencode",
+		"--form", "--user", "--user-agent", "--referer", "--cookie", "--url":
+		return true
+	}
+	return false
+}
+
+//nolint:gocyclo // a switch for each flag
+func (p *parser) apply(name, value string) error {
+	switch name {
+	case "-X", "--request":
+		p.method = value
+	case "-H", "--header":
+		return p.header(value)
+	case "-d", "--data", "--data-ascii":
+		return p.addData(value, true, true)
+	case "--data-binary":
+		return p.addData(value, true, false)
+	case "--data-raw":
+		return p.addData(value, false, false)
+	case "--data-urlencode":
+		p.hasData = true
+		p.data = append(p.data, urlEncodeData(value))
+	case "-F", "--form":
+		return p.addForm(value)
+	case "-u", "--user":
+		p.req.HasUser = true
+		p.req.Username = value
+		if i := strings.IndexRune(value, ':'); i >= 0 {
+			p.req.Username = value[:i]
+			p.req.Password = value[i+1:]
+		}
+	case "-A", "--user-agent":
+		p.req.Headers = append(p.req.Headers, Header{Name: "User-Agent", Value: value})
+	case "-e", "--referer":
+		p.req.Headers = append(p.req.Headers, Header{Name: "Referer", Value: value})
+	case "-b", "--cookie":
+		// without a = curl reads the cookies from the file with that name
+		if !strings.ContainsRune(value, '=') {
+			return xerrors.Errorf("unsupported curl flag %s: reading cookies from a file is not supported", name)
+		}
+		p.req.Headers = append(p.req.Headers, Header{Name: "Cookie", Value: value})
+	case "-G", "--get":
+		p.get = true
+	case "-I", "--head":
+		p.head = true
+	case "-k", "--insecure":
+		p.req.Insecure = true
+	case "--compressed":
+		p.req.Compressed = true
+	case "--url":
+		p.req.URL = value
+	default:
+		if ignoredArgFlags[name] {
+			return nil
+		}
+		return xerrors.Errorf("unsupported curl flag %s", name)
+	}
+	return nil
+}
+
+func (p *parser) header(value string) error {
+	if i := strings.IndexRune(value, ':'); i > 0 {
+		v := strings.TrimSpace(value[i+1:])
+		if v == "" {
+			// "Name:" removes the header in curl
+			return nil
+		}
+		p.req.Headers = append(p.req.Headers, Header{Name: strings.TrimSpace(value[:i]), Value: v})
+		return nil
+	}
+	if strings.HasSuffix(value, ";") {
+		// "Name;" sends an empty header in curl
+		p.req.Headers = append(p.req.Headers, Header{Name: strings.TrimSpace(strings.TrimSuffix(value, ";")), Value: ""})
+		return nil
+	}
+	return xerrors.Errorf("invalid header %q", value)
+}
+
+func (p *parser) addData(value string, allowFile, stripNewLines bool) error {
+	p.hasData = true
+	if allowFile && strings.HasPrefix(value, "@") {
+		buf, err := ioutil.ReadFile(value[1:])
+		if err != nil {
+			return err
+		}
+		value = string(buf)
+		if stripNewLines {
+			value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+		}
+	}
+	p.data = append(p.data, value)
+	return nil
+}
+
+func urlEncodeData(value string) string {
+	i := strings.IndexRune(value, '=')
+	switch {
+	case i < 0:
+		return url.QueryEscape(value)
+	case i == 0:
+		return url.QueryEscape(value[1:])
+	default:
+		return value[:i] + "=" + url.QueryEscape(value[i+1:])
+	}
+}
+
+func (p *parser) addForm(value string) error {
+	i := strings.IndexRune(value, '=')
+	if i <= 0 {
+		return xerrors.Errorf("invalid form field %q", value)
+	}
+	name, value := value[:i], value[i+1:]
+
+	if p.form == nil {
+		p.form = multipart.NewWriter(&p.formBody)
+	}
+
+	if !strings.HasPrefix(value, "@") && !strings.HasPrefix(value, "<") {
+		return p.form.WriteField(name, value)
+	}
+
+	// parse parameters, e.g. @file.txt;type=text/plain;filename=upload.txt
+	params := strings.Split(value[1:], ";")
+	path := params[0]
+	fileName := filepath.Base(path)
+	contentType := ""
+	for _, param := range params[1:] {
+		switch {
+		case strings.HasPrefix(param, "type="):
+			contentType = strings.TrimPrefix(param, "type=")
+		case strings.HasPrefix(param, "filename="):
+			fileName = strings.Trim(strings.TrimPrefix(param, "filename="), `"`)
+		}
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if value[0] == '<' {
+		// send the file contents as a regular field
+		return p.form.WriteField(name, string(content))
+	}
+
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", multipart.FileContentDisposition(name, fileName))
+	h.Set("Content-Type", contentType)
+	w, err := p.form.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	return err
+}
+
+func (p *parser) hasHeader(name string) bool {
+	for _, h := range p.req.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) finish() (*Request, error) {
+	if p.req.URL == "" {
+		return nil, xerrors.New("no url specified")
+	}
+	if p.hasData && p.form != nil {
+		return nil, xerrors.New("-d and -F cannot be used together")
+	}
+
+	method := http.MethodGet
+	switch {
+	case p.head:
+		method = http.MethodHead
+	case p.form != nil:
+		method = http.MethodPost
+		if err := p.form.Close(); err != nil {
+			return nil, err
+		}
+		p.req.Body = p.formBody.Bytes()
+		if !p.hasHeader("Content-Type") {
+			p.req.Headers = append(p.req.Headers, Header{Name: "Content-Type", Value: p.form.FormDataContentType()})
+		}
+	case p.hasData && p.get:
+		sep := "?"
+		if strings.ContainsRune(p.req.URL, '?') {
+			sep = "&"
+		}
+		p.req.URL += sep + strings.Join(p.data, "&")
+	case p.hasData:
+		method = http.MethodPost
+		p.req.Body = []byte(strings.Join(p.data, "&"))
+		if !p.hasHeader("Content-Type") {
+			p.req.Headers = append(p.req.Headers, Header{Name: "Content-Type", Value: "application/x-www-form-urlencoded"})
+		}
+	}
+
+	if p.method != "" {
+		method = p.method
+	}
+	p.req.Method = method
+
+	return &p.req, nil
+}
+
+// Split splits the specified command line into arguments the same way a posix shell would do.
+// Single quotes, double quotes, ANSI-C quotes ($'...'), backslash escapes and line continuations are supported.
+//
+//nolint:gocognit // a state machine is easier to follow in one function
+func Split(s string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case c == '\\':
+			if i+1 >= len(s) {
+				return nil, xerrors.New("unexpected end after \\")
+			}
+			i++
+			if s[i] == '\n' {
+				// line continuation
+				continue
+			}
+			inArg = true
+			current.WriteByte(s[i])
+		case c == '\'':
+			end := strings.IndexByte(s[i+1:], '\'')
+			if end < 0 {
+				return nil, xerrors.New("unterminated single quote")
+			}
+			inArg = true
+			current.WriteString(s[i+1 : i+1+end])
+			i += end + 1
+		case c == '$' && i+1 < len(s) && s[i+1] == '\'':
+			n, err := readANSIC(s[i+2:], &current)
+			if err != nil {
+				return nil, err
+			}
+			inArg = true
+			i += n + 1
+		case c == '"':
+			n, err := readDoubleQuoted(s[i+1:], &current)
+			if err != nil {
+				return nil, err
+			}
+			inArg = true
+			i += n
+		default:
+			inArg = true
+			current.WriteByte(c)
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// readDoubleQuoted reads a double quoted string (without the leading quote) into sb, it returns the number of
+// bytes consumed (including the closing quote).
+func readDoubleQuoted(s string, sb *strings.Builder) (int, error) {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			return i + 1, nil
+		case '\\':
+			if i+1 < len(s) && strings.IndexByte("\"\\$`\n", s[i+1]) >= 0 {
+				i++
+				if s[i] != '\n' {
+					sb.WriteByte(s[i])
+				}
+				continue
+			}
+			sb.WriteByte(s[i])
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return 0, xerrors.New("unterminated double quote")
+}
+
+// readANSIC reads an ANSI-C quoted string (without the leading $') into sb, it returns the number of bytes
+// consumed (including the closing quote).
+func readANSIC(s string, sb *strings.Builder) (int, error) {
+	escapes := map[byte]byte{
+		'n': '\n', 'r': '\r', 't': '\t', 'a': '\a', 'b': '\b', 'f': '\f', 'v': '\v',
+		'\\': '\\', '\'': '\'', '"': '"', '?': '?', 'e': 0x1b, 'E': 0x1b,
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '\'' {
+			return i + 1, nil
+		}
+		if c != '\\' || i+1 >= len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		i++
+		if r, ok := escapes[s[i]]; ok {
+			sb.WriteByte(r)
+			continue
+		}
+		if s[i] == 'x' {
+			n, v := parseHex(s[i+1:], 2)
+			if n == 0 {
+				sb.WriteString(`\x`)
+				continue
+			}
+			sb.WriteByte(byte(v))
+			i += n
+			continue
+		}
+		if s[i] == 'u' {
+			n, v := parseHex(s[i+1:], 4)
+			if n == 0 {
+				sb.WriteString(`\u`)
+				continue
+			}
+			sb.WriteRune(rune(v))
+			i += n
+			continue
+		}
+		sb.WriteByte('\\')
+		sb.WriteByte(s[i])
+	}
+	return 0, xerrors.New("unterminated ANSI-C quote")
+}
+
+func parseHex(s string, maxDigits int) (n, v int) {
+	for n < maxDigits && n < len(s) {
+		c := s[n]
+		var d int
+		switch {
+		case c >= '0' && c <= '9':
+			d = int(c - '0')
+		case c >= 'a' && c <= 'f':
+			d = int(c-'a') + 10
+		case c >= 'A' && c <= 'F':
+			d = int(c-'A') + 10
+		default:
+			return n, v
+		}
+		v = v*16 + d
+		n++
+	}
+	return n, v
+}
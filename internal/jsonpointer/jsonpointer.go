@@ -0,0 +1,65 @@
+// Package jsonpointer implements JSON pointers (RFC 6901) for decoded JSON documents.
+package jsonpointer
+
+import (
+	"strconv"
+	"strings"
+)
+
+var (
+	escaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	unescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// Escape escapes the specified token so it can be used in a JSON pointer.
+func Escape(token string) string {
+	return escaper.Replace(token)
+}
+
+// Unescape unescapes the specified JSON pointer token.
+func Unescape(token string) string {
+	return unescaper.Replace(token)
+}
+
+// Join appends the specified tokens (escaped) to the pointer.
+func Join(pointer string, tokens ...string) string {
+	var sb strings.Builder
+	sb.WriteString(pointer)
+	for _, token := range tokens {
+		sb.WriteRune('/')
+		sb.WriteString(Escape(token))
+	}
+	return sb.String()
+}
+
+// Get returns the value the pointer points to in the decoded JSON document, the second return value reports whether
+// the value exists.
+func Get(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	target := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = Unescape(token)
+		switch v := target.(type) {
+		case map[string]interface{}:
+			var ok bool
+			target, ok = v[token]
+			if !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			target = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return target, true
+}
@@ -0,0 +1,32 @@
+package jsonpointer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	require.Equal(t, "a~1b~0c", Escape("a/b~c"))
+	require.Equal(t, "a/b~c", Unescape("a~1b~0c"))
+	require.Equal(t, "/paths/~1users~1{id}/get", Join("/paths", "/users/{id}", "get"))
+}
+
+func TestGet(t *testing.T) {
+	doc := map[string]interface{}{
+		"a/b": []interface{}{"x", map[string]interface{}{"c": 1}},
+	}
+
+	v, ok := Get(doc, "")
+	require.True(t, ok)
+	require.Equal(t, doc, v)
+
+	v, ok = Get(doc, "/a~1b/1/c")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	for _, pointer := range []string{"a", "/missing", "/a~1b/2", "/a~1b/x", "/a~1b/0/c"} {
+		_, ok = Get(doc, pointer)
+		require.False(t, ok, pointer)
+	}
+}
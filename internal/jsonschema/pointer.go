@@ -0,0 +1,17 @@
+package jsonschema
+
+import (
+	"strconv"
+)
+
+// displayLocation returns the printable form of a JSON pointer, the empty pointer (the root) will be printed as "/".
+func displayLocation(pointer string) string {
+	if pointer == "" {
+		return "/"
+	}
+	return pointer
+}
+
+func itoa(i int) string {
+	return strconv.Itoa(i)
+}
@@ -0,0 +1,390 @@
+// Package jsonschema implements a validator for the JSON Schema draft 2020-12 core, applicator, unevaluated and
+// validation vocabularies.
+// The format keyword is treated as an annotation, regular expressions use the go regexp syntax.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/jsonpointer"
+)
+
+// Schema is a compiled JSON Schema.
+// A Schema must not be used concurrently, because referenced schemas will be loaded during the validation.
+type Schema struct {
+	root     interface{}
+	rootBase string
+
+	// resources holds all schema resources, the key is the absolute uri (without fragment)
+	resources map[string]interface{}
+	// anchors holds all $anchor and $dynamicAnchor schemas, the key is the absolute uri including the fragment
+	anchors map[string]interface{}
+	// dynamicAnchors holds all uris that were defined by $dynamicAnchor
+	dynamicAnchors map[string]bool
+	// bases holds the base uri for every schema object
+	bases   map[uintptr]string
+	regexps map[string]*regexp.Regexp
+}
+
+// New creates a Schema from the specified value.
+// schema can be
+//     a string or []byte containing the JSON schema,
+//     a string containing the path to a JSON schema file,
+//     any other value that can be marshaled to JSON (e.g. map[string]interface{}).
+//
+// Relative references ($ref) to other files will be resolved relative to the schema file, or relative to the current
+// working directory if the schema was not loaded from a file.
+func New(schema interface{}) (*Schema, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	base := fileURI(filepath.Join(wd, "schema.json"))
+
+	var data []byte
+	switch v := schema.(type) {
+	case []byte:
+		data = v
+	case string:
+		if isInlineJSON(v) {
+			data = []byte(v)
+			break
+		}
+		path, err := filepath.Abs(v)
+		if err != nil {
+			return nil, err
+		}
+		data, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to read schema: %w", err)
+		}
+		base = fileURI(path)
+	default:
+		data, err = json.Marshal(schema)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to encode schema: %w", err)
+		}
+	}
+
+	v, err := decode(data)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to decode schema: %w", err)
+	}
+	return Compile(v, base)
+}
+
+// Compile compiles the specified decoded JSON schema, base is the absolute uri that will be used to resolve relative
+// references.
+func Compile(schema interface{}, base string) (*Schema, error) {
+	s := &Schema{
+		root:           schema,
+		rootBase:       base,
+		resources:      make(map[string]interface{}),
+		anchors:        make(map[string]interface{}),
+		dynamicAnchors: make(map[string]bool),
+		bases:          make(map[uintptr]string),
+		regexps:        make(map[string]*regexp.Regexp),
+	}
+	if err := s.addResource(schema, base); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CompileDocument compiles the schemas at the specified JSON pointers of a document that is not a schema itself
+// (e.g. an OpenAPI document), base is the absolute uri of the document.
+// Use ValidatePointer to validate an instance against one of the schemas.
+func CompileDocument(doc interface{}, base string, pointers ...string) (*Schema, error) {
+	s := &Schema{
+		root:           doc,
+		rootBase:       base,
+		resources:      make(map[string]interface{}),
+		anchors:        make(map[string]interface{}),
+		dynamicAnchors: make(map[string]bool),
+		bases:          make(map[uintptr]string),
+		regexps:        make(map[string]*regexp.Regexp),
+	}
+	base = stripFragment(base)
+	s.resources[base] = doc
+	for _, pointer := range pointers {
+		schema, ok := jsonpointer.Get(doc, pointer)
+		if !ok {
+			return nil, xerrors.Errorf("invalid schema at %s: pointer not found", displayLocation(pointer))
+		}
+		if err := s.index(schema, base, pointer); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Decode decodes the specified JSON data into the representation that is used by the validator.
+func Decode(data []byte) (interface{}, error) {
+	return decode(data)
+}
+
+func isInlineJSON(s string) bool {
+	s = strings.TrimSpace(s)
+	return strings.HasPrefix(s, "{") || s == "true" || s == "false"
+}
+
+func fileURI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasPrefix(u.Path, "/") {
+		// windows paths
+		u.Path = "/" + u.Path
+	}
+	return u.String()
+}
+
+// decode decodes the specified JSON data, numbers will be decoded as json.Number.
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, xerrors.New("unexpected data after the top-level value")
+	}
+	return v, nil
+}
+
+// normalize converts the specified value into the representation that is used by the validator.
+func normalize(v interface{}) (interface{}, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decode(buf)
+}
+
+func identity(m map[string]interface{}) uintptr {
+	return reflect.ValueOf(m).Pointer()
+}
+
+// addResource adds the specified schema resource that was retrieved from base.
+func (s *Schema) addResource(schema interface{}, base string) error {
+	base = stripFragment(base)
+	s.resources[base] = schema
+	return s.index(schema, base, "")
+}
+
+// index walks the schema and records all resources, anchors, base uris and regular expressions.
+//
+//nolint:gocognit // the keywords are easier to follow in one function
+func (s *Schema) index(schema interface{}, base, location string) error {
+	switch schema.(type) {
+	case bool:
+		return nil
+	case map[string]interface{}:
+	default:
+		return xerrors.Errorf("invalid schema at %s: schema must be an object or a boolean", displayLocation(location))
+	}
+	m := schema.(map[string]interface{})
+
+	if id, ok := m["$id"].(string); ok {
+		uri, err := resolveURI(base, id)
+		if err != nil {
+			return xerrors.Errorf("invalid $id at %s: %w", displayLocation(location), err)
+		}
+		base = stripFragment(uri)
+		s.resources[base] = m
+	}
+	s.bases[identity(m)] = base
+
+	if anchor, ok := m["$anchor"].(string); ok {
+		s.anchors[base+"#"+anchor] = m
+	}
+	if anchor, ok := m["$dynamicAnchor"].(string); ok {
+		s.anchors[base+"#"+anchor] = m
+		s.dynamicAnchors[base+"#"+anchor] = true
+	}
+
+	if pattern, ok := m["pattern"].(string); ok {
+		if err := s.compileRegexp(pattern, location+"/pattern"); err != nil {
+			return err
+		}
+	}
+
+	for _, keyword := range []string{
+		"not", "if", "then", "else", "items", "contains", "additionalProperties", "propertyNames",
+		"unevaluatedItems", "unevaluatedProperties", "contentSchema",
+	} {
+		if v, ok := m[keyword]; ok {
+			if err := s.index(v, base, location+"/"+keyword); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, keyword := range []string{"$defs", "definitions", "properties", "patternProperties", "dependentSchemas"} {
+		v, ok := m[keyword]
+		if !ok {
+			continue
+		}
+		children, ok := v.(map[string]interface{})
+		if !ok {
+			return xerrors.Errorf("invalid schema at %s: value must be an object", displayLocation(location+"/"+keyword))
+		}
+		for name, child := range children {
+			if keyword == "patternProperties" {
+				if err := s.compileRegexp(name, location+"/"+keyword); err != nil {
+					return err
+				}
+			}
+			if err := s.index(child, base, location+"/"+keyword+"/"+jsonpointer.Escape(name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		v, ok := m[keyword]
+		if !ok {
+			continue
+		}
+		children, ok := v.([]interface{})
+		if !ok || len(children) == 0 {
+			return xerrors.Errorf("invalid schema at %s: value must be a non-empty array", displayLocation(location+"/"+keyword))
+		}
+		for i, child := range children {
+			if err := s.index(child, base, location+"/"+keyword+"/"+itoa(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compileRegexp(pattern, location string) error {
+	if _, ok := s.regexps[pattern]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return xerrors.Errorf("invalid pattern at %s: %w", displayLocation(location), err)
+	}
+	s.regexps[pattern] = re
+	return nil
+}
+
+// resolve resolves the specified reference relative to base, it returns the target schema and its base uri.
+func (s *Schema) resolve(base, ref string) (interface{}, string, error) {
+	uri, err := resolveURI(base, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	resourceURI, fragment := splitFragment(uri)
+
+	resource, ok := s.resources[resourceURI]
+	if !ok {
+		resource, err = s.load(resourceURI)
+		if err != nil {
+			return nil, "", xerrors.Errorf("unable to resolve %q: %w", ref, err)
+		}
+	}
+
+	if fragment == "" {
+		return resource, s.baseOf(resource, resourceURI), nil
+	}
+
+	if !strings.HasPrefix(fragment, "/") {
+		target, ok := s.anchors[resourceURI+"#"+fragment]
+		if !ok {
+			return nil, "", xerrors.Errorf("unable to resolve %q: anchor not found", ref)
+		}
+		return target, s.baseOf(target, resourceURI), nil
+	}
+
+	target, ok := jsonpointer.Get(resource, fragment)
+	if !ok {
+		return nil, "", xerrors.Errorf("unable to resolve %q: pointer not found", ref)
+	}
+	if m, ok := target.(map[string]interface{}); ok {
+		if _, indexed := s.bases[identity(m)]; !indexed {
+			// the schema is part of a document that was not indexed yet (e.g. the components of an OpenAPI document)
+			if err := s.index(m, resourceURI, fragment); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	return target, s.baseOf(target, resourceURI), nil
+}
+
+func (s *Schema) baseOf(schema interface{}, fallback string) string {
+	if m, ok := schema.(map[string]interface{}); ok {
+		if base, ok := s.bases[identity(m)]; ok {
+			return base
+		}
+	}
+	return fallback
+}
+
+// load loads the schema resource with the specified uri, only file uris are supported.
+func (s *Schema) load(uri string) (interface{}, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "file" {
+		return nil, xerrors.Errorf("unable to load %s: only local files can be referenced", uri)
+	}
+	path := u.Path
+	if runtime.GOOS == "windows" {
+		path = strings.TrimPrefix(path, "/")
+	}
+	data, err := ioutil.ReadFile(filepath.FromSlash(path))
+	if err != nil {
+		return nil, err
+	}
+	v, err := decode(data)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to decode %s: %w", uri, err)
+	}
+	if err := s.addResource(v, uri); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func resolveURI(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
+
+func splitFragment(uri string) (string, string) {
+	i := strings.IndexRune(uri, '#')
+	if i < 0 {
+		return uri, ""
+	}
+	fragment, err := url.PathUnescape(uri[i+1:])
+	if err != nil {
+		fragment = uri[i+1:]
+	}
+	return uri[:i], fragment
+}
+
+func stripFragment(uri string) string {
+	s, _ := splitFragment(uri)
+	return s
+}
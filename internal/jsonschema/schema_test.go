@@ -0,0 +1,252 @@
+package jsonschema
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustValidate(t *testing.T, schema, instance string) []string {
+	s, err := New(schema)
+	require.NoError(t, err)
+	v, err := decode([]byte(instance))
+	require.NoError(t, err)
+	errs, err := s.Validate(v)
+	require.NoError(t, err)
+	result := make([]string, len(errs))
+	for i := range errs {
+		result[i] = errs[i].String()
+	}
+	return result
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Schema   string
+		Instance string
+		Errors   []string
+	}{
+		{"true schema", `true`, `1`, []string{}},
+		{"false schema", `false`, `1`, []string{`/: /: no value is allowed`}},
+
+		// type
+		{"type", `{"type": "string"}`, `"Joe"`, []string{}},
+		{"type mismatch", `{"type": "string"}`, `10`, []string{`/: /type: expected string, but got integer`}},
+		{"integer is a number", `{"type": "number"}`, `10`, []string{}},
+		{"1.0 is an integer", `{"type": "integer"}`, `1.0`, []string{}},
+		{"multiple types", `{"type": ["string", "null"]}`, `true`, []string{`/: /type: expected string or null, but got boolean`}},
+
+		// enum and const
+		{"enum", `{"enum": [1, "a", {"b": [1]}]}`, `{"b": [1.0]}`, []string{}},
+		{"enum mismatch", `{"enum": [1, "a"]}`, `"b"`, []string{`/: /enum: value must be one of [1,"a"]`}},
+		{"const", `{"const": {"a": 1}}`, `{"a": 2}`, []string{`/: /const: value must be {"a":1}`}},
+
+		// numbers
+		{"multipleOf", `{"multipleOf": 0.01}`, `19.99`, []string{}},
+		{"multipleOf mismatch", `{"multipleOf": 2}`, `7`, []string{`/: /multipleOf: 7 is not a multiple of 2`}},
+		{
+			"limits", `{"minimum": 2, "maximum": 4, "exclusiveMinimum": 2, "exclusiveMaximum": 4}`, `2`,
+			[]string{`/: /exclusiveMinimum: 2 is less than or equal to the exclusive minimum of 2`},
+		},
+		{"maximum", `{"maximum": 4}`, `4.5`, []string{`/: /maximum: 4.5 is greater than the maximum of 4`}},
+
+		// strings
+		{"length in code points", `{"maxLength": 5}`, `"Grüße"`, []string{}},
+		{"minLength", `{"minLength": 4}`, `"Joe"`, []string{`/: /minLength: length 3 is less than the minimum of 4`}},
+		{"pattern", `{"pattern": "^[A-Z]"}`, `"joe"`, []string{`/: /pattern: "joe" does not match the pattern "^[A-Z]"`}},
+		{"format is an annotation", `{"format": "email"}`, `"joe"`, []string{}},
+
+		// arrays
+		{"items", `{"items": {"type": "string"}}`, `["a", 1]`, []string{`/1: /items/type: expected string, but got integer`}},
+		{
+			"prefixItems", `{"prefixItems": [{"type": "integer"}, {"type": "string"}], "items": false}`, `[1, "a", true]`,
+			[]string{`/2: /items: additional item is not allowed`},
+		},
+		{"minItems", `{"minItems": 2, "maxItems": 3}`, `[1]`, []string{`/: /minItems: 1 items, expected at least 2`}},
+		{"uniqueItems", `{"uniqueItems": true}`, `[1, 2, 1.0]`, []string{`/: /uniqueItems: items at index 0 and 2 are equal`}},
+		{"contains", `{"contains": {"type": "string"}}`, `[1, 2]`, []string{`/: /contains: expected at least 1 matching items, but found 0`}},
+		{
+			"maxContains", `{"contains": {"type": "string"}, "maxContains": 1}`, `["a", "b"]`,
+			[]string{`/: /maxContains: expected at most 1 matching items, but found 2`},
+		},
+		{"minContains 0", `{"contains": {"type": "string"}, "minContains": 0}`, `[1]`, []string{}},
+
+		// objects
+		{
+			"properties and required", `{"properties": {"ID": {"type": "integer"}}, "required": ["ID", "Name"]}`, `{"ID": "10"}`,
+			[]string{`/: /required: missing required property "Name"`, `/ID: /properties/ID/type: expected integer, but got string`},
+		},
+		{
+			"additionalProperties", `{"properties": {"a": true}, "patternProperties": {"^x-": true}, "additionalProperties": false}`,
+			`{"a": 1, "x-b": 2, "c": 3}`,
+			[]string{`/c: /additionalProperties: additional property "c" is not allowed`},
+		},
+		{
+			"propertyNames", `{"propertyNames": {"maxLength": 2}}`, `{"abc": 1}`,
+			[]string{`/abc: /propertyNames/maxLength: length 3 is greater than the maximum of 2`},
+		},
+		{
+			"dependentRequired", `{"dependentRequired": {"a": ["b"]}}`, `{"a": 1}`,
+			[]string{`/: /dependentRequired/a: property "b" is required when "a" is present`},
+		},
+		{
+			"dependentSchemas", `{"dependentSchemas": {"a": {"required": ["b"]}}}`, `{"a": 1}`,
+			[]string{`/: /dependentSchemas/a/required: missing required property "b"`},
+		},
+		{"minProperties", `{"minProperties": 1}`, `{}`, []string{`/: /minProperties: 0 properties, expected at least 1`}},
+		{
+			"escaped pointers", `{"properties": {"a/b~c": {"type": "string"}}}`, `{"a/b~c": 1}`,
+			[]string{`/a~1b~0c: /properties/a~1b~0c/type: expected string, but got integer`},
+		},
+
+		// applicators
+		{
+			"allOf", `{"allOf": [{"type": "integer"}, {"minimum": 5}]}`, `4`,
+			[]string{`/: /allOf/1/minimum: 4 is less than the minimum of 5`},
+		},
+		{"anyOf", `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`, `true`, []string{`/: /anyOf: value does not match any of the schemas`}},
+		{
+			"oneOf", `{"oneOf": [{"type": "integer"}, {"minimum": 2}]}`, `3`,
+			[]string{`/: /oneOf: value must match exactly one schema, but matches the schemas at index 0 and 1`},
+		},
+		{"not", `{"not": {"type": "string"}}`, `"a"`, []string{`/: /not: value must not match the schema`}},
+		{
+			"if then else", `{"if": {"type": "integer"}, "then": {"minimum": 1}, "else": {"type": "string"}}`, `0`,
+			[]string{`/: /then/minimum: 0 is less than the minimum of 1`},
+		},
+		{
+			"else", `{"if": {"type": "integer"}, "then": {"minimum": 1}, "else": {"type": "string"}}`, `true`,
+			[]string{`/: /else/type: expected string, but got boolean`},
+		},
+
+		// references
+		{
+			"$ref to $defs", `{"$defs": {"name": {"type": "string"}}, "properties": {"Name": {"$ref": "#/$defs/name"}}}`,
+			`{"Name": 1}`,
+			[]string{`/Name: /properties/Name/$ref/type: expected string, but got integer`},
+		},
+		{
+			"$anchor", `{"$defs": {"name": {"$anchor": "name", "type": "string"}}, "items": {"$ref": "#name"}}`, `[1]`,
+			[]string{`/0: /items/$ref/type: expected string, but got integer`},
+		},
+		{
+			"$id", `{"$id": "https://example.com/root.json", "$defs": {"a": {"$id": "a.json", "type": "string"}}, "$ref": "a.json"}`,
+			`1`,
+			[]string{`/: /$ref/type: expected string, but got integer`},
+		},
+		{
+			"recursive", `{"properties": {"Children": {"items": {"$ref": "#"}}, "Name": {"type": "string"}}}`,
+			`{"Name": "a", "Children": [{"Name": "b", "Children": [{"Name": 1}]}]}`,
+			[]string{`/Children/0/Children/0/Name: /properties/Children/items/$ref/properties/Children/items/$ref/properties/Name/type: expected string, but got integer`},
+		},
+		{
+			"$dynamicRef", `{
+				"$id": "https://example.com/strict-tree",
+				"$dynamicAnchor": "node",
+				"$ref": "tree",
+				"unevaluatedProperties": false,
+				"$defs": {
+					"tree": {
+						"$id": "tree",
+						"$dynamicAnchor": "node",
+						"type": "object",
+						"properties": {
+							"data": true,
+							"children": {"type": "array", "items": {"$dynamicRef": "#node"}}
+						}
+					}
+				}
+			}`,
+			`{"children": [{"daat": 1}]}`,
+			[]string{
+				`/children/0/daat: /$ref/properties/children/items/$dynamicRef/unevaluatedProperties: unevaluated property "daat" is not allowed`,
+				// annotations of failing subschemas are dropped
+				`/children: /unevaluatedProperties: unevaluated property "children" is not allowed`,
+			},
+		},
+
+		// unevaluated
+		{
+			"unevaluatedProperties", `{"allOf": [{"properties": {"a": true}}], "unevaluatedProperties": false}`, `{"a": 1, "b": 2}`,
+			[]string{`/b: /unevaluatedProperties: unevaluated property "b" is not allowed`},
+		},
+		{
+			"unevaluatedProperties ignores failed subschemas",
+			`{"anyOf": [{"properties": {"a": true}, "required": ["a"]}, {"properties": {"b": true}, "required": ["b"]}], "unevaluatedProperties": false}`,
+			`{"b": 1}`,
+			[]string{},
+		},
+		{
+			"unevaluatedItems", `{"prefixItems": [true], "contains": {"type": "string"}, "unevaluatedItems": false}`, `[1, "a", 2]`,
+			[]string{`/2: /unevaluatedItems: unevaluated item is not allowed`},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			require.Equal(t, test.Errors, mustValidate(t, test.Schema, test.Instance))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("go value", func(t *testing.T) {
+		s, err := New(map[string]interface{}{
+			"type":     "object",
+			"required": []string{"ID"},
+		})
+		require.NoError(t, err)
+		errs, err := s.Validate(map[string]interface{}{"Name": "Joe"})
+		require.NoError(t, err)
+		require.Len(t, errs, 1)
+		require.Equal(t, Error{
+			InstanceLocation: "",
+			KeywordLocation:  "/required",
+			Keyword:          "required",
+			Message:          `missing required property "ID"`,
+		}, errs[0])
+	})
+
+	t.Run("file with relative reference", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "jsonschema")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "user.json"), []byte(`{
+			"properties": {"Name": {"$ref": "defs.json#/$defs/name"}}
+		}`), 0o600))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "defs.json"), []byte(`{
+			"$defs": {"name": {"type": "string"}}
+		}`), 0o600))
+
+		s, err := New(filepath.Join(dir, "user.json"))
+		require.NoError(t, err)
+		errs, err := s.Validate(map[string]interface{}{"Name": 10})
+		require.NoError(t, err)
+		require.Len(t, errs, 1)
+		require.Equal(t, "/properties/Name/$ref/type", errs[0].KeywordLocation)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := New(`{"type": `)
+		require.Error(t, err)
+
+		_, err = New(`does-not-exist.json`)
+		require.Error(t, err)
+
+		_, err = New(`{"properties": {"a": 1}}`)
+		require.EqualError(t, err, "invalid schema at /properties/a: schema must be an object or a boolean")
+
+		_, err = New(`{"pattern": "("}`)
+		require.Error(t, err)
+
+		s, err := New(`{"$ref": "#/$defs/missing"}`)
+		require.NoError(t, err)
+		_, err = s.Validate(1)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,706 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/jsonpointer"
+)
+
+// maxDepth limits the number of nested references, to prevent endless loops in recursive schemas.
+const maxDepth = 256
+
+// Error describes a single violation of the schema.
+type Error struct {
+	// InstanceLocation is the JSON pointer to the value that violates the schema.
+	InstanceLocation string
+	// KeywordLocation is the JSON pointer to the violated keyword in the schema (following references).
+	KeywordLocation string
+	// Keyword is the violated keyword.
+	Keyword string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the string representation of the Error.
+func (e Error) String() string {
+	return fmt.Sprintf("%s: %s: %s", displayLocation(e.InstanceLocation), displayLocation(e.KeywordLocation), e.Message)
+}
+
+// Validate validates the specified instance against the schema and returns all violations.
+func (s *Schema) Validate(instance interface{}) ([]Error, error) {
+	v, err := normalize(instance)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &validation{schema: s}
+	r := ctx.validate(s.root, v, "", "", s.rootBase, nil, 0)
+	return r.errors, ctx.err
+}
+
+// ValidatePointer validates the specified instance against the schema at the JSON pointer in the document, the
+// keyword locations of the returned errors start with the pointer.
+func (s *Schema) ValidatePointer(pointer string, instance interface{}) ([]Error, error) {
+	schema, ok := jsonpointer.Get(s.root, pointer)
+	if !ok {
+		return nil, xerrors.Errorf("unable to find the schema %s", displayLocation(pointer))
+	}
+	v, err := normalize(instance)
+	if err != nil {
+		return nil, err
+	}
+	ctx := &validation{schema: s}
+	r := ctx.validate(schema, v, "", pointer, s.rootBase, nil, 0)
+	return r.errors, ctx.err
+}
+
+type validation struct {
+	schema *Schema
+	// err holds an error that occurred during validation (e.g. a reference could not be resolved)
+	err error
+}
+
+// result holds the errors and the annotations of one schema evaluation.
+type result struct {
+	errors []Error
+	// props holds the evaluated properties
+	props map[string]bool
+	// items is the number of evaluated items (from the beginning of the array)
+	items int
+	// allItems reports whether all items were evaluated
+	allItems bool
+	// indices holds the evaluated items that are not covered by items
+	indices map[int]bool
+}
+
+func (r *result) valid() bool {
+	return len(r.errors) == 0
+}
+
+func (r *result) fail(instanceLocation, keywordLocation, keyword, format string, a ...interface{}) {
+	r.errors = append(r.errors, Error{
+		InstanceLocation: instanceLocation,
+		KeywordLocation:  keywordLocation,
+		Keyword:          keyword,
+		Message:          fmt.Sprintf(format, a...),
+	})
+}
+
+// add adds the errors of the specified result, the annotations are only merged if the result is valid.
+func (r *result) add(o *result) {
+	r.errors = append(r.errors, o.errors...)
+	if o.valid() {
+		r.merge(o)
+	}
+}
+
+func (r *result) merge(o *result) {
+	for name := range o.props {
+		r.evaluatedProp(name)
+	}
+	if o.items > r.items {
+		r.items = o.items
+	}
+	r.allItems = r.allItems || o.allItems
+	for i := range o.indices {
+		r.evaluatedIndex(i)
+	}
+}
+
+func (r *result) evaluatedProp(name string) {
+	if r.props == nil {
+		r.props = make(map[string]bool)
+	}
+	r.props[name] = true
+}
+
+func (r *result) evaluatedIndex(i int) {
+	if r.indices == nil {
+		r.indices = make(map[int]bool)
+	}
+	r.indices[i] = true
+}
+
+//nolint:funlen,gocognit,gocyclo // the keywords are easier to follow in one function
+func (ctx *validation) validate(
+	schema, instance interface{}, instanceLocation, keywordLocation, base string, scope []string, depth int,
+) *result {
+	r := &result{}
+	if depth > maxDepth {
+		r.fail(instanceLocation, keywordLocation, "$ref", "maximum reference depth exceeded")
+		return r
+	}
+
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		if b, ok := schema.(bool); ok && !b {
+			r.fail(instanceLocation, keywordLocation, "false", "no value is allowed")
+		}
+		return r
+	}
+
+	if b, ok := ctx.schema.bases[identity(m)]; ok {
+		base = b
+	}
+	if len(scope) == 0 || scope[len(scope)-1] != base {
+		scope = append(scope[:len(scope):len(scope)], base)
+	}
+
+	sub := func(s, inst interface{}, instLoc, keyLoc string) *result {
+		return ctx.validate(s, inst, instLoc, keyLoc, base, scope, depth)
+	}
+
+	if ref, ok := m["$ref"].(string); ok {
+		target, targetBase, err := ctx.schema.resolve(base, ref)
+		if err != nil {
+			ctx.setError(err)
+			r.fail(instanceLocation, keywordLocation+"/$ref", "$ref", "%s", err.Error())
+		} else {
+			r.add(ctx.validate(target, instance, instanceLocation, keywordLocation+"/$ref", targetBase, scope, depth+1))
+		}
+	}
+
+	if ref, ok := m["$dynamicRef"].(string); ok {
+		target, targetBase, err := ctx.resolveDynamic(base, ref, scope)
+		if err != nil {
+			ctx.setError(err)
+			r.fail(instanceLocation, keywordLocation+"/$dynamicRef", "$dynamicRef", "%s", err.Error())
+		} else {
+			r.add(ctx.validate(target, instance, instanceLocation, keywordLocation+"/$dynamicRef", targetBase, scope, depth+1))
+		}
+	}
+
+	// validation vocabulary for any instance type
+	if v, ok := m["type"]; ok {
+		ctx.validateType(r, v, instance, instanceLocation, keywordLocation+"/type")
+	}
+	if v, ok := m["enum"].([]interface{}); ok {
+		found := false
+		for _, e := range v {
+			if equal(e, instance) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.fail(instanceLocation, keywordLocation+"/enum", "enum", "value must be one of %s", jsonString(v))
+		}
+	}
+	if v, ok := m["const"]; ok && !equal(v, instance) {
+		r.fail(instanceLocation, keywordLocation+"/const", "const", "value must be %s", jsonString(v))
+	}
+
+	switch inst := instance.(type) {
+	case json.Number:
+		validateNumber(r, m, inst, instanceLocation, keywordLocation)
+	case string:
+		ctx.validateString(r, m, inst, instanceLocation, keywordLocation)
+	case []interface{}:
+		validateArrayLength(r, m, inst, instanceLocation, keywordLocation)
+	case map[string]interface{}:
+		validateObjectProperties(r, m, inst, instanceLocation, keywordLocation)
+	}
+
+	// applicator vocabulary
+	if v, ok := m["allOf"].([]interface{}); ok {
+		for i, s := range v {
+			r.add(sub(s, instance, instanceLocation, keywordLocation+"/allOf/"+itoa(i)))
+		}
+	}
+	if v, ok := m["anyOf"].([]interface{}); ok {
+		matched := false
+		for i, s := range v {
+			sr := sub(s, instance, instanceLocation, keywordLocation+"/anyOf/"+itoa(i))
+			if sr.valid() {
+				matched = true
+				r.merge(sr)
+			}
+		}
+		if !matched {
+			r.fail(instanceLocation, keywordLocation+"/anyOf", "anyOf", "value does not match any of the schemas")
+		}
+	}
+	if v, ok := m["oneOf"].([]interface{}); ok {
+		var matches []int
+		for i, s := range v {
+			sr := sub(s, instance, instanceLocation, keywordLocation+"/oneOf/"+itoa(i))
+			if sr.valid() {
+				matches = append(matches, i)
+				r.merge(sr)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			r.fail(instanceLocation, keywordLocation+"/oneOf", "oneOf", "value does not match any of the schemas")
+		case 1:
+		default:
+			r.fail(instanceLocation, keywordLocation+"/oneOf", "oneOf",
+				"value must match exactly one schema, but matches the schemas at index %d and %d", matches[0], matches[1])
+		}
+	}
+	if v, ok := m["not"]; ok {
+		if sub(v, instance, instanceLocation, keywordLocation+"/not").valid() {
+			r.fail(instanceLocation, keywordLocation+"/not", "not", "value must not match the schema")
+		}
+	}
+	if v, ok := m["if"]; ok {
+		ir := sub(v, instance, instanceLocation, keywordLocation+"/if")
+		if ir.valid() {
+			r.merge(ir)
+			if then, ok := m["then"]; ok {
+				r.add(sub(then, instance, instanceLocation, keywordLocation+"/then"))
+			}
+		} else if els, ok := m["else"]; ok {
+			r.add(sub(els, instance, instanceLocation, keywordLocation+"/else"))
+		}
+	}
+
+	switch inst := instance.(type) {
+	case []interface{}:
+		ctx.validateArray(r, m, inst, instanceLocation, keywordLocation, sub)
+	case map[string]interface{}:
+		ctx.validateObject(r, m, inst, instanceLocation, keywordLocation, sub)
+	}
+
+	// unevaluated vocabulary, must be evaluated after all other keywords
+	if v, ok := m["unevaluatedItems"]; ok {
+		if arr, ok := instance.([]interface{}); ok && !r.allItems {
+			for i := r.items; i < len(arr); i++ {
+				if r.indices[i] {
+					continue
+				}
+				if isFalse(v) {
+					r.fail(instanceLocation+"/"+itoa(i), keywordLocation+"/unevaluatedItems", "unevaluatedItems",
+						"unevaluated item is not allowed")
+					continue
+				}
+				r.add(sub(v, arr[i], instanceLocation+"/"+itoa(i), keywordLocation+"/unevaluatedItems"))
+			}
+			r.allItems = true
+		}
+	}
+	if v, ok := m["unevaluatedProperties"]; ok {
+		if obj, ok := instance.(map[string]interface{}); ok {
+			for _, name := range sortedKeys(obj) {
+				if r.props[name] {
+					continue
+				}
+				loc := instanceLocation + "/" + jsonpointer.Escape(name)
+				if isFalse(v) {
+					r.fail(loc, keywordLocation+"/unevaluatedProperties", "unevaluatedProperties",
+						"unevaluated property %q is not allowed", name)
+					continue
+				}
+				r.add(sub(v, obj[name], loc, keywordLocation+"/unevaluatedProperties"))
+			}
+			for name := range obj {
+				r.evaluatedProp(name)
+			}
+		}
+	}
+
+	return r
+}
+
+func (ctx *validation) setError(err error) {
+	if ctx.err == nil {
+		ctx.err = err
+	}
+}
+
+// resolveDynamic resolves a $dynamicRef, if the statically resolved schema has a matching $dynamicAnchor the
+// outermost schema resource in the dynamic scope that defines the same $dynamicAnchor will be used.
+func (ctx *validation) resolveDynamic(base, ref string, scope []string) (interface{}, string, error) {
+	target, targetBase, err := ctx.schema.resolve(base, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	uri, err := resolveURI(base, ref)
+	if err != nil {
+		return nil, "", err
+	}
+	resourceURI, fragment := splitFragment(uri)
+	if fragment == "" || strings.HasPrefix(fragment, "/") || !ctx.schema.dynamicAnchors[resourceURI+"#"+fragment] {
+		return target, targetBase, nil
+	}
+	for _, s := range scope {
+		if ctx.schema.dynamicAnchors[s+"#"+fragment] {
+			dynamicTarget := ctx.schema.anchors[s+"#"+fragment]
+			return dynamicTarget, ctx.schema.baseOf(dynamicTarget, s), nil
+		}
+	}
+	return target, targetBase, nil
+}
+
+func (ctx *validation) validateType(r *result, v, instance interface{}, instanceLocation, keywordLocation string) {
+	var types []string
+	switch t := v.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, e := range t {
+			if s, ok := e.(string); ok {
+				types = append(types, s)
+			}
+		}
+	}
+	actual := typeOf(instance)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return
+		}
+	}
+	r.fail(instanceLocation, keywordLocation, "type", "expected %s, but got %s", strings.Join(types, " or "), actual)
+}
+
+func validateNumber(r *result, m map[string]interface{}, n json.Number, instanceLocation, keywordLocation string) {
+	value, ok := toRat(n)
+	if !ok {
+		return
+	}
+	limit := func(keyword string) (*big.Rat, bool) {
+		v, ok := m[keyword].(json.Number)
+		if !ok {
+			return nil, false
+		}
+		return toRat(v)
+	}
+
+	if d, ok := limit("multipleOf"); ok && d.Sign() > 0 {
+		if !new(big.Rat).Quo(value, d).IsInt() {
+			r.fail(instanceLocation, keywordLocation+"/multipleOf", "multipleOf", "%s is not a multiple of %s", n, m["multipleOf"])
+		}
+	}
+	if l, ok := limit("maximum"); ok && value.Cmp(l) > 0 {
+		r.fail(instanceLocation, keywordLocation+"/maximum", "maximum", "%s is greater than the maximum of %s", n, m["maximum"])
+	}
+	if l, ok := limit("exclusiveMaximum"); ok && value.Cmp(l) >= 0 {
+		r.fail(instanceLocation, keywordLocation+"/exclusiveMaximum", "exclusiveMaximum",
+			"%s is greater than or equal to the exclusive maximum of %s", n, m["exclusiveMaximum"])
+	}
+	if l, ok := limit("minimum"); ok && value.Cmp(l) < 0 {
+		r.fail(instanceLocation, keywordLocation+"/minimum", "minimum", "%s is less than the minimum of %s", n, m["minimum"])
+	}
+	if l, ok := limit("exclusiveMinimum"); ok && value.Cmp(l) <= 0 {
+		r.fail(instanceLocation, keywordLocation+"/exclusiveMinimum", "exclusiveMinimum",
+			"%s is less than or equal to the exclusive minimum of %s", n, m["exclusiveMinimum"])
+	}
+}
+
+func (ctx *validation) validateString(
+	r *result, m map[string]interface{}, s string, instanceLocation, keywordLocation string,
+) {
+	length := utf8.RuneCountInString(s)
+	if l, ok := intValue(m["maxLength"]); ok && length > l {
+		r.fail(instanceLocation, keywordLocation+"/maxLength", "maxLength", "length %d is greater than the maximum of %d", length, l)
+	}
+	if l, ok := intValue(m["minLength"]); ok && length < l {
+		r.fail(instanceLocation, keywordLocation+"/minLength", "minLength", "length %d is less than the minimum of %d", length, l)
+	}
+	if pattern, ok := m["pattern"].(string); ok {
+		if re := ctx.schema.regexps[pattern]; re != nil && !re.MatchString(s) {
+			r.fail(instanceLocation, keywordLocation+"/pattern", "pattern", "%q does not match the pattern %q", s, pattern)
+		}
+	}
+}
+
+func validateArrayLength(r *result, m map[string]interface{}, arr []interface{}, instanceLocation, keywordLocation string) {
+	if l, ok := intValue(m["maxItems"]); ok && len(arr) > l {
+		r.fail(instanceLocation, keywordLocation+"/maxItems", "maxItems", "%d items, expected at most %d", len(arr), l)
+	}
+	if l, ok := intValue(m["minItems"]); ok && len(arr) < l {
+		r.fail(instanceLocation, keywordLocation+"/minItems", "minItems", "%d items, expected at least %d", len(arr), l)
+	}
+	if unique, ok := m["uniqueItems"].(bool); ok && unique {
+		for i := 0; i < len(arr); i++ {
+			for j := i + 1; j < len(arr); j++ {
+				if equal(arr[i], arr[j]) {
+					r.fail(instanceLocation, keywordLocation+"/uniqueItems", "uniqueItems", "items at index %d and %d are equal", i, j)
+					return
+				}
+			}
+		}
+	}
+}
+
+func validateObjectProperties(
+	r *result, m map[string]interface{}, obj map[string]interface{}, instanceLocation, keywordLocation string,
+) {
+	if l, ok := intValue(m["maxProperties"]); ok && len(obj) > l {
+		r.fail(instanceLocation, keywordLocation+"/maxProperties", "maxProperties", "%d properties, expected at most %d", len(obj), l)
+	}
+	if l, ok := intValue(m["minProperties"]); ok && len(obj) < l {
+		r.fail(instanceLocation, keywordLocation+"/minProperties", "minProperties", "%d properties, expected at least %d", len(obj), l)
+	}
+	if required, ok := m["required"].([]interface{}); ok {
+		for _, v := range required {
+			name, ok := v.(string)
+			if !ok {
+				continue
+			}
+			if _, ok := obj[name]; !ok {
+				r.fail(instanceLocation, keywordLocation+"/required", "required", "missing required property %q", name)
+			}
+		}
+	}
+	if dependentRequired, ok := m["dependentRequired"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(dependentRequired) {
+			if _, ok := obj[name]; !ok {
+				continue
+			}
+			required, _ := dependentRequired[name].([]interface{})
+			for _, v := range required {
+				dependency, ok := v.(string)
+				if !ok {
+					continue
+				}
+				if _, ok := obj[dependency]; !ok {
+					r.fail(instanceLocation, keywordLocation+"/dependentRequired/"+jsonpointer.Escape(name), "dependentRequired",
+						"property %q is required when %q is present", dependency, name)
+				}
+			}
+		}
+	}
+}
+
+type subValidator func(schema, instance interface{}, instanceLocation, keywordLocation string) *result
+
+func (ctx *validation) validateArray(
+	r *result, m map[string]interface{}, arr []interface{}, instanceLocation, keywordLocation string, sub subValidator,
+) {
+	prefixItems, _ := m["prefixItems"].([]interface{})
+	for i := 0; i < len(prefixItems) && i < len(arr); i++ {
+		r.add(sub(prefixItems[i], arr[i], instanceLocation+"/"+itoa(i), keywordLocation+"/prefixItems/"+itoa(i)))
+	}
+	if len(prefixItems) > 0 {
+		if len(prefixItems) >= len(arr) {
+			r.allItems = true
+		} else if len(prefixItems) > r.items {
+			r.items = len(prefixItems)
+		}
+	}
+
+	if items, ok := m["items"]; ok {
+		for i := len(prefixItems); i < len(arr); i++ {
+			if isFalse(items) {
+				r.fail(instanceLocation+"/"+itoa(i), keywordLocation+"/items", "items", "additional item is not allowed")
+				continue
+			}
+			r.add(sub(items, arr[i], instanceLocation+"/"+itoa(i), keywordLocation+"/items"))
+		}
+		r.allItems = true
+	}
+
+	if contains, ok := m["contains"]; ok {
+		var matched []int
+		for i, item := range arr {
+			if sub(contains, item, instanceLocation+"/"+itoa(i), keywordLocation+"/contains").valid() {
+				matched = append(matched, i)
+			}
+		}
+
+		minContains, ok := intValue(m["minContains"])
+		if !ok {
+			minContains = 1
+		}
+		switch {
+		case len(matched) < minContains:
+			keyword := "contains"
+			if _, ok := m["minContains"]; ok {
+				keyword = "minContains"
+			}
+			r.fail(instanceLocation, keywordLocation+"/"+keyword, keyword,
+				"expected at least %d matching items, but found %d", minContains, len(matched))
+		default:
+			if maxContains, ok := intValue(m["maxContains"]); ok && len(matched) > maxContains {
+				r.fail(instanceLocation, keywordLocation+"/maxContains", "maxContains",
+					"expected at most %d matching items, but found %d", maxContains, len(matched))
+			}
+		}
+		for _, i := range matched {
+			r.evaluatedIndex(i)
+		}
+	}
+}
+
+//nolint:gocognit // the keywords are easier to follow in one function
+func (ctx *validation) validateObject(
+	r *result, m map[string]interface{}, obj map[string]interface{}, instanceLocation, keywordLocation string,
+	sub subValidator,
+) {
+	evaluated := make(map[string]bool)
+
+	if properties, ok := m["properties"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(properties) {
+			value, ok := obj[name]
+			if !ok {
+				continue
+			}
+			evaluated[name] = true
+			r.add(sub(properties[name], value,
+				instanceLocation+"/"+jsonpointer.Escape(name), keywordLocation+"/properties/"+jsonpointer.Escape(name)))
+		}
+	}
+
+	if patternProperties, ok := m["patternProperties"].(map[string]interface{}); ok {
+		for _, pattern := range sortedKeys(patternProperties) {
+			re := ctx.schema.regexps[pattern]
+			if re == nil {
+				continue
+			}
+			for _, name := range sortedKeys(obj) {
+				if !re.MatchString(name) {
+					continue
+				}
+				evaluated[name] = true
+				r.add(sub(patternProperties[pattern], obj[name],
+					instanceLocation+"/"+jsonpointer.Escape(name), keywordLocation+"/patternProperties/"+jsonpointer.Escape(pattern)))
+			}
+		}
+	}
+
+	if additional, ok := m["additionalProperties"]; ok {
+		for _, name := range sortedKeys(obj) {
+			if evaluated[name] {
+				continue
+			}
+			loc := instanceLocation + "/" + jsonpointer.Escape(name)
+			if isFalse(additional) {
+				r.fail(loc, keywordLocation+"/additionalProperties", "additionalProperties",
+					"additional property %q is not allowed", name)
+				continue
+			}
+			r.add(sub(additional, obj[name], loc, keywordLocation+"/additionalProperties"))
+		}
+		for name := range obj {
+			evaluated[name] = true
+		}
+	}
+
+	if propertyNames, ok := m["propertyNames"]; ok {
+		for _, name := range sortedKeys(obj) {
+			r.add(sub(propertyNames, name, instanceLocation+"/"+jsonpointer.Escape(name), keywordLocation+"/propertyNames"))
+		}
+	}
+
+	if dependentSchemas, ok := m["dependentSchemas"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(dependentSchemas) {
+			if _, ok := obj[name]; !ok {
+				continue
+			}
+			r.add(sub(dependentSchemas[name], obj, instanceLocation, keywordLocation+"/dependentSchemas/"+jsonpointer.Escape(name)))
+		}
+	}
+
+	for name := range evaluated {
+		r.evaluatedProp(name)
+	}
+}
+
+func isFalse(schema interface{}) bool {
+	b, ok := schema.(bool)
+	return ok && !b
+}
+
+func typeOf(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if r, ok := toRat(t); ok && r.IsInt() {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func toRat(n json.Number) (*big.Rat, bool) {
+	return new(big.Rat).SetString(n.String())
+}
+
+func intValue(v interface{}) (int, bool) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, false
+	}
+	r, ok := toRat(n)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, false
+	}
+	return int(r.Num().Int64()), true
+}
+
+// equal reports whether both JSON values are equal, numbers are compared by their value.
+func equal(a, b interface{}) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		rx, okx := toRat(x)
+		ry, oky := toRat(y)
+		if !okx || !oky {
+			return x == y
+		}
+		return rx.Cmp(ry) == 0
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equal(v, w) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonString(v interface{}) string {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(buf)
+}
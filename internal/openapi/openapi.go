@@ -0,0 +1,512 @@
+// Package openapi validates http requests and responses against the operations of an OpenAPI 3 document.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/Eun/go-hit/internal/jsonpointer"
+	"github.com/Eun/go-hit/internal/jsonschema"
+)
+
+// methods lists the operation methods of a path item in the order they will be reported.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Document is a loaded OpenAPI 3 document.
+type Document struct {
+	// mu guards the schema, because referenced schemas will be loaded during the validation
+	mu     sync.Mutex
+	raw    map[string]interface{}
+	schema *jsonschema.Schema
+
+	prefixes   []*regexp.Regexp
+	operations []*Operation
+}
+
+// Operation is an operation of the document.
+type Operation struct {
+	// ID is the operationId, it is empty if the operation has no operationId.
+	ID string
+	// Method is the http method in upper case.
+	Method string
+	// Path is the path template of the operation.
+	Path string
+	// Pointer is the JSON pointer to the operation in the document.
+	Pointer string
+	// Raw is the (dereferenced) operation object.
+	Raw map[string]interface{}
+
+	re         *regexp.Regexp
+	variables  []string
+	parameters []*Parameter
+}
+
+// Parameter is a parameter of an operation.
+type Parameter struct {
+	Name     string
+	In       string
+	Required bool
+	Explode  bool
+	// Pointer is the JSON pointer to the parameter in the document.
+	Pointer string
+	// Schema is the JSON pointer to the schema of the parameter, it is empty if the parameter has no schema.
+	Schema string
+}
+
+// Parameters returns the parameters of the operation (including the parameters of the path item).
+func (op *Operation) Parameters() []*Parameter {
+	return op.parameters
+}
+
+// String returns a short description of the operation.
+func (op *Operation) String() string {
+	if op.ID != "" {
+		return fmt.Sprintf("%q (%s %s)", op.ID, op.Method, op.Path)
+	}
+	return fmt.Sprintf("%s %s", op.Method, op.Path)
+}
+
+// Load loads the OpenAPI document, spec can be a path to a YAML or JSON file (string) or the content of the
+// document ([]byte).
+func Load(spec interface{}) (*Document, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	base := fileURI(filepath.Join(wd, "openapi.json"))
+
+	var data []byte
+	switch v := spec.(type) {
+	case string:
+		path, err := filepath.Abs(v)
+		if err != nil {
+			return nil, err
+		}
+		data, err = ioutil.ReadFile(path)
+		if err != nil {
+			return nil, xerrors.Errorf("unable to read the OpenAPI document: %w", err)
+		}
+		base = fileURI(path)
+	case []byte:
+		data = v
+	default:
+		return nil, xerrors.Errorf("OpenAPI document must be a path (string) or []byte, got %T", spec)
+	}
+	return parse(data, base)
+}
+
+func fileURI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasPrefix(u.Path, "/") {
+		// windows paths
+		u.Path = "/" + u.Path
+	}
+	return u.String()
+}
+
+func parse(data []byte, base string) (*Document, error) {
+	var v interface{}
+	// yaml is a superset of json, so we can decode both formats
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return nil, xerrors.Errorf("unable to decode the OpenAPI document: %w", err)
+	}
+	buf, err := json.Marshal(stringKeys(v))
+	if err != nil {
+		return nil, xerrors.Errorf("unable to decode the OpenAPI document: %w", err)
+	}
+	decoded, err := jsonschema.Decode(buf)
+	if err != nil {
+		return nil, xerrors.Errorf("unable to decode the OpenAPI document: %w", err)
+	}
+
+	raw, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, xerrors.New("OpenAPI document must be an object")
+	}
+	version, _ := raw["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, xerrors.Errorf("unsupported OpenAPI version %q, only OpenAPI 3 is supported", version)
+	}
+	if strings.HasPrefix(version, "3.0") {
+		convertSchemas30(raw)
+	}
+
+	d := &Document{raw: raw}
+	if err := d.parseServers(); err != nil {
+		return nil, err
+	}
+	pointers, err := d.parseOperations()
+	if err != nil {
+		return nil, err
+	}
+
+	d.schema, err = jsonschema.CompileDocument(raw, base, pointers...)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// stringKeys converts all map[interface{}]interface{} (yaml allows non string keys, e.g. status codes) into
+// map[string]interface{}.
+func stringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, v := range t {
+			m[fmt.Sprint(k)] = stringKeys(v)
+		}
+		return m
+	case map[string]interface{}:
+		for k, v := range t {
+			t[k] = stringKeys(v)
+		}
+		return t
+	case []interface{}:
+		for i, v := range t {
+			t[i] = stringKeys(v)
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// convertSchemas30 converts the OpenAPI 3.0 specific schema keywords (nullable and the boolean exclusiveMinimum and
+// exclusiveMaximum) into their JSON Schema 2020-12 equivalents.
+func convertSchemas30(v interface{}) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if nullable, ok := t["nullable"].(bool); ok {
+			delete(t, "nullable")
+			if typ, ok := t["type"].(string); ok && nullable {
+				t["type"] = []interface{}{typ, "null"}
+			}
+		}
+		for exclusive, limit := range map[string]string{"exclusiveMinimum": "minimum", "exclusiveMaximum": "maximum"} {
+			b, ok := t[exclusive].(bool)
+			if !ok {
+				continue
+			}
+			delete(t, exclusive)
+			if b {
+				if l, ok := t[limit]; ok {
+					t[exclusive] = l
+					delete(t, limit)
+				}
+			}
+		}
+		for _, child := range t {
+			convertSchemas30(child)
+		}
+	case []interface{}:
+		for _, child := range t {
+			convertSchemas30(child)
+		}
+	}
+}
+
+// deref follows local references ($ref) of OpenAPI objects, it returns the referenced object and its pointer.
+func (d *Document) deref(v interface{}, pointer string) (map[string]interface{}, string, error) {
+	for i := 0; i < 32; i++ {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, "", xerrors.Errorf("invalid object at %s", pointer)
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return m, pointer, nil
+		}
+		if !strings.HasPrefix(ref, "#") {
+			return nil, "", xerrors.Errorf("unable to resolve %q at %s: only local references are supported", ref, pointer)
+		}
+		fragment, err := url.PathUnescape(ref[1:])
+		if err != nil {
+			return nil, "", xerrors.Errorf("unable to resolve %q at %s: %w", ref, pointer, err)
+		}
+		v, ok = jsonpointer.Get(d.raw, fragment)
+		if !ok {
+			return nil, "", xerrors.Errorf("unable to resolve %q at %s", ref, pointer)
+		}
+		pointer = fragment
+	}
+	return nil, "", xerrors.Errorf("too many references at %s", pointer)
+}
+
+var templateVariable = regexp.MustCompile(`\{[^}/]+\}`)
+
+func (d *Document) parseServers() error {
+	servers, _ := d.raw["servers"].([]interface{})
+	for _, s := range servers {
+		m, _ := s.(map[string]interface{})
+		u, _ := m["url"].(string)
+		// only the path of the server url is relevant
+		if i := strings.Index(u, "://"); i >= 0 {
+			u = u[i+3:]
+			if j := strings.IndexRune(u, '/'); j >= 0 {
+				u = u[j:]
+			} else {
+				u = ""
+			}
+		}
+		u = strings.TrimSuffix(u, "/")
+		re, err := regexp.Compile("^" + templateRegexp(u, `[^/]*`))
+		if err != nil {
+			return xerrors.Errorf("invalid server url %q: %w", u, err)
+		}
+		d.prefixes = append(d.prefixes, re)
+	}
+	return nil
+}
+
+// templateRegexp converts the path template into a regular expression, variables will be replaced with the
+// specified expression.
+func templateRegexp(template, variable string) string {
+	var sb strings.Builder
+	last := 0
+	for _, loc := range templateVariable.FindAllStringIndex(template, -1) {
+		sb.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		sb.WriteString(variable)
+		last = loc[1]
+	}
+	sb.WriteString(regexp.QuoteMeta(template[last:]))
+	return sb.String()
+}
+
+// parseOperations parses all operations and returns the pointers to all schemas that are used by the operations.
+//
+//nolint:gocognit // the OpenAPI structure is easier to follow in one function
+func (d *Document) parseOperations() ([]string, error) {
+	var schemas []string
+	addSchema := func(parent map[string]interface{}, pointer string) {
+		if _, ok := parent["schema"]; ok {
+			schemas = append(schemas, pointer+"/schema")
+		}
+	}
+	addContent := func(parent map[string]interface{}, pointer string) {
+		content, _ := parent["content"].(map[string]interface{})
+		for mediaType, v := range content {
+			if m, ok := v.(map[string]interface{}); ok {
+				addSchema(m, jsonpointer.Join(pointer, "content", mediaType))
+			}
+		}
+	}
+
+	paths, _ := d.raw["paths"].(map[string]interface{})
+	for _, path := range sortedKeys(paths) {
+		item, itemPointer, err := d.deref(paths[path], jsonpointer.Join("/paths", path))
+		if err != nil {
+			return nil, err
+		}
+		re := regexp.MustCompile("^" + templateRegexp(path, `([^/]+)`) + "$")
+		var variables []string
+		for _, v := range templateVariable.FindAllString(path, -1) {
+			variables = append(variables, strings.Trim(v, "{}"))
+		}
+
+		common, err := d.parseParameters(item, itemPointer)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, method := range methods {
+			raw, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			op := &Operation{
+				Method:    strings.ToUpper(method),
+				Path:      path,
+				Pointer:   jsonpointer.Join(itemPointer, method),
+				Raw:       raw,
+				re:        re,
+				variables: variables,
+			}
+			op.ID, _ = raw["operationId"].(string)
+
+			params, err := d.parseParameters(raw, op.Pointer)
+			if err != nil {
+				return nil, err
+			}
+			op.parameters = mergeParameters(common, params)
+			for _, p := range op.parameters {
+				if p.Schema != "" {
+					schemas = append(schemas, p.Schema)
+				}
+			}
+
+			if v, ok := raw["requestBody"]; ok {
+				body, bodyPointer, err := d.deref(v, op.Pointer+"/requestBody")
+				if err != nil {
+					return nil, err
+				}
+				addContent(body, bodyPointer)
+			}
+
+			responses, _ := raw["responses"].(map[string]interface{})
+			for status, v := range responses {
+				res, resPointer, err := d.deref(v, jsonpointer.Join(op.Pointer, "responses", status))
+				if err != nil {
+					return nil, err
+				}
+				addContent(res, resPointer)
+				headers, _ := res["headers"].(map[string]interface{})
+				for name, v := range headers {
+					header, headerPointer, err := d.deref(v, jsonpointer.Join(resPointer, "headers", name))
+					if err != nil {
+						return nil, err
+					}
+					addSchema(header, headerPointer)
+				}
+			}
+			d.operations = append(d.operations, op)
+		}
+	}
+
+	// concrete paths must be matched before templated paths (e.g. /users/me before /users/{id})
+	sort.SliceStable(d.operations, func(i, j int) bool {
+		return len(d.operations[i].variables) < len(d.operations[j].variables)
+	})
+
+	return uniqueStrings(schemas), nil
+}
+
+func (d *Document) parseParameters(parent map[string]interface{}, pointer string) ([]*Parameter, error) {
+	list, _ := parent["parameters"].([]interface{})
+	params := make([]*Parameter, 0, len(list))
+	for i, v := range list {
+		raw, p, err := d.deref(v, jsonpointer.Join(pointer, "parameters", fmt.Sprint(i)))
+		if err != nil {
+			return nil, err
+		}
+		param := &Parameter{Pointer: p}
+		param.Name, _ = raw["name"].(string)
+		param.In, _ = raw["in"].(string)
+		param.Required, _ = raw["required"].(bool)
+		if param.In == "path" {
+			param.Required = true
+		}
+		style, _ := raw["style"].(string)
+		if style == "" && (param.In == "query" || param.In == "cookie") {
+			style = "form"
+		}
+		param.Explode = style == "form"
+		if explode, ok := raw["explode"].(bool); ok {
+			param.Explode = explode
+		}
+		if _, ok := raw["schema"]; ok {
+			param.Schema = p + "/schema"
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// mergeParameters merges the path item parameters with the operation parameters, operation parameters override path
+// item parameters with the same name and location.
+func mergeParameters(common, params []*Parameter) []*Parameter {
+	result := make([]*Parameter, 0, len(common)+len(params))
+	for _, c := range common {
+		overridden := false
+		for _, p := range params {
+			if strings.EqualFold(p.Name, c.Name) && p.In == c.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			result = append(result, c)
+		}
+	}
+	return append(result, params...)
+}
+
+// Operations returns all operations of the document.
+func (d *Document) Operations() []*Operation {
+	return d.operations
+}
+
+// Raw returns the decoded document.
+func (d *Document) Raw() map[string]interface{} {
+	return d.raw
+}
+
+// Lookup returns the object at the JSON pointer, local references ($ref) will be followed. It returns the object and
+// the pointer to the resolved object.
+func (d *Document) Lookup(pointer string) (map[string]interface{}, string, error) {
+	v, ok := jsonpointer.Get(d.raw, pointer)
+	if !ok {
+		return nil, "", xerrors.Errorf("unable to find %s", pointer)
+	}
+	return d.deref(v, pointer)
+}
+
+// FindOperation finds the operation for the specified method and path, it returns the operation and the values of
+// the path variables.
+func (d *Document) FindOperation(method, path string) (*Operation, map[string]string, error) {
+	candidates := []string{path}
+	for _, prefix := range d.prefixes {
+		if loc := prefix.FindStringIndex(path); loc != nil && loc[1] > 0 {
+			candidates = append([]string{path[loc[1]:]}, candidates...)
+		}
+	}
+
+	pathMatched := false
+	for _, candidate := range candidates {
+		for _, op := range d.operations {
+			matches := op.re.FindStringSubmatch(candidate)
+			if matches == nil {
+				continue
+			}
+			pathMatched = true
+			if op.Method != method {
+				continue
+			}
+			values := make(map[string]string, len(op.variables))
+			for i, name := range op.variables {
+				value, err := url.PathUnescape(matches[i+1])
+				if err != nil {
+					value = matches[i+1]
+				}
+				values[name] = value
+			}
+			return op, values, nil
+		}
+	}
+	if pathMatched {
+		return nil, nil, xerrors.Errorf("method %s is not allowed for %s", method, path)
+	}
+	return nil, nil, xerrors.Errorf("no operation matches %s %s", method, path)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func uniqueStrings(s []string) []string {
+	sort.Strings(s)
+	result := s[:0]
+	for i, v := range s {
+		if i > 0 && s[i-1] == v {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,280 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const spec = `
+openapi: 3.0.3
+info:
+  title: Users
+  version: 1.0.0
+servers:
+  - url: https://example.com/v1
+paths:
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        schema:
+          type: integer
+    get:
+      operationId: getUser
+      parameters:
+        - name: fields
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [ID, Name]
+          explode: false
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+      responses:
+        200:
+          description: the user
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+        4XX:
+          description: an error
+  /users/me:
+    get:
+      operationId: getMe
+      responses:
+        default:
+          description: the user
+  /users:
+    post:
+      operationId: createUser
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        201:
+          description: created
+components:
+  schemas:
+    User:
+      type: object
+      required: [ID, Name]
+      properties:
+        ID:
+          type: integer
+          minimum: 0
+          exclusiveMinimum: true
+        Name:
+          type: string
+          nullable: true
+`
+
+func mustLoad(t *testing.T) *Document {
+	doc, err := Load([]byte(spec))
+	require.NoError(t, err)
+	return doc
+}
+
+func violationStrings(violations []Violation) []string {
+	result := make([]string, len(violations))
+	for i := range violations {
+		result[i] = violations[i].String()
+	}
+	return result
+}
+
+func TestFindOperation(t *testing.T) {
+	doc := mustLoad(t)
+	require.Len(t, doc.Operations(), 3)
+
+	tests := []struct {
+		Method string
+		Path   string
+		ID     string
+		Values map[string]string
+		Error  string
+	}{
+		{"GET", "/v1/users/10", "getUser", map[string]string{"id": "10"}, ""},
+		{"GET", "/users/10", "getUser", map[string]string{"id": "10"}, ""},
+		{"GET", "/v1/users/me", "getMe", map[string]string{}, ""},
+		{"GET", "/v1/users/a%2Fb", "getUser", map[string]string{"id": "a/b"}, ""},
+		{"POST", "/v1/users", "createUser", map[string]string{}, ""},
+		{"DELETE", "/v1/users/10", "", nil, "method DELETE is not allowed for /v1/users/10"},
+		{"GET", "/v1/groups", "", nil, "no operation matches GET /v1/groups"},
+	}
+	for _, test := range tests {
+		t.Run(test.Method+" "+test.Path, func(t *testing.T) {
+			op, values, err := doc.FindOperation(test.Method, test.Path)
+			if test.Error != "" {
+				require.EqualError(t, err, test.Error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.ID, op.ID)
+			require.Equal(t, test.Values, values)
+		})
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	doc := mustLoad(t)
+
+	tests := []struct {
+		Name       string
+		Method     string
+		URL        string
+		Header     http.Header
+		Body       string
+		Violations []string
+	}{
+		{
+			"valid", "GET", "/v1/users/10?fields=ID,Name", http.Header{"X-Request-Id": {"1"}}, "",
+			[]string{},
+		},
+		{
+			"invalid parameters", "GET", "/v1/users/joe?fields=ID,Age", nil, "",
+			[]string{
+				`path parameter "id": /paths/~1users~1{id}/parameters/0/schema/type: expected integer, but got string`,
+				`query parameter "fields" /1: /paths/~1users~1{id}/get/parameters/0/schema/items/enum: value must be one of ["ID","Name"]`,
+				`header parameter "X-Request-ID": /paths/~1users~1{id}/get/parameters/1: parameter is required`,
+			},
+		},
+		{
+			"valid body", "POST", "/v1/users", http.Header{"Content-Type": {"application/json; charset=utf-8"}},
+			`{"ID": 1, "Name": null}`,
+			[]string{},
+		},
+		{
+			"missing body", "POST", "/v1/users", nil, "",
+			[]string{`request body: /paths/~1users/post/requestBody: request body is required`},
+		},
+		{
+			"invalid body", "POST", "/v1/users", http.Header{"Content-Type": {"application/json"}}, `{"ID": 0}`,
+			[]string{
+				`request body: /paths/~1users/post/requestBody/content/application~1json/schema/$ref/required: missing required property "Name"`,
+				`request body /ID: /paths/~1users/post/requestBody/content/application~1json/schema/$ref/properties/ID/exclusiveMinimum: 0 is less than or equal to the exclusive minimum of 0`,
+			},
+		},
+		{
+			"invalid json", "POST", "/v1/users", http.Header{"Content-Type": {"application/json"}}, `{`,
+			[]string{`request body: /paths/~1users/post/requestBody/content/application~1json: invalid json: unexpected EOF`},
+		},
+		{
+			"invalid content type", "POST", "/v1/users", http.Header{"Content-Type": {"text/plain"}}, `Joe`,
+			[]string{`request body: /paths/~1users/post/requestBody/content: content type "text/plain" is not allowed, expected one of application/json`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			req := httptest.NewRequest(test.Method, "https://example.com"+test.URL, strings.NewReader(test.Body))
+			if test.Header != nil {
+				req.Header = test.Header
+			}
+			op, values, err := doc.FindOperation(req.Method, req.URL.Path)
+			require.NoError(t, err)
+			violations, err := doc.ValidateRequest(op, req, values, []byte(test.Body))
+			require.NoError(t, err)
+			require.Equal(t, test.Violations, violationStrings(violations))
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	doc := mustLoad(t)
+	op, _, err := doc.FindOperation("GET", "/v1/users/10")
+	require.NoError(t, err)
+
+	tests := []struct {
+		Name       string
+		Status     int
+		Header     http.Header
+		Body       string
+		Violations []string
+	}{
+		{
+			"valid", 200, http.Header{"Content-Type": {"application/json"}, "X-Rate-Limit": {"10"}}, `{"ID": 1, "Name": "Joe"}`,
+			[]string{},
+		},
+		{
+			"invalid", 200, http.Header{"Content-Type": {"application/json"}}, `{"ID": "1", "Name": "Joe"}`,
+			[]string{
+				`response header "X-Rate-Limit": /paths/~1users~1{id}/get/responses/200/headers/X-Rate-Limit: header is required`,
+				`response body /ID: /paths/~1users~1{id}/get/responses/200/content/application~1json/schema/$ref/properties/ID/type: expected integer, but got string`,
+			},
+		},
+		{
+			"invalid header", 200, http.Header{"X-Rate-Limit": {"many"}}, ``,
+			[]string{`response header "X-Rate-Limit": /paths/~1users~1{id}/get/responses/200/headers/X-Rate-Limit/schema/type: expected integer, but got string`},
+		},
+		{"status range", 404, nil, `not found`, []string{}},
+		{
+			"undocumented status", 500, nil, ``,
+			[]string{`response status: /paths/~1users~1{id}/get/responses: status 500 is not documented`},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			res := &http.Response{StatusCode: test.Status, Header: test.Header}
+			if res.Header == nil {
+				res.Header = http.Header{}
+			}
+			violations, err := doc.ValidateResponse(op, res, []byte(test.Body))
+			require.NoError(t, err)
+			require.Equal(t, test.Violations, violationStrings(violations))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "openapi")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "openapi.json")
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{
+			"openapi": "3.1.0",
+			"info": {"title": "Users", "version": "1.0.0"},
+			"paths": {"/users": {"get": {"operationId": "listUsers", "responses": {"200": {"description": "users"}}}}}
+		}`), 0o600))
+
+		doc, err := Load(path)
+		require.NoError(t, err)
+		require.Len(t, doc.Operations(), 1)
+		require.Equal(t, "listUsers", doc.Operations()[0].ID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Load("does-not-exist.yaml")
+		require.Error(t, err)
+
+		_, err = Load([]byte(`swagger: "2.0"`))
+		require.EqualError(t, err, `unsupported OpenAPI version "", only OpenAPI 3 is supported`)
+
+		_, err = Load([]byte(`[`))
+		require.Error(t, err)
+
+		_, err = Load(10)
+		require.EqualError(t, err, "OpenAPI document must be a path (string) or []byte, got int")
+	})
+}
@@ -0,0 +1,358 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Eun/go-hit/internal/jsonpointer"
+	"github.com/Eun/go-hit/internal/jsonschema"
+)
+
+// Violation describes a single violation of the OpenAPI document.
+type Violation struct {
+	// Location describes the part of the request or response that violates the document,
+	// e.g. `query parameter "page"` or `response body`.
+	Location string
+	// InstanceLocation is the JSON pointer to the value that violates the document, it is empty if the violation
+	// does not belong to a specific value.
+	InstanceLocation string
+	// Pointer is the JSON pointer to the violated part of the document.
+	Pointer string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the string representation of the Violation.
+func (v Violation) String() string {
+	location := v.Location
+	if v.InstanceLocation != "" {
+		location += " " + v.InstanceLocation
+	}
+	return fmt.Sprintf("%s: %s: %s", location, v.Pointer, v.Message)
+}
+
+// ValidateRequest validates the request parameters and the request body against the operation.
+func (d *Document) ValidateRequest(op *Operation, req *http.Request, pathValues map[string]string, body []byte) ([]Violation, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var violations []Violation
+	query := req.URL.Query()
+	for _, p := range op.parameters {
+		location := fmt.Sprintf("%s parameter %q", p.In, p.Name)
+		var values []string
+		switch p.In {
+		case "path":
+			if v, ok := pathValues[p.Name]; ok {
+				values = []string{v}
+			}
+		case "query":
+			values = query[p.Name]
+		case "header":
+			switch http.CanonicalHeaderKey(p.Name) {
+			case "Accept", "Content-Type", "Authorization":
+				// these headers are described by the content and the security of the operation
+				continue
+			}
+			values = req.Header.Values(p.Name)
+		case "cookie":
+			if c, err := req.Cookie(p.Name); err == nil {
+				values = []string{c.Value}
+			}
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if p.Required {
+				violations = append(violations, Violation{
+					Location: location,
+					Pointer:  p.Pointer,
+					Message:  "parameter is required",
+				})
+			}
+			continue
+		}
+		v, err := d.validateValues(location, p.Schema, values, p.Explode)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+
+	raw, ok := op.Raw["requestBody"]
+	if !ok {
+		return violations, nil
+	}
+	requestBody, pointer, err := d.deref(raw, op.Pointer+"/requestBody")
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 {
+		if required, _ := requestBody["required"].(bool); required {
+			violations = append(violations, Violation{
+				Location: "request body",
+				Pointer:  pointer,
+				Message:  "request body is required",
+			})
+		}
+		return violations, nil
+	}
+	v, err := d.validateContent("request body", requestBody, pointer, req.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, err
+	}
+	return append(violations, v...), nil
+}
+
+// ValidateResponse validates the response status, the response headers and the response body against the
+// operation.
+func (d *Document) ValidateResponse(op *Operation, res *http.Response, body []byte) ([]Violation, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	responses, _ := op.Raw["responses"].(map[string]interface{})
+	var raw interface{}
+	var pointer string
+	for _, key := range []string{strconv.Itoa(res.StatusCode), fmt.Sprintf("%dXX", res.StatusCode/100), "default"} {
+		if v, ok := responses[key]; ok {
+			raw = v
+			pointer = jsonpointer.Join(op.Pointer, "responses", key)
+			break
+		}
+	}
+	if raw == nil {
+		return []Violation{{
+			Location: "response status",
+			Pointer:  op.Pointer + "/responses",
+			Message:  fmt.Sprintf("status %d is not documented", res.StatusCode),
+		}}, nil
+	}
+	response, pointer, err := d.deref(raw, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []Violation
+	headers, _ := response["headers"].(map[string]interface{})
+	for _, name := range sortedKeys(headers) {
+		if http.CanonicalHeaderKey(name) == "Content-Type" {
+			continue
+		}
+		header, headerPointer, err := d.deref(headers[name], jsonpointer.Join(pointer, "headers", name))
+		if err != nil {
+			return nil, err
+		}
+		location := fmt.Sprintf("response header %q", name)
+		values := res.Header.Values(name)
+		if len(values) == 0 {
+			if required, _ := header["required"].(bool); required {
+				violations = append(violations, Violation{
+					Location: location,
+					Pointer:  headerPointer,
+					Message:  "header is required",
+				})
+			}
+			continue
+		}
+		schema := ""
+		if _, ok := header["schema"]; ok {
+			schema = headerPointer + "/schema"
+		}
+		explode, _ := header["explode"].(bool)
+		v, err := d.validateValues(location, schema, values, explode)
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v...)
+	}
+
+	if len(body) == 0 {
+		return violations, nil
+	}
+	if _, ok := response["content"]; !ok {
+		return violations, nil
+	}
+	v, err := d.validateContent("response body", response, pointer, res.Header.Get("Content-Type"), body)
+	if err != nil {
+		return nil, err
+	}
+	return append(violations, v...), nil
+}
+
+// validateContent validates the body against the content of the request body or response object.
+func (d *Document) validateContent(
+	location string, parent map[string]interface{}, pointer, contentType string, body []byte,
+) ([]Violation, error) {
+	content, _ := parent["content"].(map[string]interface{})
+	if len(content) == 0 {
+		return nil, nil
+	}
+	var key, mediaType string
+	if strings.TrimSpace(contentType) == "" {
+		// without a content type the body can only be validated if the document describes a JSON body
+		key, mediaType = jsonMediaType(content)
+		if key == "" {
+			return nil, nil
+		}
+	} else {
+		var err error
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			mediaType = strings.ToLower(strings.TrimSpace(contentType))
+		}
+		key = matchMediaType(content, mediaType)
+	}
+	if key == "" {
+		return []Violation{{
+			Location: location,
+			Pointer:  pointer + "/content",
+			Message:  fmt.Sprintf("content type %q is not allowed, expected one of %s", contentType, strings.Join(sortedKeys(content), ", ")),
+		}}, nil
+	}
+
+	mediaPointer := jsonpointer.Join(pointer, "content", key)
+	media, _ := content[key].(map[string]interface{})
+	if _, ok := media["schema"]; !ok || !isJSON(mediaType) {
+		return nil, nil
+	}
+
+	instance, err := jsonschema.Decode(body)
+	if err != nil {
+		return []Violation{{
+			Location: location,
+			Pointer:  mediaPointer,
+			Message:  fmt.Sprintf("invalid json: %s", err.Error()),
+		}}, nil
+	}
+	return d.validateSchema(location, mediaPointer+"/schema", instance)
+}
+
+// matchMediaType returns the content key that matches the media type, exact matches are preferred over ranges
+// (e.g. application/*).
+func matchMediaType(content map[string]interface{}, mediaType string) string {
+	keys := sortedKeys(content)
+	candidates := []string{mediaType, "", "*/*"}
+	if i := strings.IndexRune(mediaType, '/'); i >= 0 {
+		candidates[1] = mediaType[:i] + "/*"
+	}
+	for _, candidate := range candidates {
+		for _, key := range keys {
+			if candidate == "" {
+				continue
+			}
+			k, _, err := mime.ParseMediaType(key)
+			if err != nil {
+				k = strings.ToLower(key)
+			}
+			if k == candidate {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// jsonMediaType returns the first content key that describes a JSON body and its media type.
+func jsonMediaType(content map[string]interface{}) (string, string) {
+	for _, key := range sortedKeys(content) {
+		mediaType, _, err := mime.ParseMediaType(key)
+		if err == nil && isJSON(mediaType) {
+			return key, mediaType
+		}
+	}
+	return "", ""
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// validateValues validates the string values of a parameter or header against the schema, the values will be
+// converted into the types of the schema first.
+func (d *Document) validateValues(location, schema string, values []string, explode bool) ([]Violation, error) {
+	if schema == "" {
+		return nil, nil
+	}
+	typ, resolved := d.schemaType(schema)
+
+	var instance interface{}
+	if typ == "array" {
+		if !explode || len(values) == 1 {
+			values = strings.Split(values[0], ",")
+		}
+		itemType, _ := d.schemaType(resolved + "/items")
+		items := make([]interface{}, len(values))
+		for i := range values {
+			items[i] = coerce(values[i], itemType)
+		}
+		instance = items
+	} else {
+		instance = coerce(values[0], typ)
+	}
+	return d.validateSchema(location, schema, instance)
+}
+
+// schemaType returns the (first non null) type of the schema at the pointer, it follows local references and
+// returns the pointer to the resolved schema.
+func (d *Document) schemaType(pointer string) (string, string) {
+	v, ok := jsonpointer.Get(d.raw, pointer)
+	if !ok {
+		return "", pointer
+	}
+	m, resolved, err := d.deref(v, pointer)
+	if err != nil {
+		return "", pointer
+	}
+	switch t := m["type"].(type) {
+	case string:
+		return t, resolved
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok && s != "null" {
+				return s, resolved
+			}
+		}
+	}
+	return "", resolved
+}
+
+// coerce converts the string into the specified JSON type, if possible.
+func coerce(s, typ string) interface{} {
+	switch typ {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(s, 64); err == nil {
+			return json.Number(s)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case "null":
+		if s == "" || s == "null" {
+			return nil
+		}
+	}
+	return s
+}
+
+func (d *Document) validateSchema(location, pointer string, instance interface{}) ([]Violation, error) {
+	errs, err := d.schema.ValidatePointer(pointer, instance)
+	if err != nil {
+		return nil, err
+	}
+	violations := make([]Violation, len(errs))
+	for i, e := range errs {
+		violations[i] = Violation{
+			Location:         location,
+			InstanceLocation: e.InstanceLocation,
+			Pointer:          e.KeywordLocation,
+			Message:          e.Message,
+		}
+	}
+	return violations, nil
+}
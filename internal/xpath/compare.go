@@ -0,0 +1,217 @@
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+	"strings"
+)
+
+// Equal reports whether the actual and the expected node are semantically equal.
+// Whitespace around text, whitespace only text, comments, processing instructions and the order of attributes are
+// ignored. Namespaces are only compared if the expected element (or attribute) has a namespace.
+func Equal(actual, expected *Node) bool {
+	actual, expected = element(actual), element(expected)
+	if actual == nil || expected == nil {
+		return actual == expected
+	}
+	return equalElement(actual, expected)
+}
+
+// Contains reports whether any element in the actual node (including itself) contains the expected element.
+// An element contains the expected element if it has the same name, has all of the expected attributes, has the same
+// text (if the expected element has text) and each of the expected children is contained in a distinct child.
+func Contains(actual, expected *Node) bool {
+	actual, expected = element(actual), element(expected)
+	if actual == nil || expected == nil {
+		return false
+	}
+	return !walk(actual, func(n *Node) bool {
+		return !containsElement(n, expected)
+	})
+}
+
+// element returns the node itself if it is an element, or the document element if it is a document.
+func element(n *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	if n.Type == DocumentNode {
+		return documentElement(n)
+	}
+	return n
+}
+
+func equalName(actual, expected xml.Name) bool {
+	return actual.Local == expected.Local && (expected.Space == "" || actual.Space == expected.Space)
+}
+
+func equalElement(actual, expected *Node) bool {
+	if actual.Type != expected.Type {
+		return false
+	}
+	if actual.Type != ElementNode {
+		return strings.TrimSpace(actual.Data) == strings.TrimSpace(expected.Data)
+	}
+	if !equalName(actual.Name, expected.Name) || len(actual.Attr) != len(expected.Attr) {
+		return false
+	}
+	if !containsAttributes(actual, expected) {
+		return false
+	}
+
+	actualChildren, expectedChildren := significantChildren(actual), significantChildren(expected)
+	if len(actualChildren) != len(expectedChildren) {
+		return false
+	}
+	for i := range actualChildren {
+		if !equalElement(actualChildren[i], expectedChildren[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAttributes(actual, expected *Node) bool {
+	for _, e := range expected.Attr {
+		found := false
+		for _, a := range actual.Attr {
+			if equalName(a.Name, e.Name) && a.Data == e.Data {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsElement(actual, expected *Node) bool {
+	if !equalName(actual.Name, expected.Name) || !containsAttributes(actual, expected) {
+		return false
+	}
+
+	actualChildren := significantChildren(actual)
+	used := make([]bool, len(actualChildren))
+	for _, e := range significantChildren(expected) {
+		found := false
+		for i, a := range actualChildren {
+			if used[i] || a.Type != e.Type {
+				continue
+			}
+			if (e.Type == ElementNode && containsElement(a, e)) ||
+				(e.Type == TextNode && strings.TrimSpace(a.Data) == strings.TrimSpace(e.Data)) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// significantChildren returns the child elements and the text nodes that do not only consist of whitespace.
+func significantChildren(n *Node) []*Node {
+	var children []*Node
+	for _, c := range n.Children {
+		switch c.Type {
+		case ElementNode:
+			children = append(children, c)
+		case TextNode:
+			if strings.TrimSpace(c.Data) != "" {
+				children = append(children, c)
+			}
+		}
+	}
+	return children
+}
+
+// Format returns an indented representation of the node, that is suitable for comparisons and diffs.
+// Attributes are sorted, whitespace around text is removed, comments and processing instructions are omitted.
+func Format(n *Node) string {
+	var buf bytes.Buffer
+	switch n.Type {
+	case DocumentNode:
+		if root := documentElement(n); root != nil {
+			formatElement(&buf, root, "", 0)
+		}
+	case ElementNode:
+		formatElement(&buf, n, "", 0)
+	default:
+		textEscape(&buf, strings.TrimSpace(n.Data))
+	}
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func formatElement(buf *bytes.Buffer, n *Node, parentSpace string, depth int) {
+	indent := strings.Repeat("  ", depth)
+	buf.WriteString(indent)
+	buf.WriteByte('<')
+	buf.WriteString(n.QualifiedName())
+
+	var attrs []string
+	if n.Name.Space != parentSpace && n.Prefix == "" {
+		attrs = append(attrs, formatAttribute("xmlns", n.Name.Space))
+	}
+	for _, a := range n.Attr {
+		attrs = append(attrs, formatAttribute(a.QualifiedName(), a.Data))
+	}
+	sort.Strings(attrs)
+	for _, a := range attrs {
+		buf.WriteByte(' ')
+		buf.WriteString(a)
+	}
+
+	children := significantChildren(n)
+	switch {
+	case len(children) == 0:
+		buf.WriteString("/>\n")
+		return
+	case len(children) == 1 && children[0].Type == TextNode:
+		buf.WriteByte('>')
+		textEscape(buf, strings.TrimSpace(children[0].Data))
+	default:
+		buf.WriteString(">\n")
+		for _, c := range children {
+			if c.Type == ElementNode {
+				formatElement(buf, c, n.Name.Space, depth+1)
+				continue
+			}
+			buf.WriteString(indent)
+			buf.WriteString("  ")
+			textEscape(buf, strings.TrimSpace(c.Data))
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(indent)
+	}
+	buf.WriteString("</")
+	buf.WriteString(n.QualifiedName())
+	buf.WriteString(">\n")
+}
+
+func formatAttribute(name, value string) string {
+	var buf bytes.Buffer
+	buf.WriteString(name)
+	buf.WriteString(`="`)
+	xmlEscape(&buf, value)
+	buf.WriteByte('"')
+	return buf.String()
+}
+
+// xmlEscape escapes the attribute value.
+func xmlEscape(buf *bytes.Buffer, s string) {
+	// EscapeText only fails if the writer fails, bytes.Buffer never does
+	_ = xml.EscapeText(buf, []byte(s))
+}
+
+// textReplacer escapes character data, unlike xml.EscapeText it keeps newlines and tabs.
+var textReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+
+func textEscape(buf *bytes.Buffer, s string) {
+	_, _ = textReplacer.WriteString(buf, s)
+}
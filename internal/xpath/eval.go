@@ -0,0 +1,558 @@
+package xpath
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// NodeSet is the result of an expression that selects nodes, the nodes are in document order.
+type NodeSet []*Node
+
+// context is the evaluation context of an expression.
+type context struct {
+	node     *Node
+	position int
+	size     int
+}
+
+type node interface {
+	eval(ctx context) (interface{}, error)
+}
+
+// Evaluate evaluates the expression with the specified node as context node.
+// The result is either a NodeSet, a string, a float64 or a bool.
+func (e *Expr) Evaluate(n *Node) (interface{}, error) {
+	v, err := e.root.eval(context{node: n, position: 1, size: 1})
+	if err != nil {
+		return nil, xerrors.Errorf("unable to evaluate %q: %w", e.source, err)
+	}
+	return v, nil
+}
+
+// Select evaluates the expression and returns the selected nodes, it fails if the expression does not select nodes.
+func (e *Expr) Select(n *Node) (NodeSet, error) {
+	v, err := e.Evaluate(n)
+	if err != nil {
+		return nil, err
+	}
+	ns, ok := v.(NodeSet)
+	if !ok {
+		return nil, xerrors.Errorf("%q does not select nodes", e.source)
+	}
+	return ns, nil
+}
+
+type stringNode string
+
+func (n stringNode) eval(context) (interface{}, error) {
+	return string(n), nil
+}
+
+type numberNode float64
+
+func (n numberNode) eval(context) (interface{}, error) {
+	return float64(n), nil
+}
+
+type negateNode struct {
+	expr node
+}
+
+func (n *negateNode) eval(ctx context) (interface{}, error) {
+	v, err := n.expr.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return -Number(v), nil
+}
+
+type unionNode struct {
+	left, right node
+}
+
+func (n *unionNode) eval(ctx context) (interface{}, error) {
+	left, err := evalNodeSet(n.left, ctx)
+	if err != nil {
+		return nil, err
+	}
+	right, err := evalNodeSet(n.right, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return documentOrder(append(append(NodeSet{}, left...), right...)), nil
+}
+
+func evalNodeSet(n node, ctx context) (NodeSet, error) {
+	v, err := n.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ns, ok := v.(NodeSet)
+	if !ok {
+		return nil, xerrors.New("expression does not select nodes")
+	}
+	return ns, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) eval(ctx context) (interface{}, error) {
+	left, err := n.left.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// short circuit
+	switch n.op {
+	case "or":
+		if Boolean(left) {
+			return true, nil
+		}
+	case "and":
+		if !Boolean(left) {
+			return false, nil
+		}
+	}
+
+	right, err := n.right.eval(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "or", "and":
+		return Boolean(right), nil
+	case "=", "!=", "<", "<=", ">", ">=":
+		return compare(n.op, left, right), nil
+	}
+
+	l, r := Number(left), Number(right)
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "div":
+		return l / r, nil
+	case "mod":
+		return math.Mod(l, r), nil
+	}
+	return nil, xerrors.Errorf("unknown operator %q", n.op)
+}
+
+// compare compares the values as described in https://www.w3.org/TR/xpath-10/#booleans.
+func compare(op string, left, right interface{}) bool {
+	leftSet, leftIsSet := left.(NodeSet)
+	rightSet, rightIsSet := right.(NodeSet)
+
+	switch {
+	case leftIsSet && rightIsSet:
+		for _, l := range leftSet {
+			for _, r := range rightSet {
+				if compareAtomic(op, l.String(), r.String()) {
+					return true
+				}
+			}
+		}
+		return false
+	case leftIsSet:
+		if b, ok := right.(bool); ok {
+			return compareAtomic(op, Boolean(left), b)
+		}
+		for _, l := range leftSet {
+			if compareAtomic(op, atomize(l, right), right) {
+				return true
+			}
+		}
+		return false
+	case rightIsSet:
+		if b, ok := left.(bool); ok {
+			return compareAtomic(op, b, Boolean(right))
+		}
+		for _, r := range rightSet {
+			if compareAtomic(op, left, atomize(r, left)) {
+				return true
+			}
+		}
+		return false
+	}
+	return compareAtomic(op, left, right)
+}
+
+// atomize converts the node into the type of the other operand.
+func atomize(n *Node, other interface{}) interface{} {
+	if _, ok := other.(float64); ok {
+		return Number(n.String())
+	}
+	return n.String()
+}
+
+func compareAtomic(op string, left, right interface{}) bool {
+	if op == "=" || op == "!=" {
+		var equal bool
+		_, leftIsBool := left.(bool)
+		_, rightIsBool := right.(bool)
+		_, leftIsNumber := left.(float64)
+		_, rightIsNumber := right.(float64)
+		switch {
+		case leftIsBool || rightIsBool:
+			equal = Boolean(left) == Boolean(right)
+		case leftIsNumber || rightIsNumber:
+			equal = Number(left) == Number(right)
+		default:
+			equal = String(left) == String(right)
+		}
+		if op == "=" {
+			return equal
+		}
+		return !equal
+	}
+
+	l, r := Number(left), Number(right)
+	switch op {
+	case "<":
+		return l < r
+	case "<=":
+		return l <= r
+	case ">":
+		return l > r
+	case ">=":
+		return l >= r
+	}
+	return false
+}
+
+type filterNode struct {
+	expr       node
+	predicates []node
+}
+
+func (n *filterNode) eval(ctx context) (interface{}, error) {
+	ns, err := evalNodeSet(n.expr, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, predicate := range n.predicates {
+		ns, err = filter(ns, predicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ns, nil
+}
+
+// filter returns the nodes that match the predicate, the position of a node is its position in the node set.
+func filter(ns NodeSet, predicate node) (NodeSet, error) {
+	var result NodeSet
+	for i, n := range ns {
+		v, err := predicate.eval(context{node: n, position: i + 1, size: len(ns)})
+		if err != nil {
+			return nil, err
+		}
+		var match bool
+		if f, ok := v.(float64); ok {
+			match = f == float64(i+1)
+		} else {
+			match = Boolean(v)
+		}
+		if match {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+type pathNode struct {
+	// filter is the expression the steps are applied to, nil for location paths
+	filter   node
+	absolute bool
+	steps    []*step
+}
+
+func (n *pathNode) eval(ctx context) (interface{}, error) {
+	var ns NodeSet
+	switch {
+	case n.filter != nil:
+		var err error
+		ns, err = evalNodeSet(n.filter, ctx)
+		if err != nil {
+			return nil, err
+		}
+	case n.absolute:
+		root := ctx.node
+		for root.Parent != nil {
+			root = root.Parent
+		}
+		ns = NodeSet{root}
+	default:
+		ns = NodeSet{ctx.node}
+	}
+
+	for _, s := range n.steps {
+		var result NodeSet
+		for _, contextNode := range ns {
+			selected, err := s.apply(contextNode)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, selected...)
+		}
+		ns = documentOrder(result)
+	}
+	return ns, nil
+}
+
+type nodeTest struct {
+	// kind is either name, node, text, comment or processing-instruction
+	kind   string
+	prefix string
+	// local is the local name (or *) for name tests and the target for processing-instruction tests
+	local string
+}
+
+func (t nodeTest) match(n *Node, principal NodeType) (bool, error) {
+	switch t.kind {
+	case "node":
+		return true, nil
+	case "text":
+		return n.Type == TextNode, nil
+	case "comment":
+		return n.Type == CommentNode, nil
+	case "processing-instruction":
+		return n.Type == ProcInstNode && (t.local == "" || t.local == n.Name.Local), nil
+	}
+
+	if n.Type != principal {
+		return false, nil
+	}
+	if t.prefix != "" {
+		uri, ok := n.lookupNamespace(t.prefix)
+		if !ok {
+			return false, xerrors.Errorf("unknown namespace prefix %q", t.prefix)
+		}
+		if uri != n.Name.Space {
+			return false, nil
+		}
+	}
+	return t.local == "*" || t.local == n.Name.Local, nil
+}
+
+type step struct {
+	axis       string
+	test       nodeTest
+	predicates []node
+}
+
+// apply returns the nodes that are selected by the step, the nodes are in axis order.
+func (s *step) apply(n *Node) (NodeSet, error) {
+	principal := ElementNode
+	if s.axis == "attribute" {
+		principal = AttributeNode
+	}
+
+	var ns NodeSet
+	for _, candidate := range axis(s.axis, n) {
+		ok, err := s.test.match(candidate, principal)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ns = append(ns, candidate)
+		}
+	}
+
+	for _, predicate := range s.predicates {
+		var err error
+		ns, err = filter(ns, predicate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ns, nil
+}
+
+// axis returns the nodes on the axis in axis order (reverse document order for reverse axes).
+func axis(name string, n *Node) NodeSet {
+	var ns NodeSet
+	switch name {
+	case "self":
+		ns = NodeSet{n}
+	case "child":
+		ns = append(ns, n.Children...)
+	case "attribute":
+		ns = append(ns, n.Attr...)
+	case "parent":
+		if n.Parent != nil {
+			ns = NodeSet{n.Parent}
+		}
+	case "ancestor", "ancestor-or-self":
+		if name == "ancestor-or-self" {
+			ns = append(ns, n)
+		}
+		for p := n.Parent; p != nil; p = p.Parent {
+			ns = append(ns, p)
+		}
+	case "descendant", "descendant-or-self":
+		if name == "descendant-or-self" {
+			ns = append(ns, n)
+		}
+		ns = appendDescendants(ns, n)
+	case "following-sibling", "preceding-sibling":
+		if n.Parent == nil || n.Type == AttributeNode {
+			return nil
+		}
+		siblings := n.Parent.Children
+		i := indexOf(siblings, n)
+		if name == "following-sibling" {
+			ns = append(ns, siblings[i+1:]...)
+		} else {
+			for j := i - 1; j >= 0; j-- {
+				ns = append(ns, siblings[j])
+			}
+		}
+	case "following":
+		for cur := n; cur.Parent != nil; cur = cur.Parent {
+			if cur.Type == AttributeNode {
+				ns = appendDescendants(ns, cur.Parent)
+				continue
+			}
+			siblings := cur.Parent.Children
+			for _, sibling := range siblings[indexOf(siblings, cur)+1:] {
+				ns = append(ns, sibling)
+				ns = appendDescendants(ns, sibling)
+			}
+		}
+		ns = documentOrder(ns)
+	case "preceding":
+		ancestors := map[*Node]bool{}
+		for p := n.Parent; p != nil; p = p.Parent {
+			ancestors[p] = true
+		}
+		root := n
+		for root.Parent != nil {
+			root = root.Parent
+		}
+		for _, c := range appendDescendants(nil, root) {
+			if c.order < n.order && !ancestors[c] {
+				ns = append(ns, c)
+			}
+		}
+		// reverse document order
+		for i, j := 0, len(ns)-1; i < j; i, j = i+1, j-1 {
+			ns[i], ns[j] = ns[j], ns[i]
+		}
+	}
+	return ns
+}
+
+func appendDescendants(ns NodeSet, n *Node) NodeSet {
+	for _, c := range n.Children {
+		ns = append(ns, c)
+		ns = appendDescendants(ns, c)
+	}
+	return ns
+}
+
+func indexOf(ns []*Node, n *Node) int {
+	for i, c := range ns {
+		if c == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// documentOrder sorts the nodes in document order and removes duplicates.
+func documentOrder(ns NodeSet) NodeSet {
+	sort.SliceStable(ns, func(i, j int) bool {
+		return ns[i].order < ns[j].order
+	})
+	result := ns[:0]
+	for i, n := range ns {
+		if i > 0 && ns[i-1] == n {
+			continue
+		}
+		result = append(result, n)
+	}
+	return result
+}
+
+// String converts the value to a string as described in https://www.w3.org/TR/xpath-10/#function-string.
+func String(v interface{}) string {
+	switch t := v.(type) {
+	case NodeSet:
+		if len(t) == 0 {
+			return ""
+		}
+		return t[0].String()
+	case string:
+		return t
+	case float64:
+		switch {
+		case math.IsNaN(t):
+			return "NaN"
+		case math.IsInf(t, 1):
+			return "Infinity"
+		case math.IsInf(t, -1):
+			return "-Infinity"
+		case t == 0:
+			return "0"
+		}
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		if t {
+			return "true"
+		}
+		return "false"
+	}
+	return ""
+}
+
+var numberRegexp = regexp.MustCompile(`^-?(\d+(\.\d*)?|\.\d+)$`)
+
+// Number converts the value to a number as described in https://www.w3.org/TR/xpath-10/#function-number.
+func Number(v interface{}) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case bool:
+		if t {
+			return 1
+		}
+		return 0
+	}
+	s := strings.TrimSpace(String(v))
+	if !numberRegexp.MatchString(s) {
+		return math.NaN()
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return f
+}
+
+// Boolean converts the value to a boolean as described in https://www.w3.org/TR/xpath-10/#function-boolean.
+func Boolean(v interface{}) bool {
+	switch t := v.(type) {
+	case NodeSet:
+		return len(t) > 0
+	case string:
+		return t != ""
+	case float64:
+		return t != 0 && !math.IsNaN(t)
+	case bool:
+		return t
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package xpath
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+type function struct {
+	minArgs int
+	// maxArgs is the maximum number of arguments, -1 for no limit
+	maxArgs int
+	call    func(ctx context, args []interface{}) (interface{}, error)
+}
+
+type callNode struct {
+	name string
+	fn   function
+	args []node
+}
+
+func (n *callNode) eval(ctx context) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(ctx)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn.call(ctx, args)
+	if err != nil {
+		return nil, xerrors.Errorf("%s(): %w", n.name, err)
+	}
+	return v, nil
+}
+
+// nodeArg returns the first node of the optional node set argument, or the context node if the argument is omitted.
+func nodeArg(ctx context, args []interface{}) (*Node, error) {
+	if len(args) == 0 {
+		return ctx.node, nil
+	}
+	ns, ok := args[0].(NodeSet)
+	if !ok {
+		return nil, xerrors.New("argument is not a node set")
+	}
+	if len(ns) == 0 {
+		return nil, nil
+	}
+	return ns[0], nil
+}
+
+// stringArg returns the string value of the optional argument, or the string-value of the context node if the
+// argument is omitted.
+func stringArg(ctx context, args []interface{}) string {
+	if len(args) == 0 {
+		return ctx.node.String()
+	}
+	return String(args[0])
+}
+
+func nameFunction(name func(n *Node) string) func(ctx context, args []interface{}) (interface{}, error) {
+	return func(ctx context, args []interface{}) (interface{}, error) {
+		n, err := nodeArg(ctx, args)
+		if err != nil || n == nil {
+			return "", err
+		}
+		switch n.Type {
+		case ElementNode, AttributeNode, ProcInstNode:
+			return name(n), nil
+		}
+		return "", nil
+	}
+}
+
+// functions holds the core function library, see https://www.w3.org/TR/xpath-10/#corelib.
+var functions = map[string]function{
+	"last": {0, 0, func(ctx context, args []interface{}) (interface{}, error) {
+		return float64(ctx.size), nil
+	}},
+	"position": {0, 0, func(ctx context, args []interface{}) (interface{}, error) {
+		return float64(ctx.position), nil
+	}},
+	"count": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		ns, ok := args[0].(NodeSet)
+		if !ok {
+			return nil, xerrors.New("argument is not a node set")
+		}
+		return float64(len(ns)), nil
+	}},
+	"local-name": {0, 1, nameFunction(func(n *Node) string {
+		return n.Name.Local
+	})},
+	"namespace-uri": {0, 1, nameFunction(func(n *Node) string {
+		return n.Name.Space
+	})},
+	"name": {0, 1, nameFunction(func(n *Node) string {
+		return n.QualifiedName()
+	})},
+	"string": {0, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return stringArg(ctx, args), nil
+	}},
+	"concat": {2, -1, func(ctx context, args []interface{}) (interface{}, error) {
+		var sb strings.Builder
+		for _, arg := range args {
+			sb.WriteString(String(arg))
+		}
+		return sb.String(), nil
+	}},
+	"starts-with": {2, 2, func(ctx context, args []interface{}) (interface{}, error) {
+		return strings.HasPrefix(String(args[0]), String(args[1])), nil
+	}},
+	"ends-with": {2, 2, func(ctx context, args []interface{}) (interface{}, error) {
+		return strings.HasSuffix(String(args[0]), String(args[1])), nil
+	}},
+	"contains": {2, 2, func(ctx context, args []interface{}) (interface{}, error) {
+		return strings.Contains(String(args[0]), String(args[1])), nil
+	}},
+	"substring-before": {2, 2, func(ctx context, args []interface{}) (interface{}, error) {
+		s, sep := String(args[0]), String(args[1])
+		if i := strings.Index(s, sep); i >= 0 {
+			return s[:i], nil
+		}
+		return "", nil
+	}},
+	"substring-after": {2, 2, func(ctx context, args []interface{}) (interface{}, error) {
+		s, sep := String(args[0]), String(args[1])
+		if i := strings.Index(s, sep); i >= 0 {
+			return s[i+len(sep):], nil
+		}
+		return "", nil
+	}},
+	"substring": {2, 3, func(ctx context, args []interface{}) (interface{}, error) {
+		runes := []rune(String(args[0]))
+		start := round(Number(args[1]))
+		end := math.Inf(1)
+		if len(args) == 3 {
+			end = start + round(Number(args[2]))
+		}
+		var sb strings.Builder
+		for i, r := range runes {
+			// positions start at 1
+			if p := float64(i + 1); p >= start && p < end {
+				sb.WriteRune(r)
+			}
+		}
+		return sb.String(), nil
+	}},
+	"string-length": {0, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return float64(utf8.RuneCountInString(stringArg(ctx, args))), nil
+	}},
+	"normalize-space": {0, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return strings.Join(strings.Fields(stringArg(ctx, args)), " "), nil
+	}},
+	"translate": {3, 3, func(ctx context, args []interface{}) (interface{}, error) {
+		from, to := []rune(String(args[1])), []rune(String(args[2]))
+		return strings.Map(func(r rune) rune {
+			for i, f := range from {
+				if f != r {
+					continue
+				}
+				if i < len(to) {
+					return to[i]
+				}
+				return -1
+			}
+			return r
+		}, String(args[0])), nil
+	}},
+	"boolean": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return Boolean(args[0]), nil
+	}},
+	"not": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return !Boolean(args[0]), nil
+	}},
+	"true": {0, 0, func(ctx context, args []interface{}) (interface{}, error) {
+		return true, nil
+	}},
+	"false": {0, 0, func(ctx context, args []interface{}) (interface{}, error) {
+		return false, nil
+	}},
+	"number": {0, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		if len(args) == 0 {
+			return Number(ctx.node.String()), nil
+		}
+		return Number(args[0]), nil
+	}},
+	"sum": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		ns, ok := args[0].(NodeSet)
+		if !ok {
+			return nil, xerrors.New("argument is not a node set")
+		}
+		var sum float64
+		for _, n := range ns {
+			sum += Number(n.String())
+		}
+		return sum, nil
+	}},
+	"floor": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return math.Floor(Number(args[0])), nil
+	}},
+	"ceiling": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return math.Ceil(Number(args[0])), nil
+	}},
+	"round": {1, 1, func(ctx context, args []interface{}) (interface{}, error) {
+		return round(Number(args[0])), nil
+	}},
+}
+
+// round rounds to the closest integer, halves are rounded towards positive infinity.
+func round(f float64) float64 {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return f
+	}
+	return math.Floor(f + 0.5)
+}
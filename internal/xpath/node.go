@@ -0,0 +1,284 @@
+// Package xpath parses XML documents and evaluates XPath 1.0 expressions on them.
+//
+// Unprefixed names in expressions match elements regardless of their namespace (so /feed/entry matches an Atom feed
+// that uses a default namespace), prefixed names are resolved with the namespace declarations of the document.
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// NodeType is the type of a Node.
+type NodeType int
+
+const (
+	// DocumentNode is the root of a document.
+	DocumentNode NodeType = iota
+	// ElementNode is an element.
+	ElementNode
+	// AttributeNode is an attribute of an element.
+	AttributeNode
+	// TextNode is character data (including CDATA sections).
+	TextNode
+	// CommentNode is a comment.
+	CommentNode
+	// ProcInstNode is a processing instruction.
+	ProcInstNode
+)
+
+// Node is a node of an XML document.
+type Node struct {
+	Type NodeType
+	// Name is the name of an element, attribute or the target of a processing instruction, Name.Space holds the
+	// namespace uri.
+	Name xml.Name
+	// Prefix is the namespace prefix that was used in the document.
+	Prefix string
+	// Data holds the value of attribute, text, comment and processing instruction nodes.
+	Data string
+
+	Parent   *Node
+	Attr     []*Node
+	Children []*Node
+
+	// order is the position of the node in the document order
+	order int
+	// namespaces holds the namespace declarations that are in scope, the key is the prefix
+	namespaces map[string]string
+}
+
+// Parse parses the XML document.
+func Parse(r io.Reader) (*Node, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+
+	doc := &Node{Type: DocumentNode, namespaces: map[string]string{"xml": "http://www.w3.org/XML/1998/namespace"}}
+	current := doc
+	order := 1
+	add := func(n *Node) {
+		n.Parent = current
+		n.order = order
+		order++
+		current.Children = append(current.Children, n)
+	}
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.Errorf("unable to parse xml: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &Node{Type: ElementNode, Name: t.Name, namespaces: current.namespaces}
+			declared := false
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					if !declared {
+						n.namespaces = copyNamespaces(current.namespaces)
+						declared = true
+					}
+					if a.Name.Space == "xmlns" {
+						n.namespaces[a.Name.Local] = a.Value
+					} else {
+						n.namespaces[""] = a.Value
+					}
+				}
+			}
+			add(n)
+			n.Prefix = prefixOf(n.namespaces, n.Name.Space, true)
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+					continue
+				}
+				attr := &Node{
+					Type:   AttributeNode,
+					Name:   a.Name,
+					Prefix: prefixOf(n.namespaces, a.Name.Space, false),
+					Data:   a.Value,
+					Parent: n,
+					order:  order,
+				}
+				order++
+				n.Attr = append(n.Attr, attr)
+			}
+			current = n
+		case xml.EndElement:
+			current = current.Parent
+		case xml.CharData:
+			if last := lastChild(current); last != nil && last.Type == TextNode {
+				// merge adjacent character data (e.g. text followed by a CDATA section)
+				last.Data += string(t)
+				continue
+			}
+			if current == doc {
+				// whitespace outside of the root element
+				continue
+			}
+			add(&Node{Type: TextNode, Data: string(t)})
+		case xml.Comment:
+			add(&Node{Type: CommentNode, Data: string(t)})
+		case xml.ProcInst:
+			if t.Target == "xml" {
+				continue
+			}
+			add(&Node{Type: ProcInstNode, Name: xml.Name{Local: t.Target}, Data: string(t.Inst)})
+		}
+	}
+
+	if documentElement(doc) == nil {
+		return nil, xerrors.New("unable to parse xml: document has no root element")
+	}
+	return doc, nil
+}
+
+// ParseString parses the XML document in the string.
+func ParseString(s string) (*Node, error) {
+	return Parse(strings.NewReader(s))
+}
+
+func copyNamespaces(m map[string]string) map[string]string {
+	c := make(map[string]string, len(m)+1)
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// prefixOf returns the prefix that is bound to the namespace uri.
+func prefixOf(namespaces map[string]string, uri string, allowDefault bool) string {
+	if uri == "" {
+		return ""
+	}
+	if allowDefault && namespaces[""] == uri {
+		return ""
+	}
+	// use the smallest prefix if the uri is bound to multiple prefixes, so the result is stable
+	result := ""
+	for prefix, u := range namespaces {
+		if u == uri && prefix != "" && (result == "" || prefix < result) {
+			result = prefix
+		}
+	}
+	return result
+}
+
+func lastChild(n *Node) *Node {
+	if len(n.Children) == 0 {
+		return nil
+	}
+	return n.Children[len(n.Children)-1]
+}
+
+func documentElement(doc *Node) *Node {
+	for _, c := range doc.Children {
+		if c.Type == ElementNode {
+			return c
+		}
+	}
+	return nil
+}
+
+// charsetReader supports the encodings that are common besides utf-8.
+func charsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return input, nil
+	case "iso-8859-1", "latin1", "latin-1":
+		data, err := ioutil.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for _, b := range data {
+			buf.WriteRune(rune(b))
+		}
+		return &buf, nil
+	}
+	return nil, xerrors.Errorf("unsupported charset %q", charset)
+}
+
+// DocumentElement returns the root element of the document.
+func (n *Node) DocumentElement() *Node {
+	for n.Parent != nil {
+		n = n.Parent
+	}
+	if n.Type != DocumentNode {
+		return n
+	}
+	return documentElement(n)
+}
+
+// QualifiedName returns the name of the node including the prefix (e.g. atom:entry).
+func (n *Node) QualifiedName() string {
+	if n.Prefix == "" {
+		return n.Name.Local
+	}
+	return n.Prefix + ":" + n.Name.Local
+}
+
+// String returns the string-value of the node.
+func (n *Node) String() string {
+	switch n.Type {
+	case DocumentNode, ElementNode:
+		var sb strings.Builder
+		n.writeText(&sb)
+		return sb.String()
+	default:
+		return n.Data
+	}
+}
+
+func (n *Node) writeText(sb *strings.Builder) {
+	for _, c := range n.Children {
+		switch c.Type {
+		case TextNode:
+			sb.WriteString(c.Data)
+		case ElementNode:
+			c.writeText(sb)
+		}
+	}
+}
+
+// lookupNamespace resolves the prefix with the namespace declarations of the document, the declarations of the node
+// are preferred.
+func (n *Node) lookupNamespace(prefix string) (string, bool) {
+	if uri, ok := n.namespaces[prefix]; ok {
+		return uri, true
+	}
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	var uri string
+	found := false
+	walk(root, func(node *Node) bool {
+		if u, ok := node.namespaces[prefix]; ok {
+			uri, found = u, true
+			return false
+		}
+		return true
+	})
+	return uri, found
+}
+
+// walk calls fn for the node and all its descendant elements (in document order), until fn returns false.
+func walk(n *Node, fn func(*Node) bool) bool {
+	if !fn(n) {
+		return false
+	}
+	for _, c := range n.Children {
+		if c.Type == ElementNode && !walk(c, fn) {
+			return false
+		}
+	}
+	return true
+}
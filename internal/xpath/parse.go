@@ -0,0 +1,559 @@
+package xpath
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokNumber
+	tokString
+	tokOperator
+	tokVariable
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+// lex splits the expression into tokens.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(expr) {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '"' || r == '\'':
+			end := strings.IndexRune(expr[i+1:], r)
+			if end < 0 {
+				return nil, xerrors.Errorf("unterminated string literal at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokString, value: expr[i+1 : i+1+end], pos: i})
+			i += end + 2
+		case isDigit(r) || (r == '.' && i+1 < len(expr) && isDigit(rune(expr[i+1]))):
+			start := i
+			for i < len(expr) && isDigit(rune(expr[i])) {
+				i++
+			}
+			if i < len(expr) && expr[i] == '.' {
+				i++
+				for i < len(expr) && isDigit(rune(expr[i])) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokNumber, value: expr[start:i], pos: start})
+		case r == '$':
+			start := i
+			i++
+			name := scanName(expr[i:])
+			if name == "" {
+				return nil, xerrors.Errorf("expected a variable name at position %d", start)
+			}
+			i += len(name)
+			tokens = append(tokens, token{kind: tokVariable, value: name, pos: start})
+		case isNameStart(r):
+			start := i
+			name := scanName(expr[i:])
+			i += len(name)
+			// prefix:name and prefix:*
+			if i+1 < len(expr) && expr[i] == ':' && expr[i+1] != ':' {
+				if expr[i+1] == '*' {
+					name += ":*"
+					i += 2
+				} else if local := scanName(expr[i+1:]); local != "" {
+					name += ":" + local
+					i += 1 + len(local)
+				}
+			}
+			tokens = append(tokens, token{kind: tokName, value: name, pos: start})
+		default:
+			start := i
+			op := ""
+			for _, candidate := range []string{"//", "::", "..", "!=", "<=", ">=", "/", "[", "]", "(", ")", "@", ",", "|", ".", "=", "<", ">", "+", "-", "*"} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, xerrors.Errorf("unexpected character %q at position %d", r, i)
+			}
+			i += len(op)
+			tokens = append(tokens, token{kind: tokOperator, value: op, pos: start})
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(expr)}), nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isNameChar(r rune) bool {
+	return isNameStart(r) || isDigit(r) || r == '-' || r == '.' || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// scanName returns the NCName at the beginning of s.
+func scanName(s string) string {
+	for i, r := range s {
+		if i == 0 && !isNameStart(r) {
+			return ""
+		}
+		if !isNameChar(r) {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// Expr is a compiled XPath expression.
+type Expr struct {
+	source string
+	root   node
+}
+
+// Compile compiles the XPath expression.
+func Compile(expr string) (*Expr, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid xpath expression %q: %w", expr, err)
+	}
+	p := parser{tokens: tokens}
+	root, err := p.parseExpr()
+	if err == nil && p.peek().kind != tokEOF {
+		err = p.unexpected()
+	}
+	if err != nil {
+		return nil, xerrors.Errorf("invalid xpath expression %q: %w", expr, err)
+	}
+	return &Expr{source: expr, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expr) String() string {
+	return e.source
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOperator(values ...string) bool {
+	t := p.peek()
+	if t.kind != tokOperator {
+		return false
+	}
+	for _, v := range values {
+		if t.value == v {
+			return true
+		}
+	}
+	return false
+}
+
+// isOperatorName reports whether the next token is an operator name (and, or, div, mod) or the multiply operator.
+// These tokens are only operators if the previous token is not an operator itself.
+func (p *parser) isOperatorName(name string) bool {
+	t := p.peek()
+	if name == "*" {
+		if t.kind != tokOperator || t.value != "*" {
+			return false
+		}
+	} else if t.kind != tokName || t.value != name {
+		return false
+	}
+	if p.pos == 0 {
+		return false
+	}
+	prev := p.tokens[p.pos-1]
+	if prev.kind != tokOperator {
+		return true
+	}
+	switch prev.value {
+	case ")", "]", ".", "..":
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) error {
+	if !p.isOperator(value) {
+		return xerrors.Errorf("expected %q at position %d", value, p.peek().pos)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokEOF {
+		return xerrors.New("unexpected end of expression")
+	}
+	return xerrors.Errorf("unexpected %q at position %d", t.value, t.pos)
+}
+
+func (p *parser) parseExpr() (node, error) {
+	return p.parseBinary(0)
+}
+
+// binaryLevels holds the binary operators ordered by their precedence (lowest first).
+var binaryLevels = [][]string{
+	{"or"},
+	{"and"},
+	{"=", "!="},
+	{"<", "<=", ">", ">="},
+	{"+", "-"},
+	{"*", "div", "mod"},
+}
+
+func (p *parser) parseBinary(level int) (node, error) {
+	if level == len(binaryLevels) {
+		return p.parseUnary()
+	}
+	left, err := p.parseBinary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.matchBinary(binaryLevels[level])
+		if op == "" {
+			return left, nil
+		}
+		right, err := p.parseBinary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *parser) matchBinary(ops []string) string {
+	for _, op := range ops {
+		switch op {
+		case "or", "and", "div", "mod", "*":
+			if p.isOperatorName(op) {
+				p.next()
+				return op
+			}
+		default:
+			if p.isOperator(op) {
+				p.next()
+				return op
+			}
+		}
+	}
+	return ""
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("-") {
+		p.next()
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negateNode{expr: n}, nil
+	}
+	return p.parseUnion()
+}
+
+func (p *parser) parseUnion() (node, error) {
+	left, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("|") {
+		p.next()
+		right, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		left = &unionNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+var nodeTypes = map[string]bool{"node": true, "text": true, "comment": true, "processing-instruction": true}
+
+func (p *parser) startsFilterExpr() bool {
+	t := p.peek()
+	switch t.kind {
+	case tokString, tokNumber, tokVariable:
+		return true
+	case tokName:
+		next := p.peekAt(1)
+		return next.kind == tokOperator && next.value == "(" && !nodeTypes[t.value]
+	case tokOperator:
+		return t.value == "("
+	}
+	return false
+}
+
+func (p *parser) parsePath() (node, error) {
+	if !p.startsFilterExpr() {
+		return p.parseLocationPath()
+	}
+
+	primary, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	predicates, err := p.parsePredicates()
+	if err != nil {
+		return nil, err
+	}
+	var filter node = primary
+	if len(predicates) > 0 {
+		filter = &filterNode{expr: primary, predicates: predicates}
+	}
+	if !p.isOperator("/", "//") {
+		return filter, nil
+	}
+	path := &pathNode{filter: filter}
+	if err := p.parseRelativeSteps(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (p *parser) parseLocationPath() (node, error) {
+	path := &pathNode{}
+	switch {
+	case p.isOperator("/"):
+		p.next()
+		path.absolute = true
+		if !p.startsStep() {
+			return path, nil
+		}
+	case p.isOperator("//"):
+		p.next()
+		path.absolute = true
+		path.steps = append(path.steps, &step{axis: "descendant-or-self", test: nodeTest{kind: "node"}})
+	}
+	s, err := p.parseStep()
+	if err != nil {
+		return nil, err
+	}
+	path.steps = append(path.steps, s)
+	if err := p.parseRelativeSteps(path); err != nil {
+		return nil, err
+	}
+	return path, nil
+}
+
+func (p *parser) parseRelativeSteps(path *pathNode) error {
+	for p.isOperator("/", "//") {
+		if p.next().value == "//" {
+			path.steps = append(path.steps, &step{axis: "descendant-or-self", test: nodeTest{kind: "node"}})
+		}
+		s, err := p.parseStep()
+		if err != nil {
+			return err
+		}
+		path.steps = append(path.steps, s)
+	}
+	return nil
+}
+
+func (p *parser) startsStep() bool {
+	t := p.peek()
+	switch t.kind {
+	case tokName:
+		return true
+	case tokOperator:
+		switch t.value {
+		case ".", "..", "@", "*":
+			return true
+		}
+	}
+	return false
+}
+
+var axes = map[string]bool{
+	"ancestor":           true,
+	"ancestor-or-self":   true,
+	"attribute":          true,
+	"child":              true,
+	"descendant":         true,
+	"descendant-or-self": true,
+	"following":          true,
+	"following-sibling":  true,
+	"namespace":          true,
+	"parent":             true,
+	"preceding":          true,
+	"preceding-sibling":  true,
+	"self":               true,
+}
+
+func (p *parser) parseStep() (*step, error) {
+	if p.isOperator(".") {
+		p.next()
+		return &step{axis: "self", test: nodeTest{kind: "node"}}, nil
+	}
+	if p.isOperator("..") {
+		p.next()
+		return &step{axis: "parent", test: nodeTest{kind: "node"}}, nil
+	}
+
+	s := &step{axis: "child"}
+	switch {
+	case p.isOperator("@"):
+		p.next()
+		s.axis = "attribute"
+	case p.peek().kind == tokName && p.peekAt(1).kind == tokOperator && p.peekAt(1).value == "::":
+		t := p.next()
+		if !axes[t.value] {
+			return nil, xerrors.Errorf("unknown axis %q at position %d", t.value, t.pos)
+		}
+		if t.value == "namespace" {
+			return nil, xerrors.Errorf("the namespace axis is not supported")
+		}
+		s.axis = t.value
+		p.next()
+	}
+
+	test, err := p.parseNodeTest()
+	if err != nil {
+		return nil, err
+	}
+	s.test = test
+	s.predicates, err = p.parsePredicates()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *parser) parseNodeTest() (nodeTest, error) {
+	t := p.peek()
+	if t.kind == tokOperator && t.value == "*" {
+		p.next()
+		return nodeTest{kind: "name", local: "*"}, nil
+	}
+	if t.kind != tokName {
+		return nodeTest{}, p.unexpected()
+	}
+	p.next()
+
+	if nodeTypes[t.value] && p.isOperator("(") {
+		p.next()
+		test := nodeTest{kind: t.value}
+		if t.value == "processing-instruction" && p.peek().kind == tokString {
+			test.local = p.next().value
+		}
+		if err := p.expect(")"); err != nil {
+			return nodeTest{}, err
+		}
+		return test, nil
+	}
+
+	test := nodeTest{kind: "name", local: t.value}
+	if i := strings.IndexByte(t.value, ':'); i >= 0 {
+		test.prefix = t.value[:i]
+		test.local = t.value[i+1:]
+	}
+	return test, nil
+}
+
+func (p *parser) parsePredicates() ([]node, error) {
+	var predicates []node
+	for p.isOperator("[") {
+		p.next()
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, expr)
+	}
+	return predicates, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokString:
+		return stringNode(t.value), nil
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid number %q at position %d", t.value, t.pos)
+		}
+		return numberNode(f), nil
+	case tokVariable:
+		return nil, xerrors.Errorf("variables are not supported (position %d)", t.pos)
+	case tokOperator:
+		// (expr)
+		expr, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+
+	// function call
+	fn, ok := functions[t.value]
+	if !ok {
+		return nil, xerrors.Errorf("unknown function %q at position %d", t.value, t.pos)
+	}
+	p.next() // (
+	call := &callNode{name: t.value, fn: fn}
+	for !p.isOperator(")") {
+		if len(call.args) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	p.next()
+	if len(call.args) < fn.minArgs || (fn.maxArgs >= 0 && len(call.args) > fn.maxArgs) {
+		return nil, xerrors.Errorf("wrong number of arguments for %s()", t.value)
+	}
+	return call, nil
+}
@@ -0,0 +1,140 @@
+package xpath
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// XML returns the xml representation of the node.
+// The namespace declarations that are in scope are added to the outermost element, so the result can be decoded on
+// its own (e.g. with xml.Unmarshal).
+func XML(n *Node) string {
+	var buf bytes.Buffer
+	serialize(&buf, n, nil, -1)
+	return buf.String()
+}
+
+// Indent returns the xml representation of the node indented with two spaces, whitespace only text is removed.
+func Indent(n *Node) string {
+	var buf bytes.Buffer
+	serialize(&buf, n, nil, 0)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// serialize writes the node to buf, scope holds the namespace declarations of the parent element (nil for the
+// outermost element). A negative depth disables the indentation.
+func serialize(buf *bytes.Buffer, n *Node, scope map[string]string, depth int) {
+	switch n.Type {
+	case DocumentNode:
+		for _, c := range n.Children {
+			serialize(buf, c, nil, depth)
+		}
+		return
+	case ElementNode:
+		serializeElement(buf, n, scope, depth)
+		return
+	case AttributeNode:
+		buf.WriteString(formatAttribute(n.QualifiedName(), n.Data))
+	case TextNode:
+		if depth >= 0 {
+			writeIndent(buf, depth)
+			textEscape(buf, strings.TrimSpace(n.Data))
+		} else {
+			textEscape(buf, n.Data)
+		}
+	case CommentNode:
+		writeIndent(buf, depth)
+		buf.WriteString("<!--")
+		buf.WriteString(n.Data)
+		buf.WriteString("-->")
+	case ProcInstNode:
+		writeIndent(buf, depth)
+		buf.WriteString("<?")
+		buf.WriteString(n.Name.Local)
+		if n.Data != "" {
+			buf.WriteByte(' ')
+			buf.WriteString(n.Data)
+		}
+		buf.WriteString("?>")
+	}
+	if depth >= 0 {
+		buf.WriteByte('\n')
+	}
+}
+
+func serializeElement(buf *bytes.Buffer, n *Node, scope map[string]string, depth int) {
+	writeIndent(buf, depth)
+	buf.WriteByte('<')
+	buf.WriteString(n.QualifiedName())
+
+	// namespace declarations that are not declared by the parent
+	var prefixes []string
+	for prefix, uri := range n.namespaces {
+		if prefix == "xml" {
+			continue
+		}
+		if parentURI, ok := scope[prefix]; !ok || parentURI != uri {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		name := "xmlns"
+		if prefix != "" {
+			name += ":" + prefix
+		}
+		buf.WriteByte(' ')
+		buf.WriteString(formatAttribute(name, n.namespaces[prefix]))
+	}
+	for _, a := range n.Attr {
+		buf.WriteByte(' ')
+		buf.WriteString(formatAttribute(a.QualifiedName(), a.Data))
+	}
+
+	children := n.Children
+	if depth >= 0 {
+		children = nil
+		for _, c := range n.Children {
+			if c.Type != TextNode || strings.TrimSpace(c.Data) != "" {
+				children = append(children, c)
+			}
+		}
+	}
+
+	switch {
+	case len(children) == 0:
+		buf.WriteString("/>")
+	case depth >= 0 && len(children) == 1 && children[0].Type == TextNode:
+		buf.WriteByte('>')
+		textEscape(buf, strings.TrimSpace(children[0].Data))
+		writeEndElement(buf, n)
+	default:
+		buf.WriteByte('>')
+		childDepth := -1
+		if depth >= 0 {
+			buf.WriteByte('\n')
+			childDepth = depth + 1
+		}
+		for _, c := range children {
+			serialize(buf, c, n.namespaces, childDepth)
+		}
+		writeIndent(buf, depth)
+		writeEndElement(buf, n)
+	}
+	if depth >= 0 {
+		buf.WriteByte('\n')
+	}
+}
+
+func writeEndElement(buf *bytes.Buffer, n *Node) {
+	buf.WriteString("</")
+	buf.WriteString(n.QualifiedName())
+	buf.WriteByte('>')
+}
+
+func writeIndent(buf *bytes.Buffer, depth int) {
+	if depth > 0 {
+		buf.WriteString(strings.Repeat("  ", depth))
+	}
+}
@@ -0,0 +1,251 @@
+package xpath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const feed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <title>Example Feed</title>
+  <!-- entries -->
+  <entry id="1" lang="en">
+    <title>Hello</title>
+    <media:thumbnail url="a.png"/>
+    <rating>4.5</rating>
+  </entry>
+  <entry id="2">
+    <title><![CDATA[World]]></title>
+    <rating>3</rating>
+  </entry>
+  <entry id="3">
+    <title>  Foo   Bar </title>
+    <rating>1.5</rating>
+  </entry>
+</feed>`
+
+func evaluate(t *testing.T, doc *Node, expr string) interface{} {
+	e, err := Compile(expr)
+	require.NoError(t, err)
+	v, err := e.Evaluate(doc)
+	require.NoError(t, err)
+	return v
+}
+
+func nodeStrings(ns interface{}) []string {
+	var result []string
+	for _, n := range ns.(NodeSet) {
+		result = append(result, n.String())
+	}
+	return result
+}
+
+func TestEvaluate(t *testing.T) {
+	doc, err := ParseString(feed)
+	require.NoError(t, err)
+
+	tests := []struct {
+		expr     string
+		expected interface{}
+	}{
+		{"/feed/entry[1]/title", []string{"Hello"}},
+		{"/feed/entry/title", []string{"Hello", "World", "  Foo   Bar "}},
+		{"//title", []string{"Example Feed", "Hello", "World", "  Foo   Bar "}},
+		{"/feed/entry[last()]/@id", []string{"3"}},
+		{"/feed/entry[@id='2']/title", []string{"World"}},
+		{"/feed/entry[rating > 2]/@id", []string{"1", "2"}},
+		{"/feed/entry[position() >= 2][1]/@id", []string{"2"}},
+		{"//media:thumbnail/@url", []string{"a.png"}},
+		{"//entry/*[local-name() = 'thumbnail']/@url", []string{"a.png"}},
+		{"//title[. = 'World']/../@id", []string{"2"}},
+		{"//entry[@lang]/following-sibling::entry/@id", []string{"2", "3"}},
+		{"//entry[@id='3']/preceding-sibling::entry[1]/@id", []string{"2"}},
+		{"//rating/ancestor::entry[1]/@id", []string{"1", "2", "3"}},
+		{"(//entry)[2]/@id", []string{"2"}},
+		{"//entry[1]/@id | //entry[3]/@id", []string{"1", "3"}},
+		{"/feed/comment()", []string{" entries "}},
+		{"/feed/entry[2]/title/text()", []string{"World"}},
+		{"/feed/nothing", []string(nil)},
+		{"count(//entry)", 3.0},
+		{"sum(//rating)", 9.0},
+		{"sum(//rating) div count(//rating)", 3.0},
+		{"7 mod 3", 1.0},
+		{"-2 * -3", 6.0},
+		{"round(2.5) + floor(1.7) + ceiling(1.2)", 6.0},
+		{"string(/feed/entry/title)", "Hello"},
+		{"normalize-space(//entry[3]/title)", "Foo Bar"},
+		{"concat(name(//media:thumbnail), '!')", "media:thumbnail!"},
+		{"substring('12345', 2, 3)", "234"},
+		{"substring-before('a/b', '/')", "a"},
+		{"substring-after('a/b', '/')", "b"},
+		{"translate('bar', 'abc', 'AB')", "BAr"},
+		{"string-length('äbc')", 3.0},
+		{"contains(/feed/title, 'Feed') and starts-with(/feed/title, 'Ex')", true},
+		{"not(//entry[@id = 4]) or false()", true},
+		{"//entry/@id = 2", true},
+		{"//entry/@id != 1", true},
+		{"//rating < 1", false},
+		{"number('abc')", math.NaN()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			v := evaluate(t, doc, test.expr)
+			switch expected := test.expected.(type) {
+			case []string:
+				require.Equal(t, expected, nodeStrings(v))
+			case float64:
+				if math.IsNaN(expected) {
+					require.True(t, math.IsNaN(v.(float64)))
+					return
+				}
+				require.Equal(t, expected, v)
+			default:
+				require.Equal(t, expected, v)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"/feed/",
+		"/feed[",
+		"foo(1)",
+		"$var",
+		"'abc",
+		"count()",
+		"bogus::node()",
+		"/feed#",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Compile(expr)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEvaluate_UnknownPrefix(t *testing.T) {
+	doc, err := ParseString(feed)
+	require.NoError(t, err)
+	e, err := Compile("//foo:bar")
+	require.NoError(t, err)
+	_, err = e.Evaluate(doc)
+	require.EqualError(t, err, `unable to evaluate "//foo:bar": unknown namespace prefix "foo"`)
+}
+
+func TestString(t *testing.T) {
+	require.Equal(t, "1", String(1.0))
+	require.Equal(t, "1.5", String(1.5))
+	require.Equal(t, "-0.25", String(-0.25))
+	require.Equal(t, "NaN", String(math.NaN()))
+	require.Equal(t, "Infinity", String(math.Inf(1)))
+	require.Equal(t, "true", String(true))
+	require.Equal(t, "", String(NodeSet{}))
+}
+
+func TestParse(t *testing.T) {
+	_, err := ParseString("")
+	require.EqualError(t, err, "unable to parse xml: document has no root element")
+
+	_, err = ParseString("<a><b></a>")
+	require.Error(t, err)
+
+	doc, err := ParseString(`<?xml version="1.0" encoding="ISO-8859-1"?><a>` + "\xe4" + `</a>`)
+	require.NoError(t, err)
+	require.Equal(t, "ä", doc.String())
+}
+
+func TestEqual(t *testing.T) {
+	mustParse := func(s string) *Node {
+		doc, err := ParseString(s)
+		require.NoError(t, err)
+		return doc
+	}
+
+	actual := mustParse(feed)
+
+	require.True(t, Equal(
+		mustParse(`<a x="1" y="2"><b>Hello</b><!-- comment --><c/></a>`),
+		mustParse(`<a y="2"   x="1">
+			<b>  Hello </b>
+			<c></c>
+		</a>`),
+	))
+	require.False(t, Equal(mustParse(`<a x="1"/>`), mustParse(`<a x="2"/>`)))
+	require.False(t, Equal(mustParse(`<a x="1"/>`), mustParse(`<a x="1" y="2"/>`)))
+	require.False(t, Equal(mustParse(`<a><b/><c/></a>`), mustParse(`<a><c/><b/></a>`)))
+	require.False(t, Equal(mustParse(`<a>Hello</a>`), mustParse(`<a>World</a>`)))
+
+	// expected elements without namespace match any namespace
+	require.True(t, Equal(mustParse(`<a xmlns="urn:a"><b/></a>`), mustParse(`<a><b/></a>`)))
+	require.False(t, Equal(mustParse(`<a xmlns="urn:a"><b/></a>`), mustParse(`<a xmlns="urn:b"><b/></a>`)))
+
+	require.True(t, Contains(actual, mustParse(`<entry id="2"><title>World</title></entry>`)))
+	require.True(t, Contains(actual, mustParse(`<entry><rating>3</rating><title>World</title></entry>`)))
+	require.True(t, Contains(actual, mustParse(`<title>Example Feed</title>`)))
+	require.False(t, Contains(actual, mustParse(`<entry id="2"><title>Hello</title></entry>`)))
+	require.False(t, Contains(actual, mustParse(`<entry><title>Hello</title><title>Hello</title></entry>`)))
+}
+
+func TestFormat(t *testing.T) {
+	doc, err := ParseString(`<a y="2" x="&lt;1&gt;"><!-- comment -->
+		<b>  Hello &amp; World </b>
+		<c/>
+	</a>`)
+	require.NoError(t, err)
+	require.Equal(t, `<a x="&lt;1&gt;" y="2">
+  <b>Hello &amp; World</b>
+  <c/>
+</a>`, Format(doc))
+
+	doc, err = ParseString(feed)
+	require.NoError(t, err)
+	e, err := Compile("//entry[1]")
+	require.NoError(t, err)
+	ns, err := e.Select(doc)
+	require.NoError(t, err)
+	require.Equal(t, `<entry id="1" lang="en" xmlns="http://www.w3.org/2005/Atom">
+  <title>Hello</title>
+  <media:thumbnail url="a.png"/>
+  <rating>4.5</rating>
+</entry>`, Format(ns[0]))
+}
+
+func TestXML(t *testing.T) {
+	doc, err := ParseString(feed)
+	require.NoError(t, err)
+
+	e, err := Compile("//entry[1]")
+	require.NoError(t, err)
+	ns, err := e.Select(doc)
+	require.NoError(t, err)
+	require.Equal(t, `<entry xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/" id="1" lang="en">
+    <title>Hello</title>
+    <media:thumbnail url="a.png"/>
+    <rating>4.5</rating>
+  </entry>`, XML(ns[0]))
+
+	doc, err = ParseString(`<?xml version="1.0"?><a x="1"><!-- comment --><b>Hello &amp; World</b><c/><?pi data?></a>`)
+	require.NoError(t, err)
+	require.Equal(t, `<a x="1"><!-- comment --><b>Hello &amp; World</b><c/><?pi data?></a>`, XML(doc))
+}
+
+func TestIndent(t *testing.T) {
+	doc, err := ParseString(`<?xml version="1.0"?><s:a xmlns:s="urn:s" x="1"><!-- comment -->
+		<s:b>  Hello  </s:b><c><d>Text<e/></d></c></s:a>`)
+	require.NoError(t, err)
+	require.Equal(t, `<s:a xmlns:s="urn:s" x="1">
+  <!-- comment -->
+  <s:b>Hello</s:b>
+  <c>
+    <d>
+      Text
+      <e/>
+    </d>
+  </c>
+</s:a>`, Indent(doc))
+}
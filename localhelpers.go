@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 func boolSliceToInterfaceSlice(params []bool) []interface{} {
@@ -160,6 +161,30 @@ func requestSliceToInterfaceSlice(params []*http.Request) []interface{} {
 	return iface
 }
 
+func cookieSliceToInterfaceSlice(params []*http.Cookie) []interface{} {
+	iface := make([]interface{}, len(params))
+	for i, v := range params {
+		iface[i] = v
+	}
+	return iface
+}
+
+func timeSliceToInterfaceSlice(params []time.Time) []interface{} {
+	iface := make([]interface{}, len(params))
+	for i, v := range params {
+		iface[i] = v
+	}
+	return iface
+}
+
+func durationSliceToInterfaceSlice(params []time.Duration) []interface{} {
+	iface := make([]interface{}, len(params))
+	for i, v := range params {
+		iface[i] = v
+	}
+	return iface
+}
+
 //nolint:deadcode,unused // keep this in for completion
 func getLastInterfaceArgument(params []interface{}) (interface{}, bool) {
 	if i := len(params); i > 0 {
@@ -0,0 +1,136 @@
+package hit
+
+import (
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/minitest"
+	"github.com/Eun/go-hit/internal/openapi"
+)
+
+// OpenAPI validates the request and the response against an OpenAPI 3 document.
+// spec can be a path to a YAML or JSON file (string) or the content of the document ([]byte).
+//
+// The request will be matched to an operation of the document by its method and path (path templates and the paths of
+// the servers are supported). Before the request is sent the path, query, header and cookie parameters and the
+// request body will be validated, after the response was received the status, the headers and the body will be
+// validated against the responses of the operation.
+// Only local references ($ref) are supported for the OpenAPI objects, JSON bodies will be validated with the schema of
+// the matching media type.
+//
+// The document will be loaded once, so the step can be passed to NewSession() to validate all requests of a Session.
+//
+// Example:
+//     spec := []byte(`{
+//         "openapi": "3.1.0",
+//         "info": {"title": "Users", "version": "1.0.0"},
+//         "paths": {
+//             "/json": {
+//                 "get": {
+//                     "operationId": "getUser",
+//                     "responses": {
+//                         "200": {
+//                             "description": "the user",
+//                             "content": {
+//                                 "application/json": {
+//                                     "schema": {
+//                                         "type": "object",
+//                                         "required": ["ID", "Name"],
+//                                         "properties": {"ID": {"type": "integer"}, "Name": {"type": "string"}}
+//                                     }
+//                                 }
+//                             }
+//                         }
+//                     }
+//                 }
+//             }
+//         }
+//     }`)
+//     MustDo(
+//         OpenAPI(spec),
+//         Get("https://example.com/json"),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+func OpenAPI(spec interface{}) IStep {
+	var once sync.Once
+	var doc *openapi.Document
+	var loadErr error
+
+	trace := ett.Prepare()
+	return &hitStep{
+		Trace:    trace,
+		When:     combineStep,
+		CallPath: newCallPath("OpenAPI", nil),
+		Exec: func(hit *hitImpl) error {
+			once.Do(func() {
+				doc, loadErr = openapi.Load(spec)
+			})
+			if loadErr != nil {
+				return loadErr
+			}
+
+			var op *openapi.Operation
+			hit.InsertSteps(
+				&hitStep{
+					Trace:    trace,
+					When:     AfterSendStep,
+					CallPath: newCallPath("OpenAPI", nil),
+					Exec: func(hit *hitImpl) error {
+						var pathValues map[string]string
+						var err error
+						req := hit.request.Request
+						op, pathValues, err = doc.FindOperation(req.Method, req.URL.Path)
+						if err != nil {
+							return err
+						}
+						body, err := hit.request.Body().Bytes()
+						if err != nil {
+							return err
+						}
+						violations, err := doc.ValidateRequest(op, req, pathValues, body)
+						if err != nil {
+							return err
+						}
+						return openAPIViolations("request", op, violations)
+					},
+				},
+				&hitStep{
+					Trace:    trace,
+					When:     ExpectStep,
+					CallPath: newCallPath("OpenAPI", nil),
+					Exec: func(hit *hitImpl) error {
+						if op == nil {
+							return nil
+						}
+						body, err := hit.response.Body().Bytes()
+						if err != nil {
+							return err
+						}
+						violations, err := doc.ValidateResponse(op, hit.response.Response, body)
+						if err != nil {
+							return err
+						}
+						return openAPIViolations("response", op, violations)
+					},
+				},
+			)
+			return nil
+		},
+	}
+}
+
+func openAPIViolations(what string, op *openapi.Operation, violations []openapi.Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	lines := make([]string, len(violations))
+	for i := range violations {
+		lines[i] = violations[i].String()
+	}
+	return xerrors.Errorf(
+		"%s does not match the OpenAPI operation %s\n%s",
+		what, op.String(), minitest.Format("violations:", strings.Join(lines, "\n")),
+	)
+}
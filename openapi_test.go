@@ -0,0 +1,160 @@
+package hit_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+const openAPISpec = `
+openapi: 3.1.0
+info:
+  title: Users
+  version: 1.0.0
+paths:
+  /users:
+    post:
+      operationId: createUser
+      parameters:
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/User'
+      responses:
+        200:
+          description: the created user
+          headers:
+            X-Request-ID:
+              required: true
+              schema:
+                type: string
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - name: id
+          in: path
+          schema:
+            type: integer
+      responses:
+        404:
+          description: not found
+components:
+  schemas:
+    User:
+      type: object
+      required: [ID, Name]
+      properties:
+        ID:
+          type: integer
+        Name:
+          type: string
+`
+
+func TestOpenAPI(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("valid", func(t *testing.T) {
+		Test(t,
+			OpenAPI([]byte(openAPISpec)),
+			Post(s.URL+"/users"),
+			Send().Headers("X-Request-ID").Add("1"),
+			Send().Headers("Content-Type").Add("application/json"),
+			Send().Body().JSON(map[string]interface{}{"ID": 10, "Name": "Joe"}),
+			Expect().Status().Equal(http.StatusOK),
+		)
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				OpenAPI([]byte(openAPISpec)),
+				Post(s.URL+"/users"),
+				Send().Body().JSON(map[string]interface{}{"ID": "10"}),
+			),
+			PtrStr(`request does not match the OpenAPI operation "createUser" (POST /users)`),
+			PtrStr(`violations: header parameter "X-Request-ID": /paths/~1users/post/parameters/0: parameter is required`),
+			PtrStr(`request body: /paths/~1users/post/requestBody/content/application~1json/schema/$ref/required: missing required property "Name"`),
+			PtrStr(`request body /ID: /paths/~1users/post/requestBody/content/application~1json/schema/$ref/properties/ID/type: expected integer, but got string`),
+		)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				OpenAPI([]byte(openAPISpec)),
+				Get(s.URL+"/users/10"),
+			),
+			PtrStr(`response does not match the OpenAPI operation "getUser" (GET /users/{id})`),
+			PtrStr(`violations: response status: /paths/~1users~1{id}/get/responses: status 200 is not documented`),
+		)
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				OpenAPI([]byte(openAPISpec)),
+				Delete(s.URL+"/users/10"),
+			),
+			PtrStr("method DELETE is not allowed for /users/10"),
+		)
+		ExpectError(t,
+			Do(
+				OpenAPI([]byte(openAPISpec)),
+				Get(s.URL+"/groups"),
+			),
+			PtrStr("no operation matches GET /groups"),
+		)
+	})
+
+	t.Run("session", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "openapi")
+		require.NoError(t, err)
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "openapi.yaml")
+		require.NoError(t, ioutil.WriteFile(path, []byte(openAPISpec), 0o600))
+
+		session := NewSession(OpenAPI(path))
+		session.MustDo(
+			Post(s.URL+"/users"),
+			Send().Headers("X-Request-ID").Add("1"),
+			Send().Headers("Content-Type").Add("application/json"),
+			Send().Body().JSON(map[string]interface{}{"ID": 10, "Name": "Joe"}),
+		)
+		ExpectError(t,
+			session.Do(
+				Get(s.URL+"/users/joe"),
+			),
+			PtrStr(`request does not match the OpenAPI operation "getUser" (GET /users/{id})`),
+			PtrStr(`violations: path parameter "id": /paths/~1users~1{id}/get/parameters/0/schema/type: expected integer, but got string`),
+		)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				OpenAPI("does-not-exist.yaml"),
+				Get(s.URL+"/users/10"),
+			),
+			nil,
+		)
+	})
+}
@@ -0,0 +1,96 @@
+package hit
+
+import (
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// defaultMaxRedirects is the amount of redirects http.Client follows if no CheckRedirect function was set.
+const defaultMaxRedirects = 10
+
+// FollowRedirects sets whether redirects should be followed, if disabled the first redirect response will be
+// returned.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com/cookies/set/session/abc123"),
+//         FollowRedirects(false),
+//         Expect().Status().Equal(http.StatusFound),
+//         Expect().Headers("Location").Equal("/cookies"),
+//     )
+func FollowRedirects(follow bool) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("FollowRedirects", nil),
+		Exec: func(hit *hitImpl) error {
+			client := *hit.client
+			client.CheckRedirect = nil
+			if !follow {
+				client.CheckRedirect = func(*http.Request, []*http.Request) error {
+					return http.ErrUseLastResponse
+				}
+			}
+			hit.client = &client
+			return nil
+		},
+	}
+}
+
+// MaxRedirects sets the maximum number of redirects that should be followed, the request will fail if more redirects
+// occur. MaxRedirects(0) does not follow any redirect, the first redirect response will be returned.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com/cookies/set/session/abc123"),
+//         MaxRedirects(1),
+//         Expect().Redirects().Count().Equal(1),
+//     )
+func MaxRedirects(n int) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("MaxRedirects", nil),
+		Exec: func(hit *hitImpl) error {
+			if n < 0 {
+				return xerrors.Errorf("max redirects must not be negative, got %d", n)
+			}
+			client := *hit.client
+			client.CheckRedirect = func(_ *http.Request, via []*http.Request) error {
+				if n == 0 {
+					return http.ErrUseLastResponse
+				}
+				if len(via) > n {
+					return xerrors.Errorf("stopped after %d redirects", n)
+				}
+				return nil
+			}
+			hit.client = &client
+			return nil
+		},
+	}
+}
+
+// recordRedirects returns a copy of the client that appends the responses of all followed redirects to redirects.
+// The CheckRedirect function of the client will still be honored.
+func recordRedirects(client *http.Client, redirects *[]*http.Response) *http.Client {
+	checkRedirect := client.CheckRedirect
+	if checkRedirect == nil {
+		checkRedirect = func(_ *http.Request, via []*http.Request) error {
+			if len(via) >= defaultMaxRedirects {
+				return xerrors.Errorf("stopped after %d redirects", defaultMaxRedirects)
+			}
+			return nil
+		}
+	}
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := checkRedirect(req, via); err != nil {
+			return err
+		}
+		*redirects = append(*redirects, req.Response)
+		return nil
+	}
+	return &c
+}
@@ -0,0 +1,99 @@
+package hit_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+// RedirectServer serves a redirect chain: /a redirects to /b, /b redirects to /c.
+func RedirectServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(writer http.ResponseWriter, request *http.Request) {
+		http.Redirect(writer, request, "/b", http.StatusFound)
+	})
+	mux.HandleFunc("/b", func(writer http.ResponseWriter, request *http.Request) {
+		http.Redirect(writer, request, "/c", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/c", func(writer http.ResponseWriter, request *http.Request) {
+		_, _ = io.WriteString(writer, "Hello World")
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFollowRedirects(t *testing.T) {
+	s := RedirectServer()
+	defer s.Close()
+
+	t.Run("follow", func(t *testing.T) {
+		Test(t,
+			Get(s.URL+"/a"),
+			FollowRedirects(true),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("Hello World"),
+		)
+	})
+
+	t.Run("do not follow", func(t *testing.T) {
+		client := &http.Client{}
+		Test(t,
+			Get(s.URL+"/a"),
+			HTTPClient(client),
+			FollowRedirects(false),
+			Expect().Status().Equal(http.StatusFound),
+			Expect().Headers("Location").Equal("/b"),
+			Expect().Redirects().Count().Equal(0),
+		)
+		require.Nil(t, client.CheckRedirect)
+	})
+
+	t.Run("follow after disabling", func(t *testing.T) {
+		Test(t,
+			Get(s.URL+"/a"),
+			FollowRedirects(false),
+			FollowRedirects(true),
+			Expect().Status().Equal(http.StatusOK),
+		)
+	})
+}
+
+func TestMaxRedirects(t *testing.T) {
+	s := RedirectServer()
+	defer s.Close()
+
+	Test(t,
+		Get(s.URL+"/a"),
+		MaxRedirects(2),
+		Expect().Status().Equal(http.StatusOK),
+		Expect().Redirects().Count().Equal(2),
+	)
+
+	Test(t,
+		Get(s.URL+"/a"),
+		MaxRedirects(0),
+		Expect().Status().Equal(http.StatusFound),
+		Expect().Headers("Location").Equal("/b"),
+		Expect().Redirects().Count().Equal(0),
+	)
+
+	ExpectError(t,
+		Do(
+			Get(s.URL+"/a"),
+			MaxRedirects(1),
+		),
+		PtrStr(`unable to perform request: Get "/c": stopped after 1 redirects`),
+	)
+
+	ExpectError(t,
+		Do(
+			Get(s.URL+"/a"),
+			MaxRedirects(-1),
+		),
+		PtrStr("max redirects must not be negative, got -1"),
+	)
+}
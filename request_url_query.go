@@ -9,9 +9,11 @@ import (
 // IRequestURLQuery provides methods to send header/trailer.
 type IRequestURLQuery interface {
 	// Add adds the specified value to the url query.
+	// Placeholders (e.g. {{.page}}) will be replaced with the corresponding variables.
 	//
 	// Usage:
 	//     Request().URL().Query("page").Add(1)
+	//     Request().URL().Query("page").Add("{{.page}}")
 	Add(value ...interface{}) IStep
 }
 
@@ -42,6 +44,10 @@ func (v *requestURLQuery) Add(values ...interface{}) IStep {
 				if err := converter.Convert(value, &s); err != nil {
 					return err
 				}
+				s, err := hit.interpolate(s)
+				if err != nil {
+					return err
+				}
 				rawQuery, queryValues := v.valueCallback(hit)
 				queryValues.Add(v.name, s)
 				*rawQuery = queryValues.Encode()
@@ -0,0 +1,138 @@
+package hit
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultRetryMaxAttempts is the amount of attempts that will be used if neither MaxAttempts nor Timeout was
+// specified in the RetryPolicy.
+const defaultRetryMaxAttempts = 3
+
+// RetryPolicy defines how often and how long a request should be retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts (including the first one), 0 means unlimited as long as
+	// Timeout is set.
+	MaxAttempts int
+	// Interval is the time to wait between the first and the second attempt.
+	Interval time.Duration
+	// MaxInterval limits the time to wait between two attempts, 0 means no limit.
+	MaxInterval time.Duration
+	// Multiplier is used to increase the Interval after each attempt, values less or equal than 1 result in a
+	// constant Interval.
+	Multiplier float64
+	// Timeout is the overall time that can be spent for all attempts, 0 means no timeout.
+	Timeout time.Duration
+}
+
+// Retry re-executes the whole request (everything from creating the request until the last Expect() step) until
+// all steps pass or the RetryPolicy is exhausted.
+// The overall deadline of a Context() step will be honored.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com"),
+//         Retry(RetryPolicy{
+//             MaxAttempts: 5,
+//             Interval:    100 * time.Millisecond,
+//             Multiplier:  2,
+//         }),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+func Retry(policy RetryPolicy) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("Retry", nil),
+		Exec: func(hit *hitImpl) error {
+			p := policy
+			if p.MaxAttempts <= 0 && p.Timeout <= 0 {
+				p.MaxAttempts = defaultRetryMaxAttempts
+			}
+			hit.retry = &p
+			return nil
+		},
+	}
+}
+
+// Eventually re-executes the whole request every interval until all steps pass or the timeout is reached.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com"),
+//         Eventually(time.Second, 100*time.Millisecond),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+func Eventually(timeout, interval time.Duration) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("Eventually", nil),
+		Exec: func(hit *hitImpl) error {
+			hit.retry = &RetryPolicy{
+				Interval: interval,
+				Timeout:  timeout,
+			}
+			return nil
+		},
+	}
+}
+
+// nextInterval returns the time to wait after the specified attempt (starting with 1).
+func (p *RetryPolicy) nextInterval(attempt int) time.Duration {
+	d := p.Interval
+	if p.Multiplier > 1 {
+		f := float64(d)
+		for i := 1; i < attempt; i++ {
+			f *= p.Multiplier
+			if p.MaxInterval > 0 && f > float64(p.MaxInterval) {
+				break
+			}
+		}
+		d = time.Duration(f)
+	}
+	if p.MaxInterval > 0 && d > p.MaxInterval {
+		d = p.MaxInterval
+	}
+	return d
+}
+
+// waitForNextAttempt waits the time that is needed before the next attempt can be started, it returns false if no
+// more attempts should be made.
+func (p *RetryPolicy) waitForNextAttempt(ctx context.Context, start time.Time, attempt int) bool {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return false
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	d := p.nextInterval(attempt)
+	if p.Timeout > 0 && time.Since(start)+d > p.Timeout {
+		return false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// retryError is the error that will be returned if all attempts failed.
+type retryError struct {
+	attempts int
+	err      error
+}
+
+func (e *retryError) Error() string {
+	return fmt.Sprintf("failed after %d attempts, last error: %s", e.attempts, e.err.Error())
+}
+
+func (e *retryError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,301 @@
+package hit_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+// SucceedAfterServer responds with http.StatusServiceUnavailable until the specified amount of requests was made.
+func SucceedAfterServer(n int32, counter *int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := ioutil.ReadAll(request.Body)
+		if atomic.AddInt32(counter, 1) < n {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write(body)
+	})
+	return httptest.NewServer(mux)
+}
+
+// headerTransport adds a X-Layer header to every request.
+type headerTransport struct {
+	next http.RoundTripper
+}
+
+func (t headerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	request.Header.Add("X-Layer", "1")
+	return next.RoundTrip(request)
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("succeed", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(3, &counter)
+		defer s.Close()
+
+		Test(t,
+			Post(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+			Send().Body().String("Hello World"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("Hello World"),
+		)
+		require.Equal(t, int32(3), atomic.LoadInt32(&counter))
+	})
+
+	t.Run("reader body", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(3, &counter)
+		defer s.Close()
+
+		Test(t,
+			Post(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+			Send().Body().Reader(bytes.NewReader([]byte("Hello World"))),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("Hello World"),
+		)
+		require.Equal(t, int32(3), atomic.LoadInt32(&counter))
+	})
+
+	t.Run("every attempt sends the reader body", func(t *testing.T) {
+		var bodies []string
+		s := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			body, _ := ioutil.ReadAll(request.Body)
+			bodies = append(bodies, string(body))
+			writer.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer s.Close()
+
+		_ = Do(
+			Post(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 3, Interval: time.Millisecond}),
+			Send().Body().Reader(bytes.NewReader([]byte("Hello World"))),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.Equal(t, []string{"Hello World", "Hello World", "Hello World"}, bodies)
+	})
+
+	t.Run("consumed reader body", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(3, &counter)
+		defer s.Close()
+
+		reader := bytes.NewReader([]byte("Hello World"))
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+				Send().Custom(func(hit Hit) error {
+					hit.Request().Body().SetReader(reader)
+					return nil
+				}),
+				Expect().Status().Equal(http.StatusOK),
+			),
+			PtrStr("unable to replay the request body: the body reader was consumed by a previous attempt, "+
+				"use Send().Body().Reader() to send a reader multiple times"),
+		)
+		require.Equal(t, int32(1), atomic.LoadInt32(&counter))
+	})
+
+	t.Run("body that changes between attempts", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(2, &counter)
+		defer s.Close()
+
+		attempt := 0
+		Test(t,
+			Post(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+			Send().Custom(func(hit Hit) error {
+				attempt++
+				// only the first attempt sends a body
+				if attempt == 1 {
+					hit.Request().Body().SetString("Hello World")
+				}
+				return nil
+			}),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal(""),
+		)
+		require.Equal(t, int32(2), atomic.LoadInt32(&counter))
+	})
+
+	t.Run("client modifications are not stacked", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(3, &counter)
+		defer s.Close()
+
+		Test(t,
+			Post(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+			Send().Custom(func(hit Hit) error {
+				client := *hit.HTTPClient()
+				client.Transport = headerTransport{next: client.Transport}
+				return hit.SetHTTPClient(&client)
+			}),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Custom(func(hit Hit) error {
+				require.Len(t, hit.Request().Header["X-Layer"], 1)
+				return nil
+			}),
+		)
+	})
+
+	t.Run("inserted steps are not duplicated", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(2, &counter)
+		defer s.Close()
+
+		Test(t,
+			Post(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 5, Interval: time.Millisecond}),
+			Send().Custom(func(hit Hit) error {
+				hit.InsertSteps(Send().Headers("X-Header").Add("Hello"))
+				return nil
+			}),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Custom(func(hit Hit) error {
+				require.Equal(t, []string{"Hello"}, hit.Request().Header["X-Header"])
+				return nil
+			}),
+		)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(10, &counter)
+		defer s.Close()
+
+		err := Do(
+			Get(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 3, Interval: time.Millisecond, Multiplier: 2}),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		ExpectError(t, err,
+			PtrStr("failed after 3 attempts, last error: not equal"),
+			PtrStr("expected: 200"),
+			PtrStr("actual:   503"),
+			nil, nil, nil, nil,
+		)
+		require.Equal(t, int32(3), atomic.LoadInt32(&counter))
+
+		var hitError *Error
+		require.True(t, errors.As(err, &hitError))
+		require.True(t, hitError.FailingStepIs(Expect().Status().Equal(http.StatusOK)))
+	})
+
+	t.Run("request creation errors are not retried", func(t *testing.T) {
+		err := Do(
+			Retry(RetryPolicy{MaxAttempts: 3, Interval: time.Millisecond}),
+		)
+		ExpectError(t, err, PtrStr("unable to create a request: did you called Post(), Get(), ...?"))
+	})
+
+	t.Run("without retry", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(2, &counter)
+		defer s.Close()
+
+		err := Do(
+			Get(s.URL),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		ExpectError(t, err,
+			PtrStr("not equal"),
+			PtrStr("expected: 200"),
+			PtrStr("actual:   503"),
+			nil, nil, nil, nil,
+		)
+		require.Equal(t, int32(1), atomic.LoadInt32(&counter))
+	})
+}
+
+func TestEventually(t *testing.T) {
+	t.Run("succeed", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(3, &counter)
+		defer s.Close()
+
+		Test(t,
+			Get(s.URL),
+			Eventually(time.Second, time.Millisecond),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.Equal(t, int32(3), atomic.LoadInt32(&counter))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(1000, &counter)
+		defer s.Close()
+
+		start := time.Now()
+		err := Do(
+			Get(s.URL),
+			Eventually(100*time.Millisecond, 10*time.Millisecond),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.Error(t, err)
+		require.Less(t, int64(time.Since(start)), int64(time.Second))
+		require.Greater(t, atomic.LoadInt32(&counter), int32(1))
+	})
+
+	t.Run("hanging attempt", func(t *testing.T) {
+		done := make(chan struct{})
+		s := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			select {
+			case <-done:
+			case <-request.Context().Done():
+			}
+		}))
+		defer s.Close()
+		defer close(done)
+
+		start := time.Now()
+		err := Do(
+			Get(s.URL),
+			Eventually(100*time.Millisecond, 10*time.Millisecond),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.Error(t, err)
+		require.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		var counter int32
+		s := SucceedAfterServer(1000, &counter)
+		defer s.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := Do(
+			Context(ctx),
+			Get(s.URL),
+			Eventually(time.Minute, 10*time.Millisecond),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.Error(t, err)
+		require.Less(t, int64(time.Since(start)), int64(time.Second))
+	})
+}
@@ -2,6 +2,8 @@ package hit
 
 import (
 	"net/http"
+
+	"golang.org/x/xerrors"
 )
 
 // ISend provides methods to set request data, such as body or headers.
@@ -19,6 +21,22 @@ type ISend interface {
 	//     )
 	Body() ISendBody
 
+	// Auth authenticates the request.
+	//
+	// Usage:
+	//     Send().Auth().Basic("joe", "secret")
+	//     Send().Auth().Bearer("{{.token}}")
+	//     Send().Auth().Digest("joe", "secret")
+	//     Send().Auth().OAuth2ClientCredentials("https://example.com/oauth2/token", "client", "secret", "read")
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com/basic-auth/joe/secret"),
+	//         Send().Auth().Basic("joe", "secret"),
+	//         Expect().Status().Equal(http.StatusOK),
+	//     )
+	Auth() ISendAuth
+
 	// Headers sets the specified request header to the specified value(s).
 	//
 	// Usage:
@@ -45,6 +63,31 @@ type ISend interface {
 	//     )
 	Trailers(name string) ISendHeaders
 
+	// Cookies sends the specified cookie (using the Cookie header).
+	//
+	// Usage:
+	//     Send().Cookies("session").Set("abc123")
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com"),
+	//         Send().Cookies("session").Set("abc123"),
+	//     )
+	Cookies(name string) ISendCookie
+
+	// Cookie sends the specified cookie (using the Cookie header).
+	// Only the name and the value will be sent, all other attributes are ignored.
+	//
+	// Usage:
+	//     Send().Cookie(&http.Cookie{Name: "session", Value: "abc123"})
+	//
+	// Example:
+	//     MustDo(
+	//         Get("https://example.com"),
+	//         Send().Cookie(&http.Cookie{Name: "session", Value: "abc123"}),
+	//     )
+	Cookie(cookie *http.Cookie) IStep
+
 	// Custom can be used to send a custom behavior.
 	//
 	// Example:
@@ -76,6 +119,10 @@ func (snd *send) Body() ISendBody {
 	return snd.body
 }
 
+func (snd *send) Auth() ISendAuth {
+	return newSendAuth(snd.cleanPath.Push("Auth", nil))
+}
+
 func (snd *send) Headers(name string) ISendHeaders {
 	return newSendHeaders(snd.cleanPath.Push("Headers", []interface{}{name}), func(hit *hitImpl) http.Header {
 		return hit.request.Header
@@ -90,6 +137,25 @@ func (snd *send) Trailers(name string) ISendHeaders {
 	}, name)
 }
 
+func (snd *send) Cookies(name string) ISendCookie {
+	return newSendCookie(snd.cleanPath.Push("Cookies", []interface{}{name}), name)
+}
+
+func (snd *send) Cookie(cookie *http.Cookie) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: snd.cleanPath.Push("Cookie", []interface{}{cookie}),
+		Exec: func(hit *hitImpl) error {
+			if cookie == nil {
+				return xerrors.New("cookie cannot be nil")
+			}
+			hit.Request().AddCookie(cookie)
+			return nil
+		},
+	}
+}
+
 func (snd *send) Custom(fn Callback) IStep {
 	return &hitStep{
 		Trace:    ett.Prepare(),
@@ -0,0 +1,160 @@
+package hit
+
+import (
+	"net/http"
+)
+
+// ISendAuth provides methods to authenticate the request.
+// Credentials that are sent in the Authorization header will be redacted in the Debug() output.
+type ISendAuth interface {
+	// Basic authenticates the request using the Basic authentication scheme.
+	// Placeholders (e.g. {{.password}}) will be replaced with the corresponding variables.
+	//
+	// Usage:
+	//     Send().Auth().Basic("joe", "secret")
+	Basic(username, password string) IStep
+
+	// Bearer authenticates the request using the specified bearer token.
+	// Placeholders (e.g. {{.token}}) will be replaced with the corresponding variables.
+	//
+	// Usage:
+	//     Send().Auth().Bearer("eyJhbGciOiJIUzI1NiJ9.e30.ZRrHA1JJJW8opsbCGfG_HACGpVUMN_a9IV7pAx_Zmeo")
+	//     Send().Auth().Bearer("{{.token}}")
+	Bearer(token string) IStep
+
+	// Digest authenticates the request using the Digest authentication scheme (RFC 7616).
+	// The request will be sent without credentials first, if the server responds with a Digest challenge the request
+	// will be sent again with the computed credentials. The algorithms MD5, SHA-256 (and their -sess variants) and
+	// the qop values auth and auth-int are supported.
+	// Placeholders (e.g. {{.password}}) will be replaced with the corresponding variables.
+	//
+	// Usage:
+	//     Send().Auth().Digest("joe", "secret")
+	Digest(username, password string) IStep
+
+	// OAuth2ClientCredentials fetches an access token from the token endpoint using the OAuth 2.0 client
+	// credentials grant (RFC 6749 section 4.4) and authenticates the request using the token as bearer token.
+	// Tokens are cached (per tokenURL, clientID, clientSecret and scopes) until they expire, so multiple requests
+	// will reuse the same token.
+	// Placeholders (e.g. {{.clientSecret}}) will be replaced with the corresponding variables.
+	//
+	// Usage:
+	//     Send().Auth().OAuth2ClientCredentials("https://example.com/oauth2/token", "client", "secret")
+	//     Send().Auth().OAuth2ClientCredentials("https://example.com/oauth2/token", "client", "secret", "read", "write")
+	OAuth2ClientCredentials(tokenURL, clientID, clientSecret string, scopes ...string) IStep
+}
+
+type sendAuth struct {
+	cleanPath callPath
+}
+
+func newSendAuth(cleanPath callPath) ISendAuth {
+	return &sendAuth{
+		cleanPath: cleanPath,
+	}
+}
+
+// interpolateAll replaces the placeholders in all specified strings.
+func interpolateAll(hit *hitImpl, s ...*string) error {
+	for _, p := range s {
+		v, err := hit.interpolate(*p)
+		if err != nil {
+			return err
+		}
+		*p = v
+	}
+	return nil
+}
+
+func (auth *sendAuth) Basic(username, password string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: auth.cleanPath.Push("Basic", []interface{}{username, password}),
+		Exec: func(hit *hitImpl) error {
+			u, p := username, password
+			if err := interpolateAll(hit, &u, &p); err != nil {
+				return err
+			}
+			hit.Request().SetBasicAuth(u, p)
+			hit.redactHeader("Authorization")
+			return nil
+		},
+	}
+}
+
+func (auth *sendAuth) Bearer(token string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: auth.cleanPath.Push("Bearer", []interface{}{token}),
+		Exec: func(hit *hitImpl) error {
+			t := token
+			if err := interpolateAll(hit, &t); err != nil {
+				return err
+			}
+			hit.Request().Header.Set("Authorization", "Bearer "+t)
+			hit.redactHeader("Authorization")
+			return nil
+		},
+	}
+}
+
+func (auth *sendAuth) Digest(username, password string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: auth.cleanPath.Push("Digest", []interface{}{username, password}),
+		Exec: func(hit *hitImpl) error {
+			u, p := username, password
+			if err := interpolateAll(hit, &u, &p); err != nil {
+				return err
+			}
+			client := *hit.client
+			transport := client.Transport
+			if transport == nil {
+				transport = http.DefaultTransport
+			}
+			// replace the credentials of a previous Digest() step instead of nesting the transports
+			if previous, ok := transport.(*digestTransport); ok {
+				transport = previous.transport
+			}
+			client.Transport = &digestTransport{
+				username:  u,
+				password:  p,
+				transport: transport,
+			}
+			hit.client = &client
+			return nil
+		},
+	}
+}
+
+func (auth *sendAuth) OAuth2ClientCredentials(tokenURL, clientID, clientSecret string, scopes ...string) IStep {
+	args := append([]interface{}{tokenURL, clientID, clientSecret}, stringSliceToInterfaceSlice(scopes)...)
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: auth.cleanPath.Push("OAuth2ClientCredentials", args),
+		Exec: func(hit *hitImpl) error {
+			u, id, secret := tokenURL, clientID, clientSecret
+			if err := interpolateAll(hit, &u, &id, &secret); err != nil {
+				return err
+			}
+			scopeValues := make([]string, len(scopes))
+			for i := range scopes {
+				scopeValues[i] = scopes[i]
+				if err := interpolateAll(hit, &scopeValues[i]); err != nil {
+					return err
+				}
+			}
+			token, err := oauth2ClientCredentialsToken(hit, u, id, secret, scopeValues)
+			if err != nil {
+				return err
+			}
+			hit.Request().Header.Set("Authorization", "Bearer "+token)
+			hit.redactHeader("Authorization")
+			return nil
+		},
+	}
+}
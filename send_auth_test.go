@@ -0,0 +1,371 @@
+package hit_test
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // required by the digest authentication scheme
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lunixbochs/vtclean"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestSendAuth_Basic(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	Test(t,
+		Post(s.URL),
+		Send().Auth().Basic("joe", "secret"),
+		Expect().Headers("Authorization").Equal("Basic am9lOnNlY3JldA=="),
+	)
+
+	session := NewSession(BaseURL(s.URL))
+	session.SetVariable("user", "joe")
+	session.Test(t,
+		Post(""),
+		Send().Auth().Basic("{{.user}}", "secret"),
+		Expect().Headers("Authorization").Equal("Basic am9lOnNlY3JldA=="),
+	)
+}
+
+func TestSendAuth_Bearer(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	Test(t,
+		Post(s.URL),
+		Send().Auth().Bearer("token"),
+		Expect().Headers("Authorization").Equal("Bearer token"),
+	)
+}
+
+func TestDigestAuthorization(t *testing.T) {
+	// examples from RFC 7616 section 3.9.1
+	const challenge = `Digest realm="http-auth@example.org", qop="auth, auth-int", algorithm=%s, ` +
+		`nonce="7ypf/xlj9XXwfDPEoM4URrv/xwf94BcCAzFZH4GiTo0v", opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`
+	const cnonce = "f2/wE4q74E6zIJEtWaHKaf5wv/H5QzzpXusqGemxURZJ"
+
+	tests := []struct {
+		algorithm string
+		response  string
+	}{
+		{"MD5", "8ca523f5e9506fed4657c9700eebdbec"},
+		{"SHA-256", "753927fa0e85d155564e2e272a28d1802ca10daf4496794697cf8db5856cb6c1"},
+	}
+	for _, test := range tests {
+		t.Run(test.algorithm, func(t *testing.T) {
+			authorization, err := DigestAuthorization(
+				fmt.Sprintf(challenge, test.algorithm), http.MethodGet, "/dir/index.html", "Mufasa", "Circle of Life", cnonce,
+			)
+			require.NoError(t, err)
+			require.Contains(t, authorization, `qop=auth,`)
+			require.Contains(t, authorization, `nc=00000001`)
+			require.Contains(t, authorization, fmt.Sprintf(`response="%s"`, test.response))
+			require.Contains(t, authorization, `opaque="FQhe/qaU925kfnzjCev0ciny7QMkPqMAFRtzCUYo5tdS"`)
+		})
+	}
+}
+
+// DigestServer creates a server that requires the Digest authentication (MD5, qop=auth) for joe:secret.
+func DigestServer() *httptest.Server {
+	const realm = "hit"
+	const nonce = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+	md5Hex := func(s string) string {
+		h := md5.Sum([]byte(s)) //nolint:gosec // required by the digest authentication scheme
+		return hex.EncodeToString(h[:])
+	}
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		params := make(map[string]string)
+		if s := request.Header.Get("Authorization"); strings.HasPrefix(s, "Digest ") {
+			for _, p := range strings.Split(s[7:], ", ") {
+				kv := strings.SplitN(p, "=", 2)
+				if len(kv) == 2 {
+					params[kv[0]] = strings.Trim(kv[1], `"`)
+				}
+			}
+		}
+		ha1 := md5Hex("joe:" + realm + ":secret")
+		ha2 := md5Hex(request.Method + ":" + params["uri"])
+		expected := md5Hex(ha1 + ":" + nonce + ":" + params["nc"] + ":" + params["cnonce"] + ":auth:" + ha2)
+		if params["username"] != "joe" || params["response"] != expected {
+			writer.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", qop="auth", nonce="%s"`, realm, nonce))
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(request.Body)
+		_, _ = writer.Write(body)
+	}))
+}
+
+func TestSendAuth_Digest(t *testing.T) {
+	s := DigestServer()
+	defer s.Close()
+
+	t.Run("valid credentials", func(t *testing.T) {
+		Test(t,
+			Post(s.URL+"/path?query=1"),
+			Send().Auth().Digest("joe", "secret"),
+			Send().Body().String("Hello World"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("Hello World"),
+		)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			Send().Auth().Digest("joe", "wrong"),
+			Expect().Status().Equal(http.StatusUnauthorized),
+		)
+	})
+
+	t.Run("last credentials are used", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			Send().Auth().Digest("joe", "secret"),
+			Send().Auth().Digest("joe", "wrong"),
+			Expect().Status().Equal(http.StatusUnauthorized),
+		)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		attempts := 0
+		Test(t,
+			Get(s.URL),
+			Retry(RetryPolicy{MaxAttempts: 3, Interval: time.Millisecond}),
+			Send().Auth().Digest("joe", "secret"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Custom(func(Hit) error {
+				if attempts++; attempts < 3 {
+					return errors.New("retry")
+				}
+				return nil
+			}),
+		)
+	})
+
+	t.Run("without digest", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			Expect().Status().Equal(http.StatusUnauthorized),
+		)
+	})
+}
+
+func TestSendAuth_OAuth2ClientCredentials(t *testing.T) {
+	var calls int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		id, secret, _ := request.BasicAuth()
+		if request.Method != http.MethodPost ||
+			request.FormValue("grant_type") != "client_credentials" ||
+			id != "client" || secret != "secret" {
+			writer.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+				"error": "invalid_client",
+			})
+			return
+		}
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+			"access_token": "token-" + strings.ReplaceAll(request.FormValue("scope"), " ", "-"),
+			"token_type":   "bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("cache token", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			Test(t,
+				Post(s.URL),
+				Send().Auth().OAuth2ClientCredentials(tokenServer.URL, "client", "secret", "read", "write"),
+				Expect().Headers("Authorization").Equal("Bearer token-read-write"),
+			)
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		Test(t,
+			Post(s.URL),
+			Send().Auth().OAuth2ClientCredentials(tokenServer.URL, "client", "secret"),
+			Expect().Headers("Authorization").Equal("Bearer token-"),
+		)
+		require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("interpolate scopes", func(t *testing.T) {
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("scope", "admin")
+		session.Test(t,
+			Post(""),
+			Send().Auth().OAuth2ClientCredentials(tokenServer.URL, "client", "secret", "{{.scope}}"),
+			Expect().Headers("Authorization").Equal("Bearer token-admin"),
+		)
+	})
+
+	t.Run("unrelated token endpoints are not blocked", func(t *testing.T) {
+		entered := make(chan struct{})
+		release := make(chan struct{})
+		slowServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			close(entered)
+			<-release
+			_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+				"access_token": "slow",
+			})
+		}))
+		defer slowServer.Close()
+
+		done := make(chan error)
+		go func() {
+			done <- Do(
+				Post(s.URL),
+				Send().Auth().OAuth2ClientCredentials(slowServer.URL, "client", "secret"),
+			)
+		}()
+		<-entered
+
+		finished := make(chan error, 1)
+		go func() {
+			finished <- Do(
+				Post(s.URL),
+				Send().Auth().OAuth2ClientCredentials(tokenServer.URL, "client", "secret", "unrelated"),
+				Expect().Headers("Authorization").Equal("Bearer token-unrelated"),
+			)
+		}()
+		select {
+		case err := <-finished:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Error("token request was blocked by another token endpoint")
+		}
+		close(release)
+		require.NoError(t, <-done)
+	})
+
+	t.Run("invalid client", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Auth().OAuth2ClientCredentials(tokenServer.URL, "client", "wrong"),
+			),
+			PtrStr("unable to fetch oauth2 token: 401 Unauthorized: invalid_client"),
+		)
+	})
+}
+
+func TestSendAuth_Redaction(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	// the EchoServer would echo the credentials in the response, use a server that does not send them back
+	plain := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer plain.Close()
+
+	const credentials = "am9lOnN1cGVyc2VjcmV0"
+
+	t.Run("Debug", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		Test(t,
+			Post(s.URL),
+			Send().Auth().Basic("joe", "supersecret"),
+			Fdebug(buf).Request().Headers(),
+			Fdebug(buf).Request(),
+			Expect().Headers("Authorization").Equal("Basic "+credentials),
+		)
+
+		b, err := ioutil.ReadAll(vtclean.NewReader(buf, false))
+		require.NoError(t, err)
+		require.Contains(t, string(b), "Basic [REDACTED]")
+		require.NotContains(t, string(b), credentials)
+	})
+
+	t.Run("Curl", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		Test(t,
+			Post(s.URL),
+			Send().Auth().Basic("joe", "supersecret"),
+			Fdebug(buf).Request().Curl(),
+		)
+		require.Contains(t, buf.String(), "Authorization: Basic [REDACTED]")
+		require.NotContains(t, buf.String(), credentials)
+	})
+
+	t.Run("IncludeCurlInError", func(t *testing.T) {
+		err := Do(
+			Post(plain.URL),
+			IncludeCurlInError(),
+			Send().Auth().Basic("joe", "supersecret"),
+			Expect().Status().Equal(http.StatusNotFound),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Authorization: Basic [REDACTED]")
+		require.NotContains(t, err.Error(), credentials)
+	})
+
+	t.Run("Debug with user info", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		Test(t,
+			Post(strings.Replace(s.URL, "http://", "http://joe:supersecret@", 1)),
+			Fdebug(buf).Request(),
+			Fdebug(buf).Request().URL(),
+			Fdebug(buf).Request().Curl(),
+		)
+		require.Contains(t, buf.String(), "-u 'joe:[REDACTED]'")
+		require.NotContains(t, buf.String(), "supersecret")
+	})
+
+	t.Run("IncludeCurlInError with user info", func(t *testing.T) {
+		err := Do(
+			Post(strings.Replace(plain.URL, "http://", "http://joe:supersecret@", 1)),
+			IncludeCurlInError(),
+			Expect().Status().Equal(http.StatusNotFound),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "-u 'joe:[REDACTED]'")
+		require.NotContains(t, err.Error(), "supersecret")
+	})
+
+	t.Run("Store keeps the credentials", func(t *testing.T) {
+		var cmd string
+		Test(t,
+			Post(s.URL),
+			Send().Auth().Basic("joe", "supersecret"),
+			Store().Request().Curl().In(&cmd),
+		)
+		require.Contains(t, cmd, credentials)
+
+		Test(t,
+			Post(strings.Replace(s.URL, "http://", "http://joe:supersecret@", 1)),
+			Store().Request().Curl().In(&cmd),
+		)
+		require.Contains(t, cmd, "-u joe:supersecret")
+	})
+
+	t.Run("HAR", func(t *testing.T) {
+		recorder := NewHARRecorder()
+		Test(t,
+			RecordHAR(recorder),
+			Post(plain.URL),
+			Send().Auth().Bearer("supersecret"),
+		)
+		buf := bytes.NewBuffer(nil)
+		_, err := recorder.WriteTo(buf)
+		require.NoError(t, err)
+		require.Contains(t, buf.String(), "Bearer [REDACTED]")
+		require.NotContains(t, buf.String(), "supersecret")
+	})
+}
@@ -1,6 +1,7 @@
 package hit
 
 import (
+	"encoding/json"
 	"io"
 
 	"github.com/Eun/go-hit/httpbody"
@@ -70,11 +71,23 @@ type ISendBody interface {
 	Int8(value int8) IStep
 
 	// JSON sets the request body to the json representation of the specified value.
+	// Placeholders (e.g. {{.userId}}) in strings will be replaced with the corresponding variables, a string that
+	// consists only of one placeholder is replaced with the json representation of the variable (so numbers stay
+	// numbers).
 	//
 	// Usage:
 	//     Send().Body().JSON(map[string]interface{}{"Name": "Joe"})
+	//     Send().Body().JSON(map[string]interface{}{"ID": "{{.userId}}"})
 	JSON(value interface{}) IStep
 
+	// Multipart sets the request body to a multipart/form-data body, the Content-Type header (including the boundary)
+	// will be set automatically.
+	//
+	// Usage:
+	//     Send().Body().Multipart().Field("username", "admin")
+	//     Send().Body().Multipart().File("avatar", "avatar.png", bytes.NewReader([]byte("Hello World")))
+	Multipart() ISendBodyMultipart
+
 	// Reader sets the request body to the specified reader.
 	//
 	// Usage:
@@ -82,9 +95,11 @@ type ISendBody interface {
 	Reader(value io.Reader) IStep
 
 	// String sets the request body to the specified string.
+	// Placeholders (e.g. {{.userId}}) will be replaced with the corresponding variables.
 	//
 	// Usage:
 	//     Send().Body().String("Hello World")
+	//     Send().Body().String("Hello {{.userName}}")
 	String(value string) IStep
 
 	// Uint sets the request body to the specified uint.
@@ -254,18 +269,34 @@ func (body *sendBody) JSON(value interface{}) IStep {
 		When:     SendStep,
 		CallPath: body.cleanPath.Push("JSON", []interface{}{value}),
 		Exec: func(hit *hitImpl) error {
-			return hit.Request().Body().JSON().Set(value)
+			buf, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			buf, err = hit.interpolateJSON(buf)
+			if err != nil {
+				return err
+			}
+			hit.Request().Body().SetBytes(buf)
+			return nil
 		},
 	}
 }
 
+func (body *sendBody) Multipart() ISendBodyMultipart {
+	return newSendBodyMultipart(body.cleanPath.Push("Multipart", nil))
+}
+
 func (body *sendBody) Reader(value io.Reader) IStep {
+	// the reader can only be consumed once, use a factory so the step can be executed multiple times
+	// (e.g. when using Retry())
+	reader := newReaderFunc(value)
 	return &hitStep{
 		Trace:    ett.Prepare(),
 		When:     SendStep,
 		CallPath: body.cleanPath.Push("Reader", []interface{}{value}),
 		Exec: func(hit *hitImpl) error {
-			hit.Request().Body().SetReader(value)
+			hit.Request().Body().SetReader(reader())
 			return nil
 		},
 	}
@@ -277,7 +308,11 @@ func (body *sendBody) String(value string) IStep {
 		When:     SendStep,
 		CallPath: body.cleanPath.Push("String", []interface{}{value}),
 		Exec: func(hit *hitImpl) error {
-			hit.Request().Body().SetString(value)
+			s, err := hit.interpolate(value)
+			if err != nil {
+				return err
+			}
+			hit.Request().Body().SetString(s)
 			return nil
 		},
 	}
@@ -0,0 +1,171 @@
+package hit
+
+import (
+	"io"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/Eun/go-doppelgangerreader"
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/httpbody"
+	"github.com/Eun/go-hit/internal/converter"
+)
+
+// ISendBodyMultipart provides methods to send a multipart/form-data body.
+// The Content-Type header (including the boundary) will be set automatically.
+type ISendBodyMultipart interface {
+	// Field adds a form field with the specified value.
+	//
+	// Usage:
+	//     Send().Body().Multipart().Field("username", "admin")
+	//     Send().Body().Multipart().Field("age", 30)
+	Field(name string, value interface{}) IStep
+
+	// File adds a file to the specified field.
+	// The file can either be an io.Reader or a string containing the path to the file. If fileName is empty and file
+	// is a path, the base name of the path will be used.
+	//
+	// Usage:
+	//     Send().Body().Multipart().File("avatar", "avatar.png", bytes.NewReader([]byte("Hello World")))
+	//     Send().Body().Multipart().File("document", "", "/tmp/document.txt")
+	File(field, fileName string, file interface{}) IStep
+
+	// Part adds a part with custom headers to the specified field.
+	// If the header does not contain a Content-Disposition it will be set to form-data with the field name.
+	// The content can either be an io.Reader or any value that can be converted into a string.
+	//
+	// Usage:
+	//     Send().Body().Multipart().Part("metadata", http.Header{"Content-Type": {"application/json"}}, `{"ID": 10}`)
+	Part(field string, header http.Header, content interface{}) IStep
+}
+
+type sendBodyMultipart struct {
+	cleanPath callPath
+}
+
+func newSendBodyMultipart(cleanPath callPath) ISendBodyMultipart {
+	return &sendBodyMultipart{
+		cleanPath: cleanPath,
+	}
+}
+
+// newReaderFunc returns a function that returns a new reader with the contents of r on every call, so the step can
+// be executed multiple times (e.g. when using Retry()).
+// The factory is created on the first call, the step might be used by multiple tests in parallel.
+func newReaderFunc(r io.Reader) func() io.Reader {
+	var once sync.Once
+	var factory doppelgangerreader.DoppelgangerFactory
+	return func() io.Reader {
+		once.Do(func() {
+			factory = doppelgangerreader.NewFactory(r)
+		})
+		return factory.NewDoppelganger()
+	}
+}
+
+func (mp *sendBodyMultipart) Field(name string, value interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: mp.cleanPath.Push("Field", []interface{}{name, value}),
+		Exec: func(hit *hitImpl) error {
+			var s string
+			if err := converter.Convert(value, &s); err != nil {
+				return err
+			}
+			m, err := requestMultipart(hit)
+			if err != nil {
+				return err
+			}
+			return m.AddField(name, s)
+		},
+	}
+}
+
+func (mp *sendBodyMultipart) File(field, fileName string, file interface{}) IStep {
+	var reader func() io.Reader
+	if r, ok := file.(io.Reader); ok {
+		reader = newReaderFunc(r)
+	}
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: mp.cleanPath.Push("File", []interface{}{field, fileName, file}),
+		Exec: func(hit *hitImpl) error {
+			m, err := requestMultipart(hit)
+			if err != nil {
+				return err
+			}
+			if reader != nil {
+				return m.AddFile(field, fileName, reader())
+			}
+
+			path, ok := file.(string)
+			if !ok {
+				return xerrors.Errorf("file must be an io.Reader or a path, got %T", file)
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			name := fileName
+			if name == "" {
+				name = filepath.Base(path)
+			}
+			return m.AddFile(field, name, f)
+		},
+	}
+}
+
+func (mp *sendBodyMultipart) Part(field string, header http.Header, content interface{}) IStep {
+	var reader func() io.Reader
+	if r, ok := content.(io.Reader); ok {
+		reader = newReaderFunc(r)
+	}
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: mp.cleanPath.Push("Part", []interface{}{field, header, content}),
+		Exec: func(hit *hitImpl) error {
+			h := make(textproto.MIMEHeader)
+			for k, v := range header {
+				for _, s := range v {
+					h.Add(k, s)
+				}
+			}
+
+			var r io.Reader
+			if reader != nil {
+				r = reader()
+			} else {
+				var s string
+				if err := converter.Convert(content, &s); err != nil {
+					return err
+				}
+				r = strings.NewReader(s)
+			}
+
+			m, err := requestMultipart(hit)
+			if err != nil {
+				return err
+			}
+			return m.AddPart(field, h, r)
+		},
+	}
+}
+
+// requestMultipart returns the multipart representation of the request body, a new multipart/form-data body will be
+// created if the request body is not a multipart body yet.
+func requestMultipart(hit *hitImpl) (*httpbody.Multipart, error) {
+	m, err := hit.Request().Body().Multipart()
+	if xerrors.Is(err, httpbody.ErrNotMultipart) {
+		return httpbody.NewMultipart(hit.Request().Body()), nil
+	}
+	return m, err
+}
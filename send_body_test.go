@@ -1,7 +1,17 @@
 package hit_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,6 +29,28 @@ func TestSendBody_String(t *testing.T) {
 		Expect().Body().String().Equal("Hello World"),
 	)
 }
+func TestSendBody_Reader(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("shared step", func(t *testing.T) {
+		step := Send().Body().Reader(strings.NewReader("Hello World"))
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				Test(t,
+					Post(s.URL),
+					step,
+					Expect().Body().String().Equal("Hello World"),
+				)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
 func TestSendBody_JSON(t *testing.T) {
 	s := EchoServer()
 	defer s.Close()
@@ -121,3 +153,123 @@ func TestSendBody_FormValue(t *testing.T) {
 		Expect().Body().String().OneOf("password=secret&username=joe", "username=joe&password=secret"),
 	)
 }
+
+func TestSendBody_Multipart(t *testing.T) {
+	type part struct {
+		Name        string
+		FileName    string
+		ContentType string
+		Data        string
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(writer http.ResponseWriter, request *http.Request) {
+		r, err := request.MultipartReader()
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var parts []part
+		for {
+			p, err := r.NextPart()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+			buf, err := ioutil.ReadAll(p)
+			if err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+			parts = append(parts, part{
+				Name:        p.FormName(),
+				FileName:    p.FileName(),
+				ContentType: p.Header.Get("Content-Type"),
+				Data:        string(buf),
+			})
+		}
+		_ = json.NewEncoder(writer).Encode(parts)
+	})
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	dir, err := ioutil.TempDir("", "multipart")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "document.txt")
+	require.NoError(t, ioutil.WriteFile(path, []byte("Hello Document"), 0600))
+
+	t.Run("fields and files", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().Multipart().Field("username", "joe"),
+			Send().Body().Multipart().Field("age", 30),
+			Send().Body().Multipart().File("avatar", "avatar.png", bytes.NewReader([]byte("Hello Avatar"))),
+			Send().Body().Multipart().File("document", "", path),
+			Send().Body().Multipart().File("upload", "upload.txt", path),
+			Send().Body().Multipart().Part("metadata", http.Header{"Content-Type": {"application/json"}}, `{"ID":10}`),
+			Send().Body().Multipart().Part("raw", http.Header{
+				"content-disposition": {`form-data; name="renamed"`},
+			}, strings.NewReader("Hello World")),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().JSON().Equal([]part{
+				{Name: "username", Data: "joe"},
+				{Name: "age", Data: "30"},
+				{Name: "avatar", FileName: "avatar.png", ContentType: "application/octet-stream", Data: "Hello Avatar"},
+				{Name: "document", FileName: "document.txt", ContentType: "application/octet-stream", Data: "Hello Document"},
+				{Name: "upload", FileName: "upload.txt", ContentType: "application/octet-stream", Data: "Hello Document"},
+				{Name: "metadata", ContentType: "application/json", Data: `{"ID":10}`},
+				{Name: "renamed", Data: "Hello World"},
+			}),
+		)
+	})
+
+	t.Run("content type", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().Multipart().Field("username", "joe"),
+			Expect().Custom(func(hit Hit) error {
+				m := hit.Request().Body().MustMultipart()
+				require.Equal(t, "multipart/form-data; boundary="+m.Boundary(), hit.Request().Header.Get("Content-Type"))
+				require.Len(t, m.Parts(), 1)
+				return nil
+			}),
+		)
+	})
+
+	t.Run("keep boundary", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Headers("Content-Type").Add(`multipart/form-data; boundary="foo123"`),
+			Send().Body().Multipart().Field("username", "joe"),
+			Expect().Custom(func(hit Hit) error {
+				require.Equal(t, "multipart/form-data; boundary=foo123", hit.Request().Header.Get("Content-Type"))
+				require.Contains(t, hit.Request().Body().MustString(), "--foo123\r\n")
+				return nil
+			}),
+		)
+	})
+
+	t.Run("file not found", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().Multipart().File("document", "", filepath.Join(dir, "missing.txt")),
+			),
+			nil,
+		)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().Multipart().File("document", "", []byte("Hello")),
+			),
+			PtrStr("file must be an io.Reader or a path, got []uint8"),
+		)
+	})
+}
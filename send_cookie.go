@@ -0,0 +1,53 @@
+package hit
+
+import (
+	"net/http"
+
+	"github.com/Eun/go-hit/internal/converter"
+)
+
+// ISendCookie provides methods to send a cookie.
+type ISendCookie interface {
+	// Set sends the cookie with the specified value.
+	// Placeholders (e.g. {{.token}}) will be replaced with the corresponding variables.
+	//
+	// Usage:
+	//     Send().Cookies("session").Set("abc123")
+	//     Send().Cookies("session").Set("{{.token}}")
+	Set(value interface{}) IStep
+}
+
+type sendCookie struct {
+	cleanPath callPath
+	name      string
+}
+
+func newSendCookie(cleanPath callPath, name string) ISendCookie {
+	return &sendCookie{
+		cleanPath: cleanPath,
+		name:      name,
+	}
+}
+
+func (c *sendCookie) Set(value interface{}) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     SendStep,
+		CallPath: c.cleanPath.Push("Set", []interface{}{value}),
+		Exec: func(hit *hitImpl) error {
+			var s string
+			if err := converter.Convert(value, &s); err != nil {
+				return err
+			}
+			s, err := hit.interpolate(s)
+			if err != nil {
+				return err
+			}
+			hit.Request().AddCookie(&http.Cookie{
+				Name:  c.name,
+				Value: s,
+			})
+			return nil
+		},
+	}
+}
@@ -11,6 +11,7 @@ import (
 // ISendHeaders provides methods to send header/trailer.
 type ISendHeaders interface {
 	// Add adds the specified value to the specified request header.
+	// Placeholders (e.g. {{.token}}) will be replaced with the corresponding variables.
 	//
 	// Usage:
 	//     Send().Headers("Set-Cookie").Add("foo=bar")
@@ -45,6 +46,10 @@ func (hdr *sendHeader) Add(values ...interface{}) IStep {
 				if err := converter.Convert(value, &s); err != nil {
 					return err
 				}
+				s, err := hit.interpolate(s)
+				if err != nil {
+					return err
+				}
 				if strings.EqualFold(hdr.name, "host") {
 					hit.Request().Host = s
 					return nil
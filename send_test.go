@@ -1,6 +1,7 @@
 package hit_test
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -51,6 +52,48 @@ func TestSendHeaders(t *testing.T) {
 	)
 }
 
+func TestSendCookies(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("Cookies", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Cookies("session").Set("abc123"),
+			Send().Cookies("theme").Set("dark"),
+			Expect().Headers("Cookie").Equal("session=abc123; theme=dark"),
+		)
+	})
+
+	t.Run("Cookie", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Cookie(&http.Cookie{Name: "session", Value: "abc123", Path: "/", HttpOnly: true}),
+			Expect().Headers("Cookie").Equal("session=abc123"),
+		)
+	})
+
+	t.Run("nil cookie", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Cookie(nil),
+			),
+			PtrStr("cookie cannot be nil"),
+		)
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Cookies("session").Set("abc123"),
+			Send().Cookies("theme").Set("dark"),
+			Clear().Send().Cookies("session"),
+			Expect().Headers("Cookie").Equal("theme=dark"),
+		)
+	})
+}
+
 func TestSendTrailers(t *testing.T) {
 	s := EchoServer()
 	defer s.Close()
@@ -0,0 +1,113 @@
+package hit
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+)
+
+// Session can be used to share cookies, default steps (e.g. BaseURL(), HTTPClient() or headers) and variables across
+// multiple Do calls.
+//
+// Example:
+//     session := NewSession(
+//         BaseURL("https://example.com"),
+//         Send().Headers("Content-Type").Add("application/json"),
+//     )
+//     session.MustDo(
+//         Get("/cookies/set/session/secret"),
+//     )
+//     session.MustDo(
+//         Get("/cookies"),
+//         Expect().Body().JSON().JQ(".cookies.session").Equal("secret"),
+//     )
+type Session struct {
+	steps     []IStep
+	jar       http.CookieJar
+	variables *variables
+}
+
+// NewSession creates a new Session, the specified steps will be prepended to every Do, MustDo or Test call of this
+// Session.
+func NewSession(steps ...IStep) *Session {
+	// cookiejar.New never returns an error
+	jar, _ := cookiejar.New(nil)
+	return &Session{
+		steps:     steps,
+		jar:       jar,
+		variables: newVariables(),
+	}
+}
+
+// Do runs the session steps and the specified steps and returns error if something was wrong.
+func (s *Session) Do(steps ...IStep) error {
+	if err := s.do(steps...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// MustDo runs the session steps and the specified steps and panics with the error if something was wrong.
+func (s *Session) MustDo(steps ...IStep) {
+	if err := s.Do(steps...); err != nil {
+		panic(err)
+	}
+}
+
+// Test runs the session steps and the specified steps and calls t.FailNow() if any error occurs during execution.
+func (s *Session) Test(t TestingT, steps ...IStep) {
+	helper(t)
+	hit := s.newHit(steps...)
+	hit.stdout = testingOutput(t)
+	test(t, hit)
+}
+
+// CookieJar returns the cookie jar that is used for this Session.
+func (s *Session) CookieJar() http.CookieJar {
+	return s.jar
+}
+
+// Variable returns the variable with the specified name, the second return value reports whether the variable
+// exists.
+func (s *Session) Variable(name string) (interface{}, bool) {
+	return s.variables.Get(name)
+}
+
+// SetVariable sets the variable with the specified name to the specified value.
+func (s *Session) SetVariable(name string, value interface{}) {
+	s.variables.Set(name, value)
+}
+
+func (s *Session) do(steps ...IStep) *Error {
+	return s.newHit(steps...).run()
+}
+
+func (s *Session) newHit(steps ...IStep) *hitImpl {
+	allSteps := make([]IStep, 0, len(s.steps)+len(steps)+1)
+	allSteps = append(allSteps, s.steps...)
+	allSteps = append(allSteps, steps...)
+	allSteps = append(allSteps, s.useCookieJarStep())
+
+	hit := newHit(allSteps...)
+	hit.variables = s.variables
+	return hit
+}
+
+// useCookieJarStep returns a step that sets the session cookie jar for the used http.Client, it will not modify the
+// http.Client if it has already a cookie jar.
+func (s *Session) useCookieJarStep() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: nil,
+		Exec: func(hit *hitImpl) error {
+			if hit.client.Jar != nil {
+				return nil
+			}
+			// copy the client, so we do not modify the client that was set (e.g. http.DefaultClient)
+			client := *hit.client
+			client.Jar = s.jar
+			hit.client = &client
+			return nil
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package hit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func CookieServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/set", func(writer http.ResponseWriter, request *http.Request) {
+		http.SetCookie(writer, &http.Cookie{
+			Name:  "session",
+			Value: request.URL.Query().Get("value"),
+			Path:  "/",
+		})
+		writer.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/get", func(writer http.ResponseWriter, request *http.Request) {
+		cookie, err := request.Cookie("session")
+		if err != nil {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(cookie.Value))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestSession(t *testing.T) {
+	t.Run("default steps", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		session := NewSession(
+			BaseURL(s.URL),
+			Send().Headers("X-Header").Add("Hello"),
+		)
+
+		session.Test(t,
+			Post("/"),
+			Expect().Headers("X-Header").Equal("Hello"),
+		)
+
+		session.Test(t,
+			Post("/"),
+			Clear().Send().Headers("X-Header"),
+			Expect().Headers("X-Header").Empty(),
+		)
+	})
+
+	t.Run("cookies", func(t *testing.T) {
+		s := CookieServer()
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+
+		session.Test(t,
+			Get("/get"),
+			Expect().Status().Equal(http.StatusUnauthorized),
+		)
+
+		session.Test(t,
+			Get("/set?value=secret"),
+			Expect().Status().Equal(http.StatusOK),
+		)
+
+		session.Test(t,
+			Get("/get"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("secret"),
+		)
+
+		// other sessions should not share the cookies
+		NewSession(BaseURL(s.URL)).Test(t,
+			Get("/get"),
+			Expect().Status().Equal(http.StatusUnauthorized),
+		)
+
+		u, err := url.Parse(s.URL)
+		require.NoError(t, err)
+		require.Len(t, session.CookieJar().Cookies(u), 1)
+
+		// http.DefaultClient should not be modified
+		require.Nil(t, http.DefaultClient.Jar)
+	})
+
+	t.Run("custom client with jar", func(t *testing.T) {
+		s := CookieServer()
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+		client := &http.Client{
+			Jar: &cookieJar{},
+		}
+
+		session.Test(t,
+			HTTPClient(client),
+			Get("/set?value=secret"),
+		)
+		u, err := url.Parse(s.URL)
+		require.NoError(t, err)
+		require.Empty(t, session.CookieJar().Cookies(u))
+		require.Len(t, client.Jar.Cookies(u), 1)
+	})
+
+	t.Run("variables", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("greeting", "Hello")
+
+		session.Test(t,
+			Post("/"),
+			Send().Body().String("World"),
+			Expect().Custom(func(hit Hit) error {
+				v, ok := hit.Variable("greeting")
+				require.True(t, ok)
+				require.Equal(t, "Hello", v)
+				hit.SetVariable("body", hit.Response().Body().MustString())
+				return nil
+			}),
+		)
+
+		v, ok := session.Variable("body")
+		require.True(t, ok)
+		require.Equal(t, "World", v)
+
+		session.Test(t,
+			Post("/"),
+			Send().Custom(func(hit Hit) error {
+				v, ok := hit.Variable("body")
+				require.True(t, ok)
+				hit.Request().Body().SetString(v.(string))
+				return nil
+			}),
+			Expect().Body().String().Equal("World"),
+		)
+
+		// variables are not shared with Do
+		Test(t,
+			Post(s.URL),
+			Expect().Custom(func(hit Hit) error {
+				_, ok := hit.Variable("body")
+				require.False(t, ok)
+				return nil
+			}),
+		)
+	})
+}
+
+type cookieJar struct {
+	cookies []*http.Cookie
+}
+
+func (jar *cookieJar) SetCookies(_ *url.URL, cookies []*http.Cookie) {
+	jar.cookies = append(jar.cookies, cookies...)
+}
+
+func (jar *cookieJar) Cookies(*url.URL) []*http.Cookie {
+	return jar.cookies
+}
@@ -0,0 +1,96 @@
+package hit
+
+import (
+	"crypto/hmac"
+	"encoding/hex"
+	"hash"
+
+	"golang.org/x/xerrors"
+)
+
+// ISign provides methods to sign the request.
+// All signing steps run in the AfterSendStep, so they sign the final request, regardless of the position of the
+// Send() steps.
+type ISign interface {
+	// HMAC computes a HMAC of the request using the specified hash function and key, the hex encoded result will be
+	// set as the value of the specified header.
+	// The canonicalizer returns the message that should be signed, if it is nil the request body will be signed.
+	//
+	// Usage:
+	//     Sign().HMAC("X-Signature", sha256.New, []byte("secret"), nil)
+	//     Sign().HMAC("X-Signature", sha256.New, []byte("secret"), func(hit Hit) ([]byte, error) {
+	//         body, err := hit.Request().Body().Bytes()
+	//         if err != nil {
+	//             return nil, err
+	//         }
+	//         return append([]byte(hit.Request().Method+"\n"+hit.Request().URL.Path+"\n"), body...), nil
+	//     })
+	HMAC(header string, algorithm func() hash.Hash, key []byte, canonicalizer Canonicalizer) IStep
+
+	// AWSSigV4 signs the request using the AWS Signature Version 4 signing process.
+	// If the request already has a X-Amz-Date header its value will be used as the signing time, otherwise the
+	// header will be set to the current time.
+	// If the credentials contain a SessionToken it will be sent (and signed) in the X-Amz-Security-Token header.
+	//
+	// Usage:
+	//     Sign().AWSSigV4("us-east-1", "execute-api", AWSCredentials{
+	//         AccessKeyID:     "AKIDEXAMPLE",
+	//         SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	//     })
+	AWSSigV4(region, service string, credentials AWSCredentials) IStep
+}
+
+// Canonicalizer returns the message that should be signed.
+type Canonicalizer func(hit Hit) ([]byte, error)
+
+type sign struct {
+	cleanPath callPath
+}
+
+func newSign(cleanPath callPath) ISign {
+	return &sign{
+		cleanPath: cleanPath,
+	}
+}
+
+func (s *sign) HMAC(header string, algorithm func() hash.Hash, key []byte, canonicalizer Canonicalizer) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     AfterSendStep,
+		CallPath: s.cleanPath.Push("HMAC", []interface{}{header, algorithm, key, canonicalizer}),
+		Exec: func(hit *hitImpl) error {
+			if algorithm == nil {
+				return xerrors.New("algorithm cannot be nil")
+			}
+			var message []byte
+			var err error
+			if canonicalizer == nil {
+				message, err = hit.Request().Body().Bytes()
+			} else {
+				message, err = canonicalizer(hit)
+			}
+			if err != nil {
+				return err
+			}
+			mac := hmac.New(algorithm, key)
+			_, _ = mac.Write(message)
+			hit.Request().Header.Set(header, hex.EncodeToString(mac.Sum(nil)))
+			return nil
+		},
+	}
+}
+
+func (s *sign) AWSSigV4(region, service string, credentials AWSCredentials) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     AfterSendStep,
+		CallPath: s.cleanPath.Push("AWSSigV4", []interface{}{region, service, credentials}),
+		Exec: func(hit *hitImpl) error {
+			if err := signAWSSigV4(hit.Request(), region, service, credentials); err != nil {
+				return err
+			}
+			hit.redactHeader("Authorization")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,213 @@
+package hit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// AWSCredentials are the credentials that will be used by Sign().AWSSigV4().
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	// SessionToken is optional, it is only required for temporary credentials.
+	SessionToken string
+}
+
+const (
+	awsSigV4Algorithm  = "AWS4-HMAC-SHA256"
+	awsSigV4TimeFormat = "20060102T150405Z"
+	awsSigV4DateFormat = "20060102"
+)
+
+// signAWSSigV4 signs the request as described in
+// https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html and sets the Authorization header.
+func signAWSSigV4(req *HTTPRequest, region, service string, credentials AWSCredentials) error {
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return xerrors.New("AccessKeyID and SecretAccessKey must be set")
+	}
+	if req.URL == nil {
+		return xerrors.New("request url is not set")
+	}
+
+	signTime := time.Now().UTC()
+	if s := req.Header.Get("X-Amz-Date"); s != "" {
+		var err error
+		signTime, err = time.Parse(awsSigV4TimeFormat, s)
+		if err != nil {
+			return xerrors.Errorf("unable to parse X-Amz-Date header: %w", err)
+		}
+	} else {
+		req.Header.Set("X-Amz-Date", signTime.Format(awsSigV4TimeFormat))
+	}
+	if credentials.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.SessionToken)
+	}
+
+	body, err := req.Body().Bytes()
+	if err != nil {
+		return err
+	}
+	payloadHash := sha256Hex(body)
+	if service == "s3" {
+		req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	}
+
+	signedHeaders, canonicalHeaders := awsCanonicalHeaders(req)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		awsCanonicalURI(req.URL, service != "s3"),
+		awsCanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{signTime.Format(awsSigV4DateFormat), region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		awsSigV4Algorithm,
+		signTime.Format(awsSigV4TimeFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+credentials.SecretAccessKey), signTime.Format(awsSigV4DateFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		awsSigV4Algorithm, credentials.AccessKeyID, scope, signedHeaders, hex.EncodeToString(hmacSHA256(key, stringToSign))))
+	return nil
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// awsURIEncode encodes every byte except the unreserved characters (A-Z, a-z, 0-9, '-', '.', '_' and '~').
+func awsURIEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' || (c == '/' && !encodeSlash) {
+			sb.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&sb, "%%%02X", c)
+	}
+	return sb.String()
+}
+
+// awsCanonicalURI returns the encoded path of the url, the segments are taken from the escaped path so encoded slashes
+// stay part of their segment.
+// If normalize is true empty segments and dot segments will be removed (S3 uses the path as is).
+func awsCanonicalURI(u *url.URL, normalize bool) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+	encoded := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		if normalize {
+			switch segment {
+			case "", ".":
+				// keep the leading and the trailing slash
+				if i == 0 || i == len(segments)-1 {
+					segment = ""
+					break
+				}
+				continue
+			case "..":
+				if len(encoded) > 1 {
+					encoded = encoded[:len(encoded)-1]
+				}
+				if i == len(segments)-1 {
+					segment = ""
+					break
+				}
+				continue
+			}
+		}
+		if s, err := url.PathUnescape(segment); err == nil {
+			segment = s
+		}
+		encoded = append(encoded, awsURIEncode(segment, true))
+	}
+	return strings.Join(encoded, "/")
+}
+
+func awsCanonicalQuery(query url.Values) string {
+	var params []string
+	for key, values := range query {
+		for _, value := range values {
+			params = append(params, awsURIEncode(key, true)+"="+awsURIEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// awsUnsignedHeaders are headers that will not be signed, because they might be modified by proxies (or by a
+// previous signing).
+var awsUnsignedHeaders = map[string]bool{
+	"authorization":   true,
+	"user-agent":      true,
+	"x-amzn-trace-id": true,
+}
+
+// awsCanonicalHeaders returns the signed headers and the canonical headers, all headers of the request (including
+// the host) except awsUnsignedHeaders will be signed.
+func awsCanonicalHeaders(req *HTTPRequest) (signedHeaders, canonicalHeaders string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string][]string{
+		"host": {host},
+	}
+	for name, values := range req.Header {
+		name = strings.ToLower(name)
+		if awsUnsignedHeaders[name] {
+			continue
+		}
+		headers[name] = append(headers[name], values...)
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		values := make([]string, len(headers[name]))
+		for i, value := range headers[name] {
+			// trim the value and replace sequential spaces with a single space
+			values[i] = strings.Join(strings.Fields(value), " ")
+		}
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(strings.Join(values, ","))
+		sb.WriteByte('\n')
+	}
+	return strings.Join(names, ";"), sb.String()
+}
@@ -0,0 +1,236 @@
+package hit_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/Eun/go-hit"
+)
+
+// HMACServer creates a server that verifies the X-Signature header (HMAC-SHA256 of the body using the key secret).
+func HMACServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		body, _ := ioutil.ReadAll(request.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write(body)
+		if request.Header.Get("X-Signature") != hex.EncodeToString(mac.Sum(nil)) {
+			writer.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = writer.Write(body)
+	}))
+}
+
+func TestSign_HMAC(t *testing.T) {
+	s := HMACServer()
+	defer s.Close()
+
+	t.Run("body", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Sign().HMAC("X-Signature", sha256.New, []byte("secret"), nil),
+			Send().Body().String("Hello World"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Body().String().Equal("Hello World"),
+		)
+	})
+
+	t.Run("canonicalizer", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String("Hello World"),
+			Sign().HMAC("X-Signature", sha256.New, []byte("secret"), func(hit Hit) ([]byte, error) {
+				return []byte("Hello World"), nil
+			}),
+			Send().Body().String("Hello Earth"),
+			Expect().Status().Equal(http.StatusForbidden),
+		)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		Test(t,
+			Post(s.URL),
+			Send().Body().String("Hello World"),
+			Sign().HMAC("X-Signature", sha256.New, []byte("wrong"), nil),
+			Expect().Status().Equal(http.StatusForbidden),
+		)
+	})
+}
+
+func TestSign_AWSSigV4(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	// test vectors from the AWS Signature Version 4 test suite
+	credentials := AWSCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	const credential = "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		steps         []IStep
+		authorization string
+	}{
+		{
+			name:   "get-vanilla",
+			method: http.MethodGet,
+			path:   "/",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "post-vanilla",
+			method: http.MethodPost,
+			path:   "/",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b",
+		},
+		{
+			name:   "get-vanilla-query-order-key-case",
+			method: http.MethodGet,
+			path:   "/?Param2=value2&Param1=value1",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=b97d918cfa904a5beff61c982a1b6f458b799221646efd99d3219ec94cdf2500",
+		},
+		{
+			name:   "get-slash",
+			method: http.MethodGet,
+			path:   "//",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "get-slash-dot-slash",
+			method: http.MethodGet,
+			path:   "/./",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "get-slash-pointless-dot",
+			method: http.MethodGet,
+			path:   "/./example",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=ef75d96142cf21edca26f06005da7988e4f8dc83a165a80865db7089db637ec5",
+		},
+		{
+			name:   "get-slashes",
+			method: http.MethodGet,
+			path:   "//example//",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=9a624bd73a37c9a373b5312afbebe7a714a789de108f0bdfe846570885f57e84",
+		},
+		{
+			name:   "get-relative",
+			method: http.MethodGet,
+			path:   "/example/..",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "get-relative-relative",
+			method: http.MethodGet,
+			path:   "/example1/example2/../..",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		},
+		{
+			name:   "get-space",
+			method: http.MethodGet,
+			path:   "/example%20space/",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=652487583200325589f1fba4c7e578f72c47cb61beeca81406b39ddec1366741",
+		},
+		{
+			name:   "get-utf8",
+			method: http.MethodGet,
+			path:   "/ሴ",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=8318018e0b0f223aa2bbf98705b62bb787dc9c0e678f255a891fd03141be5d85",
+		},
+		{
+			name:   "get-unreserved",
+			method: http.MethodGet,
+			path:   "/-._~0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz",
+			authorization: credential + "SignedHeaders=host;x-amz-date, " +
+				"Signature=07ef7494c76fa4850883e2b006601f940f8a34d404d0cfa977f52a65bbf5f24f",
+		},
+		{
+			name:   "get-header-value-trim",
+			method: http.MethodGet,
+			path:   "/",
+			steps: []IStep{
+				Send().Headers("My-Header1").Add(" value1"),
+				Send().Headers("My-Header2").Add(`"a   b   c"`),
+			},
+			authorization: credential + "SignedHeaders=host;my-header1;my-header2;x-amz-date, " +
+				"Signature=acc3ed3afb60bb290fc8d2dd0098b9911fcaa05412b367055dee359757a9c736",
+		},
+		{
+			name:   "get-header-key-duplicate",
+			method: http.MethodGet,
+			path:   "/",
+			steps: []IStep{
+				Send().Headers("My-Header1").Add("value2"),
+				Send().Headers("My-Header1").Add("value2"),
+				Send().Headers("My-Header1").Add("value1"),
+			},
+			authorization: credential + "SignedHeaders=host;my-header1;x-amz-date, " +
+				"Signature=c9d5ea9f3f72853aea855b47ea873832890dbdd183b4468f858259531a5138ea",
+		},
+		{
+			name:   "post-x-www-form-urlencoded",
+			method: http.MethodPost,
+			path:   "/",
+			steps: []IStep{
+				Send().Headers("Content-Type").Add("application/x-www-form-urlencoded"),
+				Send().Body().String("Param1=value1"),
+			},
+			authorization: credential + "SignedHeaders=content-type;host;x-amz-date, " +
+				"Signature=ff11897932ad3f4e8b18135d722051e5ac45fc38421b1da7b9d196a0fe09473a",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Test(t,
+				Method(test.method, s.URL+test.path),
+				Request().Host("example.amazonaws.com"),
+				Send().Headers("X-Amz-Date").Add("20150830T123600Z"),
+				CombineSteps(test.steps...),
+				Sign().AWSSigV4("us-east-1", "service", credentials),
+				Expect().Headers("Authorization").Equal(test.authorization),
+			)
+		})
+	}
+
+	t.Run("session token", func(t *testing.T) {
+		credentials := credentials
+		credentials.SessionToken = "token"
+		Test(t,
+			Get(s.URL),
+			Sign().AWSSigV4("us-east-1", "service", credentials),
+			Expect().Headers("X-Amz-Security-Token").Equal("token"),
+			Expect().Headers("X-Amz-Date").NotEmpty(),
+			Expect().Headers("Authorization").First().Contains("SignedHeaders=host;x-amz-date;x-amz-security-token, "),
+		)
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				Sign().AWSSigV4("us-east-1", "service", AWSCredentials{}),
+			),
+			PtrStr("AccessKeyID and SecretAccessKey must be set"),
+		)
+	})
+}
@@ -0,0 +1,200 @@
+package hit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/internal/minitest"
+)
+
+// SnapshotDir is the directory the snapshots will be stored in, relative paths are relative to the working directory
+// of the test (the package directory).
+var SnapshotDir = filepath.Join("testdata", "__snapshots__")
+
+// UpdateSnapshotsEnv is the name of the environment variable that sets the default of UpdateSnapshots
+// (e.g. HIT_UPDATE_SNAPSHOTS=1 go test ./...).
+const UpdateSnapshotsEnv = "HIT_UPDATE_SNAPSHOTS"
+
+// UpdateSnapshots controls whether MatchSnapshot() rewrites existing snapshots that do not match (missing snapshots
+// will always be created). It defaults to the value of the UpdateSnapshotsEnv environment variable and can be bound to
+// a custom flag, e.g.:
+//     flag.BoolVar(&hit.UpdateSnapshots, "update", hit.UpdateSnapshots, "update the snapshots")
+var UpdateSnapshots = updateSnapshotsFromEnv()
+
+// redactedValue replaces the values that were selected by the redaction rules.
+const redactedValue = "<redacted>"
+
+// snapshotPath returns the path of the snapshot file for the specified name.
+func snapshotPath(name string) (string, error) {
+	if name == "" {
+		return "", xerrors.New("snapshot name must not be empty")
+	}
+	clean := filepath.Clean(filepath.FromSlash(name))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", xerrors.Errorf("invalid snapshot name %q: name must be relative to the snapshot directory", name)
+	}
+	return filepath.Join(SnapshotDir, clean+".snap"), nil
+}
+
+func updateSnapshotsFromEnv() bool {
+	b, _ := strconv.ParseBool(os.Getenv(UpdateSnapshotsEnv))
+	return b
+}
+
+// matchSnapshot compares data with the snapshot with the specified name, the snapshot will be created if it does not
+// exist and rewritten if it does not match and UpdateSnapshots is set.
+// If data is json the redaction rules (jq paths) will be applied and the json will be compared structurally,
+// otherwise data will be compared as a string.
+func matchSnapshot(name string, data []byte, redact []string) error {
+	path, err := snapshotPath(name)
+	if err != nil {
+		return err
+	}
+
+	actual, isJSON, err := snapshotValue(data, redact)
+	if err != nil {
+		return err
+	}
+
+	expectedData, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return writeSnapshot(path, actual, isJSON)
+	}
+	if err != nil {
+		return xerrors.Errorf("unable to read snapshot %s: %w", path, err)
+	}
+
+	if err := compareSnapshot(expectedData, data, actual, isJSON); err != nil {
+		if UpdateSnapshots {
+			return writeSnapshot(path, actual, isJSON)
+		}
+		return snapshotError(path, err)
+	}
+	return nil
+}
+
+// compareSnapshot compares the expected snapshot data with the actual data (or its json value).
+func compareSnapshot(expectedData, data []byte, actual interface{}, isJSON bool) error {
+	if isJSON {
+		var expected interface{}
+		if err := json.Unmarshal(expectedData, &expected); err == nil {
+			var actualValue interface{}
+			// marshal and unmarshal to get the same types as the expected value
+			buf, err := json.Marshal(actual)
+			if err != nil {
+				return err
+			}
+			if err := json.Unmarshal(buf, &actualValue); err != nil {
+				return err
+			}
+			return minitest.Equal(actualValue, expected)
+		}
+	}
+	return minitest.Equal(string(data), string(expectedData))
+}
+
+func snapshotError(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return xerrors.Errorf(
+		"does not match the snapshot %s (set %s=1 to update the snapshot)\n%s",
+		path, UpdateSnapshotsEnv, err.Error(),
+	)
+}
+
+func writeSnapshot(path string, v interface{}, isJSON bool) error {
+	var data []byte
+	if isJSON {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	} else {
+		data = v.([]byte)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return xerrors.Errorf("unable to create snapshot directory: %w", err)
+	}
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return xerrors.Errorf("unable to write snapshot %s: %w", path, err)
+	}
+	return nil
+}
+
+// snapshotValue decodes data if it is json and applies the redaction rules, if data is not json it will be returned
+// as is.
+func snapshotValue(data []byte, redact []string) (interface{}, bool, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		if len(redact) > 0 {
+			return nil, false, xerrors.New("unable to redact the snapshot: data is not json")
+		}
+		return data, false, nil
+	}
+	v = jqValue(v)
+
+	for _, expression := range redact {
+		query, err := gojq.Parse(
+			"reduce path(" + expression + ") as $p (.; if getpath($p) == null then . else setpath($p; $v) end)",
+		)
+		if err != nil {
+			return nil, false, xerrors.Errorf("invalid redaction rule %q: %w", expression, err)
+		}
+		code, err := gojq.Compile(query, gojq.WithVariables([]string{"$v"}))
+		if err != nil {
+			return nil, false, xerrors.Errorf("invalid redaction rule %q: %w", expression, err)
+		}
+		result, ok := code.Run(v, redactedValue).Next()
+		if !ok {
+			continue
+		}
+		if err, ok := result.(error); ok {
+			return nil, false, xerrors.Errorf("unable to apply redaction rule %q: %w", expression, err)
+		}
+		v = result
+	}
+	return v, true, nil
+}
+
+// jqValue converts the json.Number values into types that gojq understands.
+func jqValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, val := range t {
+			t[k] = jqValue(val)
+		}
+		return t
+	case []interface{}:
+		for i, val := range t {
+			t[i] = jqValue(val)
+		}
+		return t
+	case json.Number:
+		if i, err := strconv.Atoi(t.String()); err == nil {
+			return i
+		}
+		if i, ok := new(big.Int).SetString(t.String(), 10); ok {
+			return i
+		}
+		f, _ := t.Float64()
+		return f
+	default:
+		return v
+	}
+}
@@ -0,0 +1,229 @@
+package hit_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func useSnapshotDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.NoError(t, err)
+	old := SnapshotDir
+	SnapshotDir = dir
+	return dir, func() {
+		SnapshotDir = old
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// withUpdateSnapshots runs fn with UpdateSnapshots enabled.
+func withUpdateSnapshots(fn func()) {
+	old := UpdateSnapshots
+	UpdateSnapshots = true
+	defer func() {
+		UpdateSnapshots = old
+	}()
+	fn()
+}
+
+func TestExpectBody_MatchSnapshot(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("json", func(t *testing.T) {
+		dir, cleanup := useSnapshotDir(t)
+		defer cleanup()
+
+		// first run creates the snapshot
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`{"ID": 10, "Name": "Joe", "CreatedAt": "2020-01-01T00:00:00Z"}`),
+			Expect().Body().MatchSnapshot("users/get", ".CreatedAt"),
+		)
+		buf, err := ioutil.ReadFile(filepath.Join(dir, "users", "get.snap"))
+		require.NoError(t, err)
+		require.Equal(t, `{
+  "CreatedAt": "<redacted>",
+  "ID": 10,
+  "Name": "Joe"
+}
+`, string(buf))
+
+		// formatting and redacted values do not matter
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`{"Name":"Joe","ID":10,"CreatedAt":"2021-02-02T00:00:00Z"}`),
+			Expect().Body().MatchSnapshot("users/get", ".CreatedAt"),
+		)
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String(`{"ID": 11, "Name": "Joe", "CreatedAt": "2020-01-01T00:00:00Z"}`),
+				Expect().Body().MatchSnapshot("users/get", ".CreatedAt"),
+			),
+			PtrStr("does not match the snapshot "+filepath.Join(dir, "users", "get.snap")+" (set HIT_UPDATE_SNAPSHOTS=1 to update the snapshot)"),
+			PtrStr("not equal"),
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		)
+	})
+
+	t.Run("redact nested values", func(t *testing.T) {
+		dir, cleanup := useSnapshotDir(t)
+		defer cleanup()
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`[{"ID": 1, "Name": "Joe"}, {"ID": 2, "Name": "Alice", "Token": null}]`),
+			Expect().Body().MatchSnapshot("list", ".[].ID", ".[].Token"),
+		)
+		buf, err := ioutil.ReadFile(filepath.Join(dir, "list.snap"))
+		require.NoError(t, err)
+		require.Equal(t, `[
+  {
+    "ID": "<redacted>",
+    "Name": "Joe"
+  },
+  {
+    "ID": "<redacted>",
+    "Name": "Alice",
+    "Token": null
+  }
+]
+`, string(buf))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		dir, cleanup := useSnapshotDir(t)
+		defer cleanup()
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String("Hello World"),
+			Expect().Body().MatchSnapshot("hello"),
+		)
+		buf, err := ioutil.ReadFile(filepath.Join(dir, "hello.snap"))
+		require.NoError(t, err)
+		require.Equal(t, "Hello World", string(buf))
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String("Hello Earth"),
+				Expect().Body().MatchSnapshot("hello"),
+			),
+			PtrStr("does not match the snapshot "+filepath.Join(dir, "hello.snap")+" (set HIT_UPDATE_SNAPSHOTS=1 to update the snapshot)"),
+			PtrStr("not equal"),
+			PtrStr(`expected: "Hello World"`),
+			PtrStr(`actual: "Hello Earth"`),
+			nil, nil, nil, nil,
+		)
+		// the snapshot is only rewritten if UpdateSnapshots is set
+		buf, err = ioutil.ReadFile(filepath.Join(dir, "hello.snap"))
+		require.NoError(t, err)
+		require.Equal(t, "Hello World", string(buf))
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Send().Body().String("Hello World"),
+				Expect().Body().MatchSnapshot("hello", ".ID"),
+			),
+			PtrStr("unable to redact the snapshot: data is not json"),
+		)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		dir, cleanup := useSnapshotDir(t)
+		defer cleanup()
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "hello.snap"), []byte("Hello World"), 0600))
+
+		withUpdateSnapshots(func() {
+			Test(t,
+				Post(s.URL),
+				Send().Body().String("Hello Earth"),
+				Expect().Body().MatchSnapshot("hello"),
+			)
+		})
+		buf, err := ioutil.ReadFile(filepath.Join(dir, "hello.snap"))
+		require.NoError(t, err)
+		require.Equal(t, "Hello Earth", string(buf))
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, cleanup := useSnapshotDir(t)
+		defer cleanup()
+
+		ExpectError(t,
+			Do(
+				Post(s.URL),
+				Expect().Body().MatchSnapshot("../hello"),
+			),
+			PtrStr(`invalid snapshot name "../hello": name must be relative to the snapshot directory`),
+		)
+	})
+}
+
+func TestExpectHeaders_MatchSnapshot(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	dir, cleanup := useSnapshotDir(t)
+	defer cleanup()
+
+	Test(t,
+		Post(s.URL),
+		Send().Headers("X-Request-Id").Add("1234"),
+		Send().Headers("Content-Type").Add("text/plain"),
+		Expect().AllHeaders().Contains("X-Request-Id"),
+		Expect().AllHeaders().MatchSnapshot("headers", `."X-Request-Id"`),
+		Expect().Headers("Content-Type").MatchSnapshot("content-type"),
+	)
+
+	buf, err := ioutil.ReadFile(filepath.Join(dir, "headers.snap"))
+	require.NoError(t, err)
+	require.Equal(t, `{
+  "Accept-Encoding": [
+    "gzip"
+  ],
+  "Content-Length": [
+    "0"
+  ],
+  "Content-Type": [
+    "text/plain"
+  ],
+  "X-Request-Id": "<redacted>"
+}
+`, string(buf))
+
+	buf, err = ioutil.ReadFile(filepath.Join(dir, "content-type.snap"))
+	require.NoError(t, err)
+	require.Equal(t, `[
+  "text/plain"
+]
+`, string(buf))
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Send().Headers("Content-Type").Add("application/json"),
+			Expect().Headers("Content-Type").MatchSnapshot("content-type"),
+		),
+		PtrStr("does not match the snapshot "+filepath.Join(dir, "content-type.snap")+" (set HIT_UPDATE_SNAPSHOTS=1 to update the snapshot)"),
+		PtrStr("not equal"),
+		PtrStr("expected: []interface {}{"),
+		PtrStr(`"text/plain",`),
+		PtrStr("}"),
+		PtrStr("actual: []interface {}{"),
+		PtrStr(`"application/json",`),
+		PtrStr("}"),
+		nil, nil, nil, nil,
+	)
+}
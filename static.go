@@ -1,12 +1,15 @@
 package hit
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
-	"os"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/Eun/go-hit/internal/misc"
 
@@ -120,6 +123,19 @@ func Store() IStore {
 	return newStore()
 }
 
+// Sign signs the request, the signing steps run after all Send() steps, so they sign the final request.
+//
+// Example:
+//     MustDo(
+//         Post("https://example.com/post"),
+//         Send().Body().String("Hello World"),
+//         Sign().HMAC("X-Signature", sha256.New, []byte("secret"), nil),
+//         Expect().Status().Equal(http.StatusOK),
+//     )
+func Sign() ISign {
+	return newSign(newCallPath("Sign", nil))
+}
+
 // HTTPClient sets the client for the request.
 //
 // Example:
@@ -139,6 +155,35 @@ func HTTPClient(client *http.Client) IStep {
 	}
 }
 
+// CookieJar sets the cookie jar that will be used for the request, the jar will be attached to a copy of the
+// current http client, so the client itself will not be modified.
+// Using nil as jar disables the jar.
+//
+// Example:
+//     jar, _ := cookiejar.New(nil)
+//     MustDo(
+//         Get("https://example.com/cookies/set/session/abc123"),
+//         CookieJar(jar),
+//     )
+//     MustDo(
+//         Get("https://example.com/cookies"),
+//         CookieJar(jar),
+//         Expect().Body().JSON().JQ(".cookies.session").Equal("abc123"),
+//     )
+func CookieJar(jar http.CookieJar) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("CookieJar", nil),
+		Exec: func(hit *hitImpl) error {
+			client := *hit.client
+			client.Jar = jar
+			hit.client = &client
+			return nil
+		},
+	}
+}
+
 // BaseURL sets the base url for each Connect, Delete, Get, Head, Post, Options, Put, Trace or Method.
 //
 // Examples:
@@ -179,6 +224,8 @@ func Request() IRequest {
 }
 
 // Method sets the specified method and url.
+// Placeholders (e.g. {{.userId}}) in the url will be replaced with the corresponding variables, the values will be
+// escaped (e.g. a / in a path placeholder will be sent as %2F).
 //
 // Examples:
 //     MustDo(
@@ -205,7 +252,7 @@ func makeMethodStep(fnName, method, url string, a ...interface{}) IStep {
 				return nil
 			}
 			var err error
-			hit.request.URL, err = urlpkg.Parse(u)
+			hit.request.URL, err = hit.interpolateURL(u)
 			if err != nil {
 				return err
 			}
@@ -329,20 +376,37 @@ func Trace(url string, a ...interface{}) IStep {
 }
 
 // Test runs the specified steps and calls t.FailNow() if any error occurs during execution.
+// Debug() output will be written to t.Log() if t supports it.
 func Test(t TestingT, steps ...IStep) {
-	if err := Do(steps...); err != nil {
-		_, _ = os.Stderr.WriteString(err.Error())
-		t.FailNow()
+	helper(t)
+	hit := newHit(steps...)
+	hit.stdout = testingOutput(t)
+	test(t, hit)
+}
+
+func test(t TestingT, hit *hitImpl) {
+	helper(t)
+	if err := hit.run(); err != nil {
+		report(t, err)
 	}
 }
 
 // do func that ensures we always return an *ErrorTrace.
 func do(steps ...IStep) *Error {
-	hit := &hitImpl{
-		client: http.DefaultClient,
-		steps:  steps,
-		state:  combineStep,
+	return newHit(steps...).run()
+}
+
+func newHit(steps ...IStep) *hitImpl {
+	return &hitImpl{
+		client:    http.DefaultClient,
+		steps:     steps,
+		state:     combineStep,
+		variables: newVariables(),
 	}
+}
+
+// run executes all steps of the hit instance.
+func (hit *hitImpl) run() *Error {
 	if err := hit.runSteps(combineStep); err != nil {
 		return err
 	}
@@ -351,11 +415,69 @@ func do(steps ...IStep) *Error {
 		return err
 	}
 
-	hit.request = newHTTPRequest(hit, nil)
-	hit.request.Header = map[string][]string{
-		// remove some standard headers
-		"User-Agent": {""},
+	// transports that were copied by modifyTransport are only used for this run
+	defer hit.closeTransports()
+
+	// keep a copy of the steps, so we can restore them for every attempt
+	stepsToRun := make([]IStep, len(hit.steps))
+	copy(stepsToRun, hit.steps)
+
+	// keep the client, steps that modify the client (e.g. TLS steps) must start with the original client on every
+	// attempt, otherwise their modifications would be applied multiple times
+	client := hit.client
+
+	// body sources of the previous attempts, used to detect request bodies that cannot be replayed
+	var bodySources []io.Reader
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		hit.steps = make([]IStep, len(stepsToRun))
+		copy(hit.steps, stepsToRun)
+		hit.client = client
+		hit.retry = nil
+		hit.response = nil
+		hit.collectAllFailures = false
+		hit.includeCurlInError = false
+		hit.har = nil
+		hit.failures = nil
+		hit.redactedHeaders = nil
+		hit.cassette = nil
+
+		err := doAttempt(hit, start, &bodySources)
+		if err == nil {
+			return closeBodies(hit)
+		}
+		// errors during the request creation will not go away by retrying
+		if hit.state <= requestCreateStep || hit.retry == nil || xerrors.Is(err.et.Unwrap(), errBodyNotReplayable) {
+			_ = closeBodies(hit)
+			return err
+		}
+
+		_ = closeBodies(hit)
+		if !hit.retry.waitForNextAttempt(hit.Context(), start, attempt) {
+			if attempt > 1 {
+				err.et.SetError(&retryError{
+					attempts: attempt,
+					err:      err.et.Unwrap(),
+				})
+			}
+			return err
+		}
 	}
+}
+
+// errBodyNotReplayable will be returned if a retry would send a body reader that was already consumed by a previous
+// attempt.
+var errBodyNotReplayable = xerrors.New("unable to replay the request body: the body reader was consumed by a " +
+	"previous attempt, use Send().Body().Reader() to send a reader multiple times")
+
+// doAttempt runs all steps from requestCreateStep until AfterExpectStep.
+// start is the time the first attempt was started, bodySources holds the body readers of the previous attempts.
+func doAttempt(hit *hitImpl, start time.Time, bodySources *[]io.Reader) *Error {
+	hit.request = newHTTPRequest(hit, nil)
+	// remove some standard headers
+	// (modify the existing map, the request body holds a reference to it)
+	hit.request.Header["User-Agent"] = []string{""}
 	hit.state = requestCreateStep
 	if err := hit.runSteps(requestCreateStep); err != nil {
 		return err
@@ -370,47 +492,83 @@ func do(steps ...IStep) *Error {
 		hit.request.URL.Scheme = "https"
 	}
 
-	hit.state = BeforeSendStep
-	if err := hit.runSteps(BeforeSendStep); err != nil {
-		return err
+	for _, state := range []StepTime{BeforeSendStep, SendStep, AfterSendStep} {
+		hit.state = state
+		if err := hit.runSteps(state); err != nil {
+			return err
+		}
 	}
-	hit.state = SendStep
-	if err := hit.runSteps(SendStep); err != nil {
-		return err
+	// the setters of the body (and Send().Body().Reader()) create a new reader for every attempt, a reader that was
+	// used before has been consumed
+	if source := hit.request.Body().Source(); source != nil && reflect.TypeOf(source).Comparable() {
+		for _, used := range *bodySources {
+			if used == source {
+				return wrapError(hit, errBodyNotReplayable)
+			}
+		}
+		*bodySources = append(*bodySources, source)
 	}
-	hit.state = AfterSendStep
-	if err := hit.runSteps(AfterSendStep); err != nil {
-		return err
+	bodyLength, err := hit.request.Body().Length()
+	if err != nil {
+		return wrapError(hit, xerrors.Errorf("unable to get body length: %w", err))
 	}
 	hit.request.Request.Body = hit.request.Body().Reader()
-	res, err := hit.client.Do(hit.request.Request)
+	var redirects []*http.Response
+	client := recordRedirects(hit.client, &redirects)
+	if hit.cassette != nil {
+		client = hit.cassette.install(hit, client)
+	}
+	req := hit.request.Request
+	// a single attempt must not exceed the timeout of the retry policy
+	if hit.retry != nil && hit.retry.Timeout > 0 {
+		ctx, cancel := context.WithDeadline(req.Context(), start.Add(hit.retry.Timeout))
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	timing := newTimingRecorder()
+	started := time.Now()
+	res, err := client.Do(timing.withClientTrace(req))
+	wait := time.Since(started)
 	if err != nil {
+		if hit.har != nil {
+			hit.har.record(hit, started, wait, err)
+		}
 		return wrapError(hit, xerrors.Errorf("unable to perform request: %w", err))
 	}
-	hit.request.Request.ContentLength, err = hit.request.Body().Length()
+	hit.request.Request.ContentLength = bodyLength
+
+	// read the body right away, so the timings do not depend on the steps that run before the body is used
+	body, err := timing.readBody(res.Body)
 	if err != nil {
-		return wrapError(hit, xerrors.Errorf("unable to get body length: %w", err))
+		if hit.har != nil {
+			hit.har.record(hit, started, wait, err)
+		}
+		return wrapError(hit, xerrors.Errorf("unable to read response body: %w", err))
 	}
-
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
 	hit.response = newHTTPResponse(hit, res)
-	hit.state = BeforeExpectStep
-	if err := hit.runSteps(BeforeExpectStep); err != nil {
-		return err
-	}
-	hit.state = ExpectStep
-	if err := hit.runSteps(ExpectStep); err != nil {
-		return err
-	}
-	hit.state = AfterExpectStep
-	if err := hit.runSteps(AfterExpectStep); err != nil {
-		return err
+	hit.response.redirects = redirects
+	hit.response.timing = timing
+	if hit.har != nil {
+		hit.har.record(hit, started, wait, nil)
+	}
+	for _, state := range []StepTime{BeforeExpectStep, ExpectStep, AfterExpectStep} {
+		hit.state = state
+		if err := hit.runSteps(state); err != nil {
+			return err
+		}
 	}
-	if hit.request.Request.Body != nil {
+	return collectedError(hit.failures)
+}
+
+// closeBodies closes the request and response bodies of the current attempt.
+func closeBodies(hit *hitImpl) *Error {
+	if hit.request != nil && hit.request.Request.Body != nil {
 		if err := hit.request.Request.Body.Close(); err != nil {
 			return wrapError(hit, err)
 		}
 	}
-	if hit.response.Response.Body != nil {
+	if hit.response != nil && hit.response.Response.Body != nil {
 		if err := hit.response.Response.Body.Close(); err != nil {
 			return wrapError(hit, err)
 		}
@@ -557,6 +715,29 @@ func Return() IStep {
 	}
 }
 
+// CollectAllFailures runs all Expect() steps (and all steps that run during ExpectStep and AfterExpectStep) even if
+// some of them fail. The returned error contains all failures.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com"),
+//         CollectAllFailures(),
+//         Expect().Status().Equal(http.StatusOK),
+//         Expect().Headers("Content-Type").NotEmpty(),
+//         Expect().Body().String().Contains("Hello World"),
+//     )
+func CollectAllFailures() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("CollectAllFailures", nil),
+		Exec: func(hit *hitImpl) error {
+			hit.collectAllFailures = true
+			return nil
+		},
+	}
+}
+
 // Context sets the context for the request.
 //
 // Example:
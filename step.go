@@ -111,7 +111,16 @@ func execStep(hit *hitImpl, step IStep) (err *Error) {
 		setMeta := func() {
 			step.trace().SetDescription(hit.Description())
 			var b bytes.Buffer
-			if newDebug(step.callPath().Push("Debug", nil), &b).exec(hit) == nil {
+			if newDebug(step.callPath().Push("Debug", nil), &b).exec(hit) != nil {
+				b.Reset()
+			}
+			if s := curlErrorContext(hit); s != "" {
+				if b.Len() > 0 {
+					b.WriteRune('\n')
+				}
+				b.WriteString(s)
+			}
+			if b.Len() > 0 {
 				step.trace().SetContext(b.String())
 			}
 		}
@@ -72,6 +72,21 @@ type IStoreStep interface {
 	//         Store().Response().Body().String().In(&body),
 	//     )
 	In(interface{}) IStep
+
+	// As can be used to store the result as a named variable, the variable can be used in placeholders (e.g.
+	// {{.userId}}) of following steps and requests.
+	// Placeholders of variables that are not set result in an error, use \{{ to keep a literal placeholder.
+	//
+	// Example:
+	//     s := NewSession(BaseURL("https://example.com"))
+	//     s.MustDo(
+	//         Get("/json"),
+	//         Store().Response().Body().JSON().JQ(".ID").As("userId"),
+	//     )
+	//     s.MustDo(
+	//         Get("/get?id={{.userId}}"),
+	//     )
+	As(name string) IStep
 }
 
 func newStoreStep(f storeFunc) IStoreStep {
@@ -88,6 +103,10 @@ func (s *storeStep) In(v interface{}) IStep {
 	return newStoreInStep(s.f, v)
 }
 
+func (s *storeStep) As(name string) IStep {
+	return newStoreAsStep(s.f, name)
+}
+
 func newStoreInStep(f storeFunc, v interface{}) IStep {
 	return &hitStep{
 		Trace:    ett.Prepare(),
@@ -99,6 +118,22 @@ func newStoreInStep(f storeFunc, v interface{}) IStep {
 	}
 }
 
+func newStoreAsStep(f storeFunc, name string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     AfterExpectStep,
+		CallPath: nil,
+		Exec: func(hit *hitImpl) error {
+			var v interface{}
+			if err := f(hit, &v); err != nil {
+				return err
+			}
+			hit.SetVariable(name, v)
+			return nil
+		},
+	}
+}
+
 func storeStringSlice(in []string, out interface{}) error {
 	if out == nil {
 		return xerrors.New("destination type cannot be nil")
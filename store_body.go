@@ -92,6 +92,18 @@ type IStoreBody interface {
 	//     )
 	JSON() IStoreBodyJSON
 
+	// Multipart treats the body as a multipart body (e.g. multipart/mixed or multipart/byteranges), the parts can
+	// than be stored afterwards.
+	//
+	// Usage:
+	//     var count int
+	//     Store().Response().Body().Multipart().Len().In(&count)
+	//     var contentType string
+	//     Store().Response().Body().Multipart().Part(0).Headers("Content-Type").In(&contentType)
+	//     var name string
+	//     Store().Response().Body().Multipart().Part(0).Body().JSON().JQ(".Name").In(&name)
+	Multipart() IStoreBodyMultipart
+
 	// String treats the body contents as String data and stores it
 	//
 	// Usage:
@@ -133,30 +145,37 @@ type IStoreBody interface {
 	//     var body uint64
 	//     Store().Response().Body().Uint64().In(&body)
 	Uint64() IStoreStep
-}
 
-type storeBodyMode int
+	// XML treats the body as XML data and stores it
+	//
+	// Example:
+	//     // given the following body: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     type Entry struct {
+	//         ID    int    `xml:"id,attr"`
+	//         Title string `xml:"title"`
+	//     }
+	//     var feed struct {
+	//         Entries []Entry `xml:"entry"`
+	//     }
+	//     var entry Entry
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Store().Response().Body().XML().In(&feed),                           // decode the whole body
+	//         Store().Response().Body().XML().XPath("/feed/entry[2]").In(&entry), // store the second entry
+	//     )
+	XML() IStoreBodyXML
+}
 
-const (
-	storeBodyRequest storeBodyMode = iota
-	storeBodyResponse
-)
+type storeBodyCallback func(hit Hit) *httpbody.HTTPBody
 
 type storeBody struct {
-	mode storeBodyMode
+	body storeBodyCallback
 }
 
-func newStoreBody(mode storeBodyMode) IStoreBody {
+func newStoreBody(body storeBodyCallback) IStoreBody {
 	return &storeBody{
-		mode: mode,
-	}
-}
-
-func (s *storeBody) body(hit Hit) *httpbody.HTTPBody {
-	if s.mode == storeBodyRequest {
-		return hit.Request().Body()
+		body: body,
 	}
-	return hit.Response().Body()
 }
 
 func (s *storeBody) Bool() IStoreStep {
@@ -226,7 +245,11 @@ func (s *storeBody) Int64() IStoreStep {
 }
 
 func (s *storeBody) JSON() IStoreBodyJSON {
-	return newStoreBodyJSON(s.mode)
+	return newStoreBodyJSON(s.body)
+}
+
+func (s *storeBody) Multipart() IStoreBodyMultipart {
+	return newStoreBodyMultipart(s.body)
 }
 
 func (s *storeBody) String() IStoreStep {
@@ -264,3 +287,7 @@ func (s *storeBody) Uint64() IStoreStep {
 		return converter.Convert(s.body(hit).MustUint64(), v)
 	})
 }
+
+func (s *storeBody) XML() IStoreBodyXML {
+	return newStoreBodyXML(s.body)
+}
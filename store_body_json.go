@@ -1,9 +1,5 @@
 package hit
 
-import (
-	"github.com/Eun/go-hit/httpbody"
-)
-
 // IStoreBodyJSON defines the functions that can be used to store data from the http request/response body
 // (in JSON format).
 type IStoreBodyJSON interface {
@@ -22,20 +18,13 @@ type IStoreBodyJSON interface {
 }
 
 type storeBodyJSON struct {
-	mode storeBodyMode
+	body storeBodyCallback
 }
 
-func newStoreBodyJSON(mode storeBodyMode) IStoreBodyJSON {
+func newStoreBodyJSON(body storeBodyCallback) IStoreBodyJSON {
 	return &storeBodyJSON{
-		mode: mode,
-	}
-}
-
-func (s *storeBodyJSON) body(hit Hit) *httpbody.HTTPBody {
-	if s.mode == storeBodyRequest {
-		return hit.Request().Body()
+		body: body,
 	}
-	return hit.Response().Body()
 }
 
 func (s *storeBodyJSON) JQ(expression ...string) IStoreStep {
@@ -44,8 +33,14 @@ func (s *storeBodyJSON) JQ(expression ...string) IStoreStep {
 	})
 }
 
+func (s *storeBodyJSON) decode(hit Hit, v interface{}) error {
+	return s.body(hit).JSON().Decode(v)
+}
+
 func (s *storeBodyJSON) In(v interface{}) IStep {
-	return newStoreInStep(func(hit Hit, v interface{}) error {
-		return s.body(hit).JSON().Decode(v)
-	}, v)
+	return newStoreInStep(s.decode, v)
+}
+
+func (s *storeBodyJSON) As(name string) IStep {
+	return newStoreAsStep(s.decode, name)
 }
@@ -0,0 +1,113 @@
+package hit
+
+import (
+	"net/http"
+
+	"github.com/Eun/go-hit/httpbody"
+	"github.com/Eun/go-hit/internal/converter"
+)
+
+// IStoreBodyMultipart defines the functions that can be used to store data from the http request/response body
+// (in multipart format).
+type IStoreBodyMultipart interface {
+	// Len stores the number of parts.
+	//
+	// Usage:
+	//     var count int
+	//     Store().Response().Body().Multipart().Len().In(&count)
+	Len() IStoreStep
+
+	// Part returns the part with the specified index, the first part has the index 0.
+	//
+	// Usage:
+	//     var contentRange string
+	//     Store().Response().Body().Multipart().Part(0).Headers("Content-Range").In(&contentRange)
+	//     var body string
+	//     Store().Response().Body().Multipart().Part(1).Body().String().In(&body)
+	Part(index int) IStoreBodyMultipartPart
+}
+
+// IStoreBodyMultipartPart defines the functions that can be used to store data from a single part of a multipart
+// body.
+type IStoreBodyMultipartPart interface {
+	// Headers stores the part headers, if the headerName is specified only the values of this header will be stored.
+	//
+	// Usage:
+	//     var headers http.Header
+	//     Store().Response().Body().Multipart().Part(0).Headers().In(&headers)
+	//     var contentType string
+	//     Store().Response().Body().Multipart().Part(0).Headers("Content-Type").In(&contentType)
+	Headers(headerName ...string) IStoreStep
+
+	// Body treats the part contents as body, all functions that are available for the response body can be used.
+	//
+	// Usage:
+	//     var name string
+	//     Store().Response().Body().Multipart().Part(0).Body().JSON().JQ(".Name").In(&name)
+	Body() IStoreBody
+}
+
+type storeBodyMultipart struct {
+	body storeBodyCallback
+}
+
+func newStoreBodyMultipart(body storeBodyCallback) IStoreBodyMultipart {
+	return &storeBodyMultipart{
+		body: body,
+	}
+}
+
+func (s *storeBodyMultipart) Len() IStoreStep {
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		m, err := s.body(hit).Multipart()
+		if err != nil {
+			return err
+		}
+		return converter.Convert(len(m.Parts()), v)
+	})
+}
+
+func (s *storeBodyMultipart) Part(index int) IStoreBodyMultipartPart {
+	return &storeBodyMultipartPart{
+		body:  s.body,
+		index: index,
+	}
+}
+
+type storeBodyMultipartPart struct {
+	body  storeBodyCallback
+	index int
+}
+
+func (s *storeBodyMultipartPart) part(hit Hit) (*httpbody.MultipartPart, error) {
+	m, err := s.body(hit).Multipart()
+	if err != nil {
+		return nil, err
+	}
+	return m.Part(s.index)
+}
+
+func (s *storeBodyMultipartPart) Headers(headerName ...string) IStoreStep {
+	if header, ok := getLastStringArgument(headerName); ok {
+		return newStoreStep(func(hit Hit, v interface{}) error {
+			p, err := s.part(hit)
+			if err != nil {
+				return err
+			}
+			return storeStringSlice(p.Header.Values(header), v)
+		})
+	}
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		p, err := s.part(hit)
+		if err != nil {
+			return err
+		}
+		return converter.Convert(http.Header(p.Header), v)
+	})
+}
+
+func (s *storeBodyMultipartPart) Body() IStoreBody {
+	return newStoreBody(func(hit Hit) *httpbody.HTTPBody {
+		return s.body(hit).MustMultipart().MustPart(s.index).Body()
+	})
+}
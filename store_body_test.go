@@ -1,6 +1,7 @@
 package hit_test
 
 import (
+	"net/http"
 	"testing"
 
 	"net/url"
@@ -262,6 +263,94 @@ func TestStoreBody(t *testing.T) {
 			})
 		})
 
+		t.Run("xml", func(t *testing.T) {
+			type Entry struct {
+				ID    int    `xml:"id,attr"`
+				Title string `xml:"title"`
+			}
+			type Feed struct {
+				Entries []Entry `xml:"entry"`
+			}
+			body := `<feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>`
+
+			t.Run("struct", func(t *testing.T) {
+				var v Feed
+				Test(t,
+					Post(s.URL),
+					Send().Body().String(body),
+					storeBody().XML().In(&v),
+				)
+				require.Equal(t, Feed{Entries: []Entry{{1, "Hello"}, {2, "World"}}}, v)
+			})
+
+			t.Run("XPath", func(t *testing.T) {
+				t.Run("string", func(t *testing.T) {
+					var str string
+					Test(t,
+						Post(s.URL),
+						Send().Body().String(body),
+						storeBody().XML().XPath("/feed/entry[2]/title").In(&str),
+					)
+					require.Equal(t, "World", str)
+				})
+
+				t.Run("int", func(t *testing.T) {
+					var n int
+					Test(t,
+						Post(s.URL),
+						Send().Body().String(body),
+						storeBody().XML().XPath("/feed/entry[2]/@id").In(&n),
+					)
+					require.Equal(t, 2, n)
+				})
+
+				t.Run("struct", func(t *testing.T) {
+					var entry Entry
+					Test(t,
+						Post(s.URL),
+						Send().Body().String(body),
+						storeBody().XML().XPath("/feed/entry[1]").In(&entry),
+					)
+					require.Equal(t, Entry{1, "Hello"}, entry)
+				})
+			})
+		})
+
+		t.Run("multipart", func(t *testing.T) {
+			var n int
+			var headers http.Header
+			var contentType string
+			var name string
+			var str string
+			Test(t,
+				Post(s.URL),
+				Send().Body().Multipart().Part("user", http.Header{"Content-Type": {"application/json"}}, `{"Name": "Joe"}`),
+				Send().Body().Multipart().Field("greeting", "Hello World"),
+				storeBody().Multipart().Len().In(&n),
+				storeBody().Multipart().Part(0).Headers().In(&headers),
+				storeBody().Multipart().Part(0).Headers("Content-Type").In(&contentType),
+				storeBody().Multipart().Part(0).Body().JSON().JQ(".Name").In(&name),
+				storeBody().Multipart().Part(1).Body().String().In(&str),
+			)
+			require.Equal(t, 2, n)
+			require.Equal(t, http.Header{
+				"Content-Disposition": {`form-data; name="user"`},
+				"Content-Type":        {"application/json"},
+			}, headers)
+			require.Equal(t, "application/json", contentType)
+			require.Equal(t, "Joe", name)
+			require.Equal(t, "Hello World", str)
+
+			ExpectError(t,
+				Do(
+					Post(s.URL),
+					Send().Body().Multipart().Field("greeting", "Hello World"),
+					storeBody().Multipart().Part(1).Headers().In(&headers),
+				),
+				PtrStr("unable to find part 1, body has 1 parts"),
+			)
+		})
+
 		t.Run("string", func(t *testing.T) {
 			var v string
 			Test(t,
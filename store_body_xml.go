@@ -0,0 +1,50 @@
+package hit
+
+// IStoreBodyXML defines the functions that can be used to store data from the http request/response body
+// (in XML format).
+type IStoreBodyXML interface {
+	IStoreStep
+
+	// XPath evaluates an XPath 1.0 expression on the XML body the result can than be stored afterwards.
+	// If the expression selects elements they can be stored in structs (the first element) or slices (all elements),
+	// other containers receive the text of the first selected node.
+	//
+	// Example:
+	//     // given the following body: <feed><entry id="1"><title>Hello</title></entry><entry id="2"><title>World</title></entry></feed>
+	//     var title string
+	//     var id int
+	//     MustDo(
+	//         Get("https://example.com/xml"),
+	//         Store().Response().Body().XML().XPath("/feed/entry[1]/title").In(&title), // store "Hello" in title
+	//         Store().Response().Body().XML().XPath("/feed/entry[2]/@id").In(&id),      // store 2 in id
+	//     )
+	XPath(expression string) IStoreStep
+}
+
+type storeBodyXML struct {
+	body storeBodyCallback
+}
+
+func newStoreBodyXML(body storeBodyCallback) IStoreBodyXML {
+	return &storeBodyXML{
+		body: body,
+	}
+}
+
+func (s *storeBodyXML) XPath(expression string) IStoreStep {
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		return s.body(hit).XML().XPath(v, expression)
+	})
+}
+
+func (s *storeBodyXML) decode(hit Hit, v interface{}) error {
+	return s.body(hit).XML().Decode(v)
+}
+
+func (s *storeBodyXML) In(v interface{}) IStep {
+	return newStoreInStep(s.decode, v)
+}
+
+func (s *storeBodyXML) As(name string) IStep {
+	return newStoreAsStep(s.decode, name)
+}
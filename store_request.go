@@ -1,6 +1,7 @@
 package hit
 
 import (
+	"github.com/Eun/go-hit/httpbody"
 	"github.com/Eun/go-hit/internal/converter"
 )
 
@@ -71,6 +72,14 @@ type IStoreRequest interface {
 	//     var name string
 	//     Store().Request().Body().JSON().JQ(".Name").In(&name) // store "Joe" in name
 	Body() IStoreBody
+
+	// Curl stores the Request as a curl command line.
+	// Unlike Debug().Request().Curl() credentials will not be redacted.
+	//
+	// Usage:
+	//     var cmd string
+	//     Store().Request().Curl().In(&cmd)
+	Curl() IStoreStep
 }
 
 type storeRequest struct{}
@@ -148,5 +157,17 @@ func (d *storeRequest) Trailers(trailerName ...string) IStoreStep {
 }
 
 func (d *storeRequest) Body() IStoreBody {
-	return newStoreBody(storeBodyRequest)
+	return newStoreBody(func(hit Hit) *httpbody.HTTPBody {
+		return hit.Request().Body()
+	})
+}
+
+func (d *storeRequest) Curl() IStoreStep {
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		s, err := curlCommand(hit)
+		if err != nil {
+			return err
+		}
+		return converter.Convert(s, v)
+	})
 }
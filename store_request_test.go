@@ -363,3 +363,48 @@ func TestStoreRequest_Host(t *testing.T) {
 
 	require.Equal(t, "example.com", host)
 }
+
+func TestStoreRequest_Curl(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	var cmd string
+	Test(t,
+		Put(s.URL),
+		Request().URL().Query("page").Add(1),
+		Request().URL().User().Username("joe"),
+		Send().Body().String("it's me"),
+		Store().Request().Curl().In(&cmd),
+	)
+	require.Equal(t, `curl -X PUT -u joe '`+s.URL+`?page=1' -H User-Agent: --data-binary 'it'\''s me'`, cmd)
+}
+
+func TestIncludeCurlInError(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	err := Do(
+		Post(s.URL),
+		IncludeCurlInError(),
+		Send().Body().String("Hello World"),
+		Expect().Body().String().Equal("Hello Universe"),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `curl -X POST `+s.URL+` -H User-Agent: --data-binary 'Hello World'`)
+
+	err = Do(
+		Post(s.URL),
+		Send().Body().String("Hello World"),
+		Expect().Body().String().Equal("Hello Universe"),
+	)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), `curl -X POST`)
+
+	// connection errors
+	err = Do(
+		Get("http://127.0.0.1:0/index.html"),
+		IncludeCurlInError(),
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `curl http://127.0.0.1:0/index.html -H User-Agent:`)
+}
@@ -1,9 +1,14 @@
 package hit
 
 import (
+	"crypto/tls"
 	"io"
 	"io/ioutil"
+	"net/http"
 
+	"golang.org/x/xerrors"
+
+	"github.com/Eun/go-hit/httpbody"
 	"github.com/Eun/go-hit/internal/converter"
 )
 
@@ -56,6 +61,22 @@ type IStoreResponse interface {
 	//     Store().Response().Trailers("Content-Type").In(&contentType)
 	Trailers(trailerName ...string) IStoreStep
 
+	// Cookies stores the cookies that were set by the response (using the Set-Cookie header)
+	//
+	// If you specify the argument you can directly store the cookie (if the cookie was set multiple times the last one
+	// will be stored), storing it in a string stores the cookie value.
+	//
+	// Usage:
+	//     var cookies []*http.Cookie
+	//     Store().Response().Cookies().In(&cookies)
+	//
+	//     var session *http.Cookie
+	//     Store().Response().Cookies("session").In(&session)
+	//
+	//     var sessionID string
+	//     Store().Response().Cookies("session").In(&sessionID)
+	Cookies(name ...string) IStoreStep
+
 	// Body stores the Response's Body
 	//
 	// given the following body: { "ID": 10, "Name": "Joe", "Roles": ["Admin", "User"] }
@@ -68,6 +89,21 @@ type IStoreResponse interface {
 
 	// Uncompressed stores the Response's Uncompressed status
 	Uncompressed() IStoreStep
+
+	// Timing stores the timing breakdown of the request
+	//
+	// Usage:
+	//     var timing Timing
+	//     Store().Response().Timing().In(&timing)
+	Timing() IStoreStep
+
+	// TLS stores the tls connection state of the Response, storing fails if the Response was not received over a tls
+	// connection.
+	//
+	// Usage:
+	//     var state *tls.ConnectionState
+	//     Store().Response().TLS().In(&state)
+	TLS() IStoreStep
 }
 
 type storeResponse struct{}
@@ -144,8 +180,41 @@ func (d *storeResponse) Trailers(trailerName ...string) IStoreStep {
 	})
 }
 
+func (d *storeResponse) Cookies(name ...string) IStoreStep {
+	if n, ok := getLastStringArgument(name); ok {
+		return newStoreStep(func(hit Hit, v interface{}) error {
+			cookie := findCookie(hit.Response().Cookies(), n)
+			if cookie == nil {
+				return xerrors.Errorf("cookie %q was not set", n)
+			}
+			switch t := v.(type) {
+			case **http.Cookie:
+				*t = cookie
+				return nil
+			case *http.Cookie:
+				*t = *cookie
+				return nil
+			case *string:
+				*t = cookie.Value
+				return nil
+			}
+			return converter.Convert(cookie, v)
+		})
+	}
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		cookies := hit.Response().Cookies()
+		if t, ok := v.(*[]*http.Cookie); ok {
+			*t = cookies
+			return nil
+		}
+		return converter.Convert(cookies, v)
+	})
+}
+
 func (d *storeResponse) Body() IStoreBody {
-	return newStoreBody(storeBodyResponse)
+	return newStoreBody(func(hit Hit) *httpbody.HTTPBody {
+		return hit.Response().Body()
+	})
 }
 
 func (d *storeResponse) Uncompressed() IStoreStep {
@@ -153,3 +222,32 @@ func (d *storeResponse) Uncompressed() IStoreStep {
 		return converter.Convert(hit.Response().Uncompressed, v)
 	})
 }
+
+func (d *storeResponse) Timing() IStoreStep {
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		timing := hit.Response().Timing()
+		if t, ok := v.(*Timing); ok {
+			*t = timing
+			return nil
+		}
+		return converter.Convert(timing, v)
+	})
+}
+
+func (d *storeResponse) TLS() IStoreStep {
+	return newStoreStep(func(hit Hit, v interface{}) error {
+		state := hit.Response().TLS
+		if state == nil {
+			return xerrors.New("response was not received over a tls connection")
+		}
+		switch t := v.(type) {
+		case **tls.ConnectionState:
+			*t = state
+			return nil
+		case *tls.ConnectionState:
+			*t = *state
+			return nil
+		}
+		return converter.Convert(state, v)
+	})
+}
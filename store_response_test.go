@@ -1,6 +1,7 @@
 package hit_test
 
 import (
+	"crypto/tls"
 	"testing"
 
 	. "github.com/Eun/go-hit"
@@ -109,6 +110,86 @@ func TestStoreResponse_TransferEncoding(t *testing.T) {
 	require.Equal(t, []string{"gzip", "chunked"}, transferEncoding)
 }
 
+func TestStoreResponse_Cookies(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	var cookies []*http.Cookie
+	var session *http.Cookie
+	var cookie http.Cookie
+	var value string
+	Test(t,
+		Post(s.URL),
+		Send().Headers("Set-Cookie").Add("session=abc123; Path=/; HttpOnly", "theme=dark"),
+		Store().Response().Cookies().In(&cookies),
+		Store().Response().Cookies("session").In(&session),
+		Store().Response().Cookies("theme").In(&cookie),
+		Store().Response().Cookies("session").In(&value),
+	)
+
+	require.Len(t, cookies, 2)
+	require.Equal(t, "session", cookies[0].Name)
+	require.Equal(t, "theme", cookies[1].Name)
+	require.NotNil(t, session)
+	require.Equal(t, "abc123", session.Value)
+	require.Equal(t, "/", session.Path)
+	require.True(t, session.HttpOnly)
+	require.Equal(t, "dark", cookie.Value)
+	require.Equal(t, "abc123", value)
+
+	ExpectError(t,
+		Do(
+			Post(s.URL),
+			Store().Response().Cookies("session").In(&value),
+		),
+		PtrStr(`cookie "session" was not set`),
+	)
+}
+
+func TestStoreResponse_Timing(t *testing.T) {
+	s := SlowServer()
+	defer s.Close()
+
+	var timing Timing
+	Test(t,
+		Get(s.URL),
+		Store().Response().Timing().In(&timing),
+	)
+
+	require.GreaterOrEqual(t, int64(timing.TimeToFirstByte), int64(slowServerDelay))
+	require.GreaterOrEqual(t, int64(timing.BodyRead), int64(slowServerDelay))
+	require.Equal(t, timing.TimeToFirstByte+timing.BodyRead, timing.Total)
+}
+
+func TestStoreResponse_TLS(t *testing.T) {
+	s := TLSServer()
+	defer s.Close()
+
+	var statePtr *tls.ConnectionState
+	var state tls.ConnectionState
+	Test(t,
+		Get(s.URL),
+		HTTPClient(s.Client()),
+		Store().Response().TLS().In(&statePtr),
+		Store().Response().TLS().In(&state),
+	)
+
+	require.NotNil(t, statePtr)
+	require.Equal(t, "h2", statePtr.NegotiatedProtocol)
+	require.Equal(t, "h2", state.NegotiatedProtocol)
+	require.True(t, state.HandshakeComplete)
+
+	es := EchoServer()
+	defer es.Close()
+	ExpectError(t,
+		Do(
+			Post(es.URL),
+			Store().Response().TLS().In(&statePtr),
+		),
+		PtrStr("response was not received over a tls connection"),
+	)
+}
+
 func TestStoreResponse_Uncompressed(t *testing.T) {
 	s := EchoServer()
 	defer s.Close()
@@ -71,10 +71,16 @@ func newStoreURL() IStoreURL {
 	return &storeURL{}
 }
 
+func (s *storeURL) url(hit Hit, v interface{}) error {
+	return converter.Convert(hit.Request().URL, v)
+}
+
 func (s *storeURL) In(v interface{}) IStep {
-	return newStoreInStep(func(hit Hit, v interface{}) error {
-		return converter.Convert(hit.Request().URL, v)
-	}, v)
+	return newStoreInStep(s.url, v)
+}
+
+func (s *storeURL) As(name string) IStep {
+	return newStoreAsStep(s.url, name)
 }
 
 func (s *storeURL) Scheme() IStoreStep {
@@ -21,10 +21,16 @@ func newStoreUserInfo() IStoreUserInfo {
 	return &storeUserInfo{}
 }
 
+func (s *storeUserInfo) userInfo(hit Hit, v interface{}) error {
+	return converter.Convert(hit.Request().URL.User, v)
+}
+
 func (s *storeUserInfo) In(v interface{}) IStep {
-	return newStoreInStep(func(hit Hit, container interface{}) error {
-		return converter.Convert(hit.Request().URL.User, container)
-	}, v)
+	return newStoreInStep(s.userInfo, v)
+}
+
+func (s *storeUserInfo) As(name string) IStep {
+	return newStoreAsStep(s.userInfo, name)
 }
 
 func (s *storeUserInfo) Username() IStoreStep {
@@ -1,6 +1,121 @@
 package hit
 
+import (
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
 // TestingT is the minimum interface that is required for Test().
+//
+// If the passed in TestingT also implements Helper(), Fatalf(), Errorf() or Log() (like *testing.T does) these
+// functions will be used to report failures and to print Debug() output.
 type TestingT interface {
 	FailNow()
 }
+
+type testingHelper interface {
+	Helper()
+}
+
+type testingFatalf interface {
+	Fatalf(format string, args ...interface{})
+}
+
+type testingErrorf interface {
+	Errorf(format string, args ...interface{})
+}
+
+type testingLog interface {
+	Log(args ...interface{})
+}
+
+type testingRun interface {
+	Run(name string, f func(t *testing.T)) bool
+}
+
+func helper(t TestingT) {
+	if h, ok := t.(testingHelper); ok {
+		h.Helper()
+	}
+}
+
+// report reports the specified error (if not nil) to the TestingT and stops the test.
+func report(t TestingT, err error) {
+	helper(t)
+	if err == nil {
+		return
+	}
+	switch v := t.(type) {
+	case testingFatalf:
+		v.Fatalf("%s", err.Error())
+	case testingErrorf:
+		v.Errorf("%s", err.Error())
+		t.FailNow()
+	default:
+		_, _ = os.Stderr.WriteString(err.Error())
+		t.FailNow()
+	}
+}
+
+// testingOutput returns a writer that writes to the log of the TestingT, it returns nil if TestingT has no Log
+// function.
+func testingOutput(t TestingT) io.Writer {
+	if l, ok := t.(testingLog); ok {
+		return &testingLogWriter{t: t, log: l}
+	}
+	return nil
+}
+
+type testingLogWriter struct {
+	t   TestingT
+	log testingLog
+}
+
+func (w *testingLogWriter) Write(p []byte) (int, error) {
+	helper(w.t)
+	if s := strings.TrimSuffix(string(p), "\n"); s != "" {
+		w.log.Log(s)
+	}
+	return len(p), nil
+}
+
+// TestCases runs each of the specified step lists as a subtest (using t.Run()), the name of each subtest is the key
+// of the map. The subtests will run in sorted order.
+//
+// If t does not support subtests all step lists will be run one after another.
+//
+// Example:
+//     TestCases(t, map[string][]IStep{
+//         "index": {
+//             Get("https://example.com/index.html"),
+//             Expect().Status().Equal(http.StatusOK),
+//         },
+//         "json": {
+//             Get("https://example.com/json"),
+//             Expect().Headers("Content-Type").Equal("application/json"),
+//         },
+//     })
+func TestCases(t TestingT, cases map[string][]IStep) {
+	helper(t)
+	names := make([]string, 0, len(cases))
+	for name := range cases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	runner, ok := t.(testingRun)
+	for _, name := range names {
+		steps := cases[name]
+		if !ok {
+			Test(t, append([]IStep{Description(name)}, steps...)...)
+			continue
+		}
+		runner.Run(name, func(t *testing.T) {
+			t.Helper()
+			Test(t, steps...)
+		})
+	}
+}
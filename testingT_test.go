@@ -0,0 +1,155 @@
+package hit_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+type minimalT struct {
+	failed bool
+}
+
+func (t *minimalT) FailNow() {
+	t.failed = true
+}
+
+type fullT struct {
+	minimalT
+	helperCalls int
+	fatal       []string
+	logs        []string
+}
+
+func (t *fullT) Helper() {
+	t.helperCalls++
+}
+
+func (t *fullT) Fatalf(format string, args ...interface{}) {
+	t.fatal = append(t.fatal, fmt.Sprintf(format, args...))
+	t.FailNow()
+}
+
+func (t *fullT) Log(args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprint(args...))
+}
+
+type errorfT struct {
+	minimalT
+	errors []string
+}
+
+func (t *errorfT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestTestingT(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	t.Run("minimal", func(t *testing.T) {
+		var mt minimalT
+		Test(&mt,
+			Post(s.URL),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.False(t, mt.failed)
+
+		Test(&mt,
+			Post(s.URL),
+			Expect().Status().Equal(http.StatusNotFound),
+		)
+		require.True(t, mt.failed)
+	})
+
+	t.Run("fatalf", func(t *testing.T) {
+		var ft fullT
+		Test(&ft,
+			Post(s.URL),
+			Expect().Status().Equal(http.StatusNotFound),
+		)
+		require.True(t, ft.failed)
+		require.Len(t, ft.fatal, 1)
+		require.Contains(t, ft.fatal[0], "not equal")
+		require.NotZero(t, ft.helperCalls)
+	})
+
+	t.Run("errorf", func(t *testing.T) {
+		var et errorfT
+		Test(&et,
+			Post(s.URL),
+			Expect().Status().Equal(http.StatusNotFound),
+		)
+		require.True(t, et.failed)
+		require.Len(t, et.errors, 1)
+		require.Contains(t, et.errors[0], "not equal")
+	})
+
+	t.Run("debug output", func(t *testing.T) {
+		var ft fullT
+		Test(&ft,
+			Post(s.URL),
+			Send().Body().String("Hello World"),
+			Debug().Response().Body(),
+		)
+		require.False(t, ft.failed)
+		require.Equal(t, []string{"Hello World"}, ft.logs)
+	})
+
+	t.Run("session", func(t *testing.T) {
+		var ft fullT
+		NewSession(BaseURL(s.URL)).Test(&ft,
+			Post("/"),
+			Send().Body().String("Hello World"),
+			Debug().Response().Body(),
+			Expect().Status().Equal(http.StatusNotFound),
+		)
+		require.True(t, ft.failed)
+		require.Len(t, ft.fatal, 1)
+		require.Equal(t, []string{"Hello World"}, ft.logs)
+	})
+}
+
+func TestTestCases(t *testing.T) {
+	s := EchoServer()
+	defer s.Close()
+
+	var names []string
+	TestCases(t, map[string][]IStep{
+		"b": {
+			Post(s.URL),
+			Send().Body().String("b"),
+			Expect().Custom(func(hit Hit) error {
+				names = append(names, hit.Response().Body().MustString())
+				return nil
+			}),
+		},
+		"a": {
+			Post(s.URL),
+			Send().Body().String("a"),
+			Expect().Custom(func(hit Hit) error {
+				names = append(names, hit.Response().Body().MustString())
+				return nil
+			}),
+		},
+	})
+	require.Equal(t, []string{"a", "b"}, names)
+
+	t.Run("without subtests", func(t *testing.T) {
+		var ft fullT
+		TestCases(&ft, map[string][]IStep{
+			"failing case": {
+				Post(s.URL),
+				Expect().Status().Equal(http.StatusNotFound),
+			},
+		})
+		require.True(t, ft.failed)
+		require.Len(t, ft.fatal, 1)
+		require.True(t, strings.Contains(ft.fatal[0], "failing case"))
+	})
+}
@@ -0,0 +1,131 @@
+package hit
+
+import (
+	"crypto/tls"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing contains the timing breakdown of a request.
+// If redirects were followed the DNS, Connect and TLSHandshake durations of all requests are summed up.
+type Timing struct {
+	// DNS is the time spent for the dns lookup, it is zero if no lookup was necessary (e.g. a connection was reused).
+	DNS time.Duration
+	// Connect is the time spent to establish the connection, it is zero if a connection was reused.
+	Connect time.Duration
+	// TLSHandshake is the time spent for the tls handshake, it is zero for plain http requests.
+	TLSHandshake time.Duration
+	// TimeToFirstByte is the time from sending the request until the first byte of the response was received.
+	TimeToFirstByte time.Duration
+	// BodyRead is the time spent to read the response body after the first byte was received.
+	BodyRead time.Duration
+	// Total is the time from sending the request until the response body was read completely.
+	Total time.Duration
+}
+
+// timingRecorder records the timings of a request using httptrace.
+type timingRecorder struct {
+	mu        sync.Mutex
+	started   time.Time
+	firstByte time.Time
+	bodyRead  time.Time
+
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	timing       Timing
+}
+
+func newTimingRecorder() *timingRecorder {
+	return &timingRecorder{
+		started: time.Now(),
+	}
+}
+
+// withClientTrace returns a copy of the request that reports to the recorder.
+func (r *timingRecorder) withClientTrace(req *http.Request) *http.Request {
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			r.mu.Lock()
+			r.dnsStart = time.Now()
+			r.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			r.mu.Lock()
+			r.timing.DNS += time.Since(r.dnsStart)
+			r.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			r.mu.Lock()
+			if r.connectStart.IsZero() {
+				r.connectStart = time.Now()
+			}
+			r.mu.Unlock()
+		},
+		ConnectDone: func(_, _ string, err error) {
+			r.mu.Lock()
+			// multiple addresses might be dialed in parallel, only count the successful one
+			if err == nil && !r.connectStart.IsZero() {
+				r.timing.Connect += time.Since(r.connectStart)
+				r.connectStart = time.Time{}
+			}
+			r.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			r.mu.Lock()
+			r.tlsStart = time.Now()
+			r.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			r.mu.Lock()
+			r.timing.TLSHandshake += time.Since(r.tlsStart)
+			r.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			r.mu.Lock()
+			r.firstByte = time.Now()
+			r.mu.Unlock()
+		},
+	}))
+}
+
+// readBody reads the body completely and records the time when it was read, the body will be closed.
+func (r *timingRecorder) readBody(body io.ReadCloser) ([]byte, error) {
+	if body == nil {
+		r.markBodyRead()
+		return nil, nil
+	}
+	buf, err := ioutil.ReadAll(body)
+	r.markBodyRead()
+	if closeErr := body.Close(); err == nil {
+		err = closeErr
+	}
+	return buf, err
+}
+
+func (r *timingRecorder) markBodyRead() {
+	r.mu.Lock()
+	if r.bodyRead.IsZero() {
+		r.bodyRead = time.Now()
+	}
+	r.mu.Unlock()
+}
+
+// Timing returns the recorded timings, the body must be read (using readBody) before calling this function.
+func (r *timingRecorder) Timing() Timing {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.timing
+	firstByte := r.firstByte
+	if firstByte.IsZero() {
+		firstByte = r.bodyRead
+	}
+	t.TimeToFirstByte = firstByte.Sub(r.started)
+	t.BodyRead = r.bodyRead.Sub(firstByte)
+	t.Total = r.bodyRead.Sub(r.started)
+	return t
+}
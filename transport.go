@@ -0,0 +1,203 @@
+package hit
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+
+	"golang.org/x/xerrors"
+)
+
+// clonedTransport is a copy of a *http.Transport that was created by modifyTransport.
+type clonedTransport struct {
+	original *http.Transport
+	clone    *http.Transport
+}
+
+// ownedTransport returns the copy that was created for the specified transport during this Do call, if the
+// transport is a copy itself it will be returned. It returns nil if there is no copy.
+func (hit *hitImpl) ownedTransport(transport *http.Transport) *http.Transport {
+	for _, t := range hit.transports {
+		if t.clone == transport || t.original == transport {
+			return t.clone
+		}
+	}
+	return nil
+}
+
+// closeTransports closes the idle connections of all transports that were copied during this Do call.
+func (hit *hitImpl) closeTransports() {
+	for _, t := range hit.transports {
+		t.clone.CloseIdleConnections()
+	}
+	hit.transports = nil
+}
+
+// modifyTransport applies the specified modification to a copy of the *http.Transport that is used by the current
+// http.Client. If the client uses http.DefaultTransport (or no transport) a copy of http.DefaultTransport will be
+// modified. The original client and transport will not be modified, so steps like TLSConfig() or
+// InsecureSkipVerify() keep all other client settings.
+// The transport will only be copied once per Do call, so every attempt (see Retry()) uses the same copy, the
+// modification must therefore produce the same result if it is applied multiple times.
+func modifyTransport(hit *hitImpl, modify func(transport *http.Transport) error) error {
+	rt := hit.client.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	original, ok := rt.(*http.Transport)
+	if !ok {
+		return xerrors.Errorf("unable to modify the transport: transport must be a *http.Transport, but was %T", rt)
+	}
+	transport := hit.ownedTransport(original)
+	if transport == nil {
+		transport = original.Clone()
+		hit.transports = append(hit.transports, clonedTransport{
+			original: original,
+			clone:    transport,
+		})
+	}
+	if err := modify(transport); err != nil {
+		return err
+	}
+	if hit.client.Transport != transport {
+		client := *hit.client
+		client.Transport = transport
+		hit.client = &client
+	}
+	return nil
+}
+
+// modifyTLSConfig applies the specified modification to the tls configuration of a copied transport, see
+// modifyTransport.
+func modifyTLSConfig(hit *hitImpl, modify func(config *tls.Config) error) error {
+	return modifyTransport(hit, func(transport *http.Transport) error {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{} //nolint:gosec // the version will be negotiated
+		}
+		return modify(transport.TLSClientConfig)
+	})
+}
+
+// TLSConfig sets the tls configuration that will be used for the request.
+//
+// Usage:
+//     TLSConfig(&tls.Config{MinVersion: tls.VersionTLS12})
+func TLSConfig(config *tls.Config) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("TLSConfig", nil),
+		Exec: func(hit *hitImpl) error {
+			return modifyTransport(hit, func(transport *http.Transport) error {
+				transport.TLSClientConfig = config.Clone()
+				return nil
+			})
+		},
+	}
+}
+
+// ClientCertificate sets the certificate that will be presented to the server (e.g. for mutual tls).
+// Both, the certificate and the private key must be PEM encoded.
+//
+// Usage:
+//     certPEM, _ := ioutil.ReadFile("client.crt")
+//     keyPEM, _ := ioutil.ReadFile("client.key")
+//     ClientCertificate(certPEM, keyPEM)
+func ClientCertificate(certPEM, keyPEM []byte) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("ClientCertificate", nil),
+		Exec: func(hit *hitImpl) error {
+			certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return xerrors.Errorf("unable to load client certificate: %w", err)
+			}
+			return modifyTLSConfig(hit, func(config *tls.Config) error {
+				for _, c := range config.Certificates {
+					if len(c.Certificate) > 0 && bytes.Equal(c.Certificate[0], certificate.Certificate[0]) {
+						// already added (e.g. by a previous attempt)
+						return nil
+					}
+				}
+				config.Certificates = append(config.Certificates, certificate)
+				return nil
+			})
+		},
+	}
+}
+
+// RootCAs sets the root certificate authorities that will be used to verify the server certificate, the
+// certificates will be loaded from the specified PEM files. The system certificate pool will not be used.
+//
+// Usage:
+//     RootCAs("/etc/ssl/internal-ca.pem")
+//     RootCAs("/etc/ssl/internal-ca.pem", "/etc/ssl/partner-ca.pem")
+func RootCAs(pemFiles ...string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("RootCAs", nil),
+		Exec: func(hit *hitImpl) error {
+			pool := x509.NewCertPool()
+			for _, file := range pemFiles {
+				buf, err := ioutil.ReadFile(file)
+				if err != nil {
+					return xerrors.Errorf("unable to read root certificates: %w", err)
+				}
+				if !pool.AppendCertsFromPEM(buf) {
+					return xerrors.Errorf("unable to find any certificates in %s", file)
+				}
+			}
+			return modifyTLSConfig(hit, func(config *tls.Config) error {
+				config.RootCAs = pool
+				return nil
+			})
+		},
+	}
+}
+
+// InsecureSkipVerify disables the verification of the server certificate.
+// This should only be used for testing purposes.
+//
+// Example:
+//     MustDo(
+//         Get("https://example.com"),
+//         InsecureSkipVerify(),
+//     )
+func InsecureSkipVerify() IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("InsecureSkipVerify", nil),
+		Exec: func(hit *hitImpl) error {
+			return modifyTLSConfig(hit, func(config *tls.Config) error {
+				config.InsecureSkipVerify = true //nolint:gosec // requested by the user
+				return nil
+			})
+		},
+	}
+}
+
+// ServerName sets the server name that will be sent (SNI) and used to verify the server certificate.
+//
+// Example:
+//     MustDo(
+//         Get("https://127.0.0.1"),
+//         ServerName("example.com"),
+//     )
+func ServerName(sni string) IStep {
+	return &hitStep{
+		Trace:    ett.Prepare(),
+		When:     requestCreateStep,
+		CallPath: newCallPath("ServerName", nil),
+		Exec: func(hit *hitImpl) error {
+			return modifyTLSConfig(hit, func(config *tls.Config) error {
+				config.ServerName = sni
+				return nil
+			})
+		},
+	}
+}
@@ -0,0 +1,262 @@
+package hit_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+// generateClientCertificate generates a self signed client certificate and returns the certificate and the key as PEM.
+func generateClientCertificate(t *testing.T, commonName string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	cert, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+// writeServerCertificate writes the certificate of the server into a PEM file and returns the path to the file.
+func writeServerCertificate(t *testing.T, s *httptest.Server) string {
+	f, err := ioutil.TempFile("", "hit-root-ca-*.pem")
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+	return f.Name()
+}
+
+func CommonNameHandler() http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if len(request.TLS.PeerCertificates) == 0 {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = io.WriteString(writer, request.TLS.PeerCertificates[0].Subject.CommonName)
+	})
+}
+
+func TestClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateClientCertificate(t, "Joe")
+	block, _ := pem.Decode(certPEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	s := httptest.NewUnstartedServer(CommonNameHandler())
+	s.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	t.Run("with certificate", func(t *testing.T) {
+		client := s.Client()
+		Test(t,
+			Get(s.URL),
+			HTTPClient(client),
+			ClientCertificate(certPEM, keyPEM),
+			Expect().Body().String().Equal("Joe"),
+		)
+		require.Empty(t, client.Transport.(*http.Transport).TLSClientConfig.Certificates)
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		var transports []*http.Transport
+		attempts := 0
+		Test(t,
+			Get(s.URL),
+			HTTPClient(s.Client()),
+			ClientCertificate(certPEM, keyPEM),
+			Retry(RetryPolicy{MaxAttempts: 3, Interval: time.Millisecond}),
+			Expect().Custom(func(hit Hit) error {
+				transport := hit.HTTPClient().Transport.(*http.Transport)
+				transports = append(transports, transport)
+				require.Len(t, transport.TLSClientConfig.Certificates, 1)
+				if attempts++; attempts < 3 {
+					return errors.New("retry")
+				}
+				return nil
+			}),
+		)
+		require.Len(t, transports, 3)
+		// the transport is only copied once per Do
+		require.Equal(t, transports[0], transports[1])
+		require.Equal(t, transports[0], transports[2])
+	})
+
+	t.Run("without certificate", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				HTTPClient(s.Client()),
+			),
+			nil,
+		)
+	})
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				HTTPClient(s.Client()),
+				ClientCertificate(certPEM, []byte("invalid")),
+			),
+			PtrStr("unable to load client certificate: tls: failed to find any PEM data in key input"),
+		)
+	})
+}
+
+func TestTLSConfig(t *testing.T) {
+	s := httptest.NewTLSServer(CommonNameHandler())
+	defer s.Close()
+
+	certPEM, keyPEM := generateClientCertificate(t, "Joe")
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	Test(t,
+		Get(s.URL),
+		HTTPClient(s.Client()),
+		TLSConfig(&tls.Config{
+			RootCAs:      s.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs,
+			Certificates: []tls.Certificate{certificate},
+			MaxVersion:   tls.VersionTLS12,
+		}),
+		Expect().TLS().Version().Equal("TLS 1.2"),
+		Expect().Status().Equal(http.StatusUnauthorized),
+	)
+}
+
+func TestRootCAs(t *testing.T) {
+	s := httptest.NewTLSServer(CommonNameHandler())
+	defer s.Close()
+
+	path := writeServerCertificate(t, s)
+	defer os.Remove(path)
+
+	t.Run("valid", func(t *testing.T) {
+		Test(t,
+			Get(s.URL),
+			RootCAs(path),
+			Expect().TLS().Verified(),
+		)
+	})
+
+	t.Run("unknown authority", func(t *testing.T) {
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+			),
+			nil,
+		)
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		f, err := ioutil.TempFile("", "hit-root-ca-*.pem")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		defer os.Remove(f.Name())
+
+		ExpectError(t,
+			Do(
+				Get(s.URL),
+				RootCAs(f.Name()),
+			),
+			PtrStr("unable to find any certificates in "+f.Name()),
+		)
+	})
+}
+
+func TestInsecureSkipVerify(t *testing.T) {
+	s := httptest.NewTLSServer(CommonNameHandler())
+	defer s.Close()
+
+	Test(t,
+		Get(s.URL),
+		InsecureSkipVerify(),
+		Expect().Status().Equal(http.StatusUnauthorized),
+	)
+
+	// the default client should not be modified
+	require.Nil(t, http.DefaultClient.Transport)
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil {
+		require.False(t, config.InsecureSkipVerify)
+	}
+}
+
+func TestServerName(t *testing.T) {
+	s := httptest.NewTLSServer(CommonNameHandler())
+	defer s.Close()
+
+	Test(t,
+		Get(s.URL),
+		HTTPClient(s.Client()),
+		ServerName("example.com"),
+		Expect().TLS().Verified(),
+	)
+
+	ExpectError(t,
+		Do(
+			Get(s.URL),
+			HTTPClient(s.Client()),
+			ServerName("example.org"),
+		),
+		nil,
+	)
+}
+
+func TestModifyTransport_CloseIdleConnections(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	s.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			closed <- struct{}{}
+		}
+	}
+	s.StartTLS()
+	defer s.Close()
+
+	Test(t,
+		Get(s.URL),
+		InsecureSkipVerify(),
+		Expect().Status().Equal(http.StatusOK),
+	)
+
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("the connection of the copied transport was not closed")
+	}
+}
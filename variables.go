@@ -0,0 +1,223 @@
+package hit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
+// variables holds the named variables that can be used in placeholders (e.g. {{.userId}}).
+type variables struct {
+	mu     sync.RWMutex
+	values map[string]interface{}
+}
+
+func newVariables() *variables {
+	return &variables{
+		values: make(map[string]interface{}),
+	}
+}
+
+func (v *variables) Get(name string) (interface{}, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	value, ok := v.values[name]
+	return value, ok
+}
+
+func (v *variables) Set(name string, value interface{}) {
+	v.mu.Lock()
+	v.values[name] = value
+	v.mu.Unlock()
+}
+
+// copy returns a copy of all variables.
+func (v *variables) copy() map[string]interface{} {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	m := make(map[string]interface{}, len(v.values))
+	for k, value := range v.values {
+		m[k] = value
+	}
+	return m
+}
+
+// placeholderRegex matches a placeholder (e.g. {{.userId}}), a placeholder that is prefixed with a backslash
+// (e.g. \{{.userId}}) is an escaped literal.
+var placeholderRegex = regexp.MustCompile(`\\?\{\{\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// interpolate resolves all placeholders (e.g. {{.userId}}) in the specified string with the variables of the
+// current hit instance.
+// Other template-like strings (e.g. {{name}} or {{ .Values.name }}) are left untouched, prefix a placeholder with a
+// backslash to keep it literally (e.g. \{{.userId}} results in {{.userId}}).
+func (hit *hitImpl) interpolate(s string) (string, error) {
+	return hit.resolvePlaceholders(s, func(value interface{}) string {
+		return fmt.Sprint(value)
+	})
+}
+
+// resolvePlaceholders replaces all placeholders in the specified string with the result of replace.
+func (hit *hitImpl) resolvePlaceholders(s string, replace func(value interface{}) string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+
+	values := hit.variables.copy()
+	var err error
+	s = placeholderRegex.ReplaceAllStringFunc(s, func(placeholder string) string {
+		if placeholder[0] == '\\' {
+			return placeholder[1:]
+		}
+		if err != nil {
+			return placeholder
+		}
+		name := placeholderRegex.FindStringSubmatch(placeholder)[1]
+		value, ok := values[name]
+		if !ok {
+			err = xerrors.Errorf("unable to resolve placeholder %s: variable %q is not set", placeholder, name)
+			return placeholder
+		}
+		return replace(value)
+	})
+	if err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// interpolateURL parses the specified url and resolves its placeholders, the values will be escaped for the
+// component they are used in (e.g. a / in a path segment will be escaped as %2F).
+func (hit *hitImpl) interpolateURL(s string) (*url.URL, error) {
+	// replace the placeholders with tokens that survive the parsing, so the values can be escaped afterwards
+	var values []string
+	s, err := hit.resolvePlaceholders(s, func(value interface{}) string {
+		values = append(values, fmt.Sprint(value))
+		return urlToken(len(values) - 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || len(values) == 0 {
+		return u, err
+	}
+
+	replace := func(s string, escape func(string) string) string {
+		for i, value := range values {
+			s = strings.ReplaceAll(s, urlToken(i), escape(value))
+		}
+		return s
+	}
+	raw := func(s string) string {
+		return s
+	}
+
+	u.Scheme = replace(u.Scheme, raw)
+	u.Opaque = replace(u.Opaque, raw)
+	u.Host = replace(u.Host, raw)
+	if u.User != nil {
+		username := replace(u.User.Username(), raw)
+		if password, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(username, replace(password, raw))
+		} else {
+			u.User = url.User(username)
+		}
+	}
+	u.RawPath = replace(u.EscapedPath(), url.PathEscape)
+	if u.Path, err = url.PathUnescape(u.RawPath); err != nil {
+		return nil, err
+	}
+	u.RawQuery = replace(u.RawQuery, url.QueryEscape)
+	u.Fragment = replace(u.Fragment, raw)
+	return u, nil
+}
+
+func urlToken(i int) string {
+	return "hitplaceholder" + strconv.Itoa(i) + "x"
+}
+
+// interpolateJSON resolves all placeholders in the string values (and keys) of the specified json document.
+// A string value that consists only of one placeholder is replaced with the json representation of the variable, so
+// numbers, booleans, arrays and objects keep their type.
+func (hit *hitImpl) interpolateJSON(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte("{{")) {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != '"' {
+			buf.WriteByte(data[i])
+			continue
+		}
+
+		// find the end of the string literal
+		end := i + 1
+		for ; end < len(data) && data[end] != '"'; end++ {
+			if data[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(data) {
+			return nil, xerrors.New("unable to resolve placeholders: unterminated string in json")
+		}
+
+		literal := data[i : end+1]
+		i = end
+		if !bytes.Contains(literal, []byte("{{")) {
+			buf.Write(literal)
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(literal, &s); err != nil {
+			return nil, err
+		}
+
+		if !isJSONKey(data[end+1:]) {
+			if value, ok := hit.singlePlaceholderValue(s); ok {
+				literal, err := json.Marshal(value)
+				if err != nil {
+					return nil, err
+				}
+				buf.Write(literal)
+				continue
+			}
+		}
+
+		s, err := hit.interpolate(s)
+		if err != nil {
+			return nil, err
+		}
+		literal, err = json.Marshal(s)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(literal)
+	}
+	return buf.Bytes(), nil
+}
+
+// singlePlaceholderValue returns the value of the variable if the specified string consists only of one placeholder.
+func (hit *hitImpl) singlePlaceholderValue(s string) (interface{}, bool) {
+	m := placeholderRegex.FindStringSubmatchIndex(s)
+	if m == nil || m[0] != 0 || m[1] != len(s) || s[0] == '\\' {
+		return nil, false
+	}
+	return hit.variables.Get(s[m[2]:m[3]])
+}
+
+// isJSONKey reports whether the string literal that is followed by rest is an object key.
+func isJSONKey(rest []byte) bool {
+	rest = bytes.TrimLeft(rest, " \t\r\n")
+	return len(rest) > 0 && rest[0] == ':'
+}
@@ -0,0 +1,219 @@
+package hit_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/Eun/go-hit"
+)
+
+func TestVariables(t *testing.T) {
+	t.Run("store as", func(t *testing.T) {
+		s := PrintJSONServer(map[string]interface{}{"ID": 10, "Name": "Joe"})
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+		session.Test(t,
+			Get("/"),
+			Store().Response().Body().JSON().JQ(".ID").As("userId"),
+			Store().Response().Body().JSON().JQ(".Name").As("userName"),
+			Store().Response().Body().JSON().As("user"),
+			Store().Response().Headers("Content-Type").As("contentType"),
+			Store().Request().URL().Host().As("host"),
+		)
+
+		v, ok := session.Variable("userId")
+		require.True(t, ok)
+		require.Equal(t, float64(10), v)
+
+		v, ok = session.Variable("userName")
+		require.True(t, ok)
+		require.Equal(t, "Joe", v)
+
+		v, ok = session.Variable("user")
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"ID": float64(10), "Name": "Joe"}, v)
+
+		v, ok = session.Variable("contentType")
+		require.True(t, ok)
+		require.Equal(t, "text/plain; charset=utf-8", v)
+
+		_, ok = session.Variable("host")
+		require.True(t, ok)
+	})
+
+	t.Run("interpolation", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("userId", 10)
+		session.SetVariable("userName", `Joe "The Man"`)
+
+		session.Test(t,
+			Post("/users/{{.userId}}"),
+			Request().URL().Query("name").Add("{{.userName}}"),
+			Send().Headers("X-User").Add("{{.userId}}"),
+			Send().Body().String("Hello {{.userName}}"),
+			Expect().Headers("X-User").Equal("10"),
+			Expect().Body().String().Equal(`Hello Joe "The Man"`),
+			Expect().Custom(func(hit Hit) error {
+				require.Equal(t, "/users/10", hit.Request().URL.Path)
+				require.Equal(t, `Joe "The Man"`, hit.Request().URL.Query().Get("name"))
+				return nil
+			}),
+		)
+
+		session.Test(t,
+			Post("/"),
+			Send().Body().JSON(map[string]interface{}{
+				"ID":   "{{.userId}}",
+				"Name": "{{.userName}}",
+				"Tags": []string{"{{.userId}}", "plain"},
+			}),
+			Expect().Body().JSON().Equal(map[string]interface{}{
+				"ID":   10,
+				"Name": `Joe "The Man"`,
+				"Tags": []interface{}{10, "plain"},
+			}),
+		)
+
+		session.Test(t,
+			Post("/"),
+			Send().Body().JSON(map[string]interface{}{
+				"{{.userId}}": "id {{.userId}}",
+			}),
+			Expect().Body().JSON().Equal(map[string]interface{}{
+				"10": "id 10",
+			}),
+		)
+	})
+
+	t.Run("template-like strings are kept", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String(`Hello {{name}} {{ .Values.x }} \{{.userId}}`),
+			Expect().Body().String().Equal("Hello {{name}} {{ .Values.x }} {{.userId}}"),
+		)
+
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("userId", 10)
+		session.Test(t,
+			Post("/"),
+			Send().Body().String("Hello {{name}} {{ .Values.x }} {{.userId}}"),
+			Expect().Body().String().Equal("Hello {{name}} {{ .Values.x }} 10"),
+		)
+		session.Test(t,
+			Post("/"),
+			Send().Body().JSON(map[string]interface{}{
+				"Chart": "{{ .Values.x }}",
+			}),
+			Expect().Body().JSON().Equal(map[string]interface{}{
+				"Chart": "{{ .Values.x }}",
+			}),
+		)
+	})
+
+	t.Run("escaped placeholder", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("userId", 10)
+		session.Test(t,
+			Post("/"),
+			Send().Body().String(`\{{.userId}} {{.userId}}`),
+			Expect().Body().String().Equal("{{.userId}} 10"),
+		)
+		session.Test(t,
+			Post("/"),
+			Send().Body().JSON(map[string]interface{}{
+				"ID": `\{{.unknown}}`,
+			}),
+			Expect().Body().JSON().Equal(map[string]interface{}{
+				"ID": "{{.unknown}}",
+			}),
+		)
+	})
+
+	t.Run("variables set in the same step list", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		Test(t,
+			Post(s.URL),
+			Send().Body().String("Joe"),
+			Store().Response().Body().String().As("name"),
+			Custom(AfterExpectStep, func(hit Hit) error {
+				v, ok := hit.Variable("name")
+				require.True(t, ok)
+				require.Equal(t, "Joe", v)
+				return nil
+			}),
+		)
+	})
+
+	t.Run("url values are escaped", func(t *testing.T) {
+		var path, rawQuery string
+		s := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			path = request.URL.EscapedPath()
+			rawQuery = request.URL.RawQuery
+		}))
+		defer s.Close()
+
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("name", "a b/c?d")
+		session.Test(t,
+			Get("/users/{{.name}}/posts?q={{.name}}&page=1"),
+			Expect().Status().Equal(http.StatusOK),
+			Expect().Custom(func(hit Hit) error {
+				require.Equal(t, "/users/a b/c?d/posts", hit.Request().URL.Path)
+				require.Equal(t, "a b/c?d", hit.Request().URL.Query().Get("q"))
+				return nil
+			}),
+		)
+		require.Equal(t, "/users/a%20b%2Fc%3Fd/posts", path)
+		require.Equal(t, "q=a+b%2Fc%3Fd&page=1", rawQuery)
+	})
+
+	t.Run("unresolved variable", func(t *testing.T) {
+		s := EchoServer()
+		defer s.Close()
+
+		// no variables are set
+		err := Do(
+			Get(s.URL+"/{{.id}}"),
+		)
+		ExpectError(t, err,
+			PtrStr(`unable to resolve placeholder {{.id}}: variable "id" is not set`),
+		)
+
+		session := NewSession(BaseURL(s.URL))
+		session.SetVariable("userName", "Joe")
+
+		err = session.Do(
+			Post("/"),
+			Send().Headers("X-User").Add("{{.userId}}"),
+		)
+		ExpectError(t, err,
+			PtrStr(`unable to resolve placeholder {{.userId}}: variable "userId" is not set`),
+		)
+
+		err = session.Do(
+			Get(s.URL+"/{{.userId}}"),
+			Expect().Status().Equal(http.StatusOK),
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `variable "userId" is not set`)
+		var hitError *Error
+		require.True(t, errors.As(err, &hitError))
+		require.True(t, hitError.FailingStepIs(Get(s.URL+"/{{.userId}}")))
+	})
+}